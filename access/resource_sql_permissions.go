@@ -348,7 +348,8 @@ func ResourceSqlPermissions() common.Resource {
 			return nil
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			ta, err := tableAclForLoad(ctx, d, c)
+			// reading grants executes read-only `SHOW GRANT` commands on the cluster
+			ta, err := tableAclForLoad(common.WithReadOnlyCommands(ctx), d, c)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,89 @@
+package catalog
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/databricks/terraform-provider-databricks/catalog/permissions"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// EffectivePrivilege is a privilege of the principal, either assigned directly or inherited
+type EffectivePrivilege struct {
+	Privilege         string `json:"privilege"`
+	InheritedFromType string `json:"inherited_from_type,omitempty"`
+	InheritedFromName string `json:"inherited_from_name,omitempty"`
+}
+
+// EffectivePrivilegeAssignment reflects on computed `grant` block
+type EffectivePrivilegeAssignment struct {
+	Principal  string               `json:"principal"`
+	Privileges []EffectivePrivilege `json:"privileges"`
+}
+
+type effectiveGrantsData struct {
+	Principal   string                         `json:"principal,omitempty"`
+	Assignments []EffectivePrivilegeAssignment `json:"grant,omitempty" tf:"computed"`
+}
+
+func DataSourceEffectiveGrants() common.Resource {
+	s := common.StructToSchema(effectiveGrantsData{}, func(m map[string]*schema.Schema) map[string]*schema.Schema {
+		fields := []string{}
+		for field := range permissions.Mappings {
+			fields = append(fields, field)
+		}
+		for field := range permissions.Mappings {
+			m[field] = &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: fields,
+			}
+		}
+		return m
+	})
+	return common.Resource{
+		Schema: s,
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var data effectiveGrantsData
+			common.DataToStructPointer(d, s, &data)
+			securable, name := permissions.Mappings.KeyValue(d)
+			assignments, err := permissions.NewUnityCatalogPermissionsAPI(ctx, c).GetEffectivePermissions(
+				permissions.Mappings.GetSecurableType(securable), name, data.Principal)
+			if err != nil {
+				return err
+			}
+			// privileges of the same principal may come on different pages
+			privileges := map[string][]EffectivePrivilege{}
+			for _, v := range assignments {
+				merged := privileges[v.Principal]
+				for _, p := range v.Privileges {
+					merged = append(merged, EffectivePrivilege{
+						Privilege:         p.Privilege.String(),
+						InheritedFromType: p.InheritedFromType.String(),
+						InheritedFromName: p.InheritedFromName,
+					})
+				}
+				privileges[v.Principal] = merged
+			}
+			data.Assignments = []EffectivePrivilegeAssignment{}
+			for principal, v := range privileges {
+				slices.SortFunc(v, func(a, b EffectivePrivilege) int {
+					return cmp.Or(cmp.Compare(a.Privilege, b.Privilege),
+						cmp.Compare(a.InheritedFromType, b.InheritedFromType),
+						cmp.Compare(a.InheritedFromName, b.InheritedFromName))
+				})
+				data.Assignments = append(data.Assignments, EffectivePrivilegeAssignment{
+					Principal:  principal,
+					Privileges: slices.Compact(v),
+				})
+			}
+			slices.SortFunc(data.Assignments, func(a, b EffectivePrivilegeAssignment) int {
+				return cmp.Compare(a.Principal, b.Principal)
+			})
+			d.SetId(permissions.Mappings.Id(d))
+			return common.StructToData(data, s, d)
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/databricks-sdk-go/service/sharing"
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+func TestEffectiveGrantsData(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/effective-permissions/table/main.sales.orders?principal=analysts",
+				Response: catalog.EffectivePermissionsList{
+					PrivilegeAssignments: []catalog.EffectivePrivilegeAssignment{
+						{
+							Principal: "analysts",
+							Privileges: []catalog.EffectivePrivilege{
+								{
+									Privilege:         "SELECT",
+									InheritedFromType: "SCHEMA",
+									InheritedFromName: "main.sales",
+								},
+							},
+						},
+					},
+					NextPageToken: "next",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/effective-permissions/table/main.sales.orders?page_token=next&principal=analysts",
+				Response: catalog.EffectivePermissionsList{
+					PrivilegeAssignments: []catalog.EffectivePrivilegeAssignment{
+						{
+							Principal: "analysts",
+							Privileges: []catalog.EffectivePrivilege{
+								{
+									Privilege: "MODIFY",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceEffectiveGrants(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		table = "main.sales.orders"
+		principal = "analysts"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                             "table/main.sales.orders",
+		"grant.#":                        1,
+		"grant.0.principal":              "analysts",
+		"grant.0.privileges.#":           2,
+		"grant.0.privileges.0.privilege": "MODIFY",
+		"grant.0.privileges.0.inherited_from_name": "",
+		"grant.0.privileges.1.privilege":           "SELECT",
+		"grant.0.privileges.1.inherited_from_type": "SCHEMA",
+		"grant.0.privileges.1.inherited_from_name": "main.sales",
+	})
+}
+
+func TestEffectiveGrantsData_Share(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/shares/myshare/permissions?",
+				Response: sharing.GetSharePermissionsResponse{
+					PrivilegeAssignments: []sharing.PrivilegeAssignment{
+						{
+							Principal:  "recipient",
+							Privileges: []sharing.Privilege{"SELECT"},
+						},
+						{
+							Principal:  "other",
+							Privileges: []sharing.Privilege{"SELECT"},
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceEffectiveGrants(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		share = "myshare"
+		principal = "recipient"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"grant.#":                        1,
+		"grant.0.principal":              "recipient",
+		"grant.0.privileges.0.privilege": "SELECT",
+	})
+}
+
+func TestEffectiveGrantsData_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures:    qa.HTTPFailures,
+		Resource:    DataSourceEffectiveGrants(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `catalog = "main"`,
+	}.ExpectError(t, "i'm a teapot")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/databricks/databricks-sdk-go/service/catalog"
 	"github.com/databricks/databricks-sdk-go/service/sharing"
 	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -49,6 +51,44 @@ func (a UnityCatalogPermissionsAPI) GetPermissions(securable catalog.SecurableTy
 	return
 }
 
+// GetEffectivePermissions returns direct and inherited permissions on the securable, optionally filtered by principal.
+// Shares don't support inheritance, so only direct permissions are returned for them.
+func (a UnityCatalogPermissionsAPI) GetEffectivePermissions(securable catalog.SecurableType, name, principal string) (list []catalog.EffectivePrivilegeAssignment, err error) {
+	if securable.String() == "share" {
+		direct, err := a.GetPermissions(securable, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, pa := range direct.PrivilegeAssignments {
+			if principal != "" && pa.Principal != principal {
+				continue
+			}
+			assignment := catalog.EffectivePrivilegeAssignment{Principal: pa.Principal}
+			for _, p := range pa.Privileges {
+				assignment.Privileges = append(assignment.Privileges, catalog.EffectivePrivilege{Privilege: p})
+			}
+			list = append(list, assignment)
+		}
+		return list, nil
+	}
+	request := catalog.GetEffectiveRequest{
+		SecurableType: securable.String(),
+		FullName:      name,
+		Principal:     principal,
+	}
+	for {
+		page, err := a.client.Grants.GetEffective(a.context, request)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, page.PrivilegeAssignments...)
+		if page.NextPageToken == "" {
+			return list, nil
+		}
+		request.PageToken = page.NextPageToken
+	}
+}
+
 func (a UnityCatalogPermissionsAPI) UpdatePermissions(securable catalog.SecurableType, name string, diff []catalog.PermissionsChange) error {
 	if securable.String() == "share" {
 		var shareDiff []sharing.PermissionsChange
@@ -144,11 +184,125 @@ var Mappings = SecurableMapping{
 	"volume":             catalog.SecurableType("volume"),
 }
 
+// Privileges is the matrix of valid privileges for each securable in Mappings.
+// Securables without an entry are not validated.
+// See https://docs.databricks.com/en/data-governance/unity-catalog/manage-privileges/privileges.html
+var Privileges = map[string][]string{
+	"catalog": {"ALL_PRIVILEGES", "APPLY_TAG", "BROWSE", "CREATE_FUNCTION", "CREATE_MATERIALIZED_VIEW",
+		"CREATE_MODEL", "CREATE_MODEL_VERSION", "CREATE_SCHEMA", "CREATE_TABLE", "CREATE_VOLUME", "EXECUTE",
+		"EXTERNAL_USE_SCHEMA", "MANAGE", "MODIFY", "READ_VOLUME", "REFRESH", "SELECT", "USE_CATALOG", "USE_SCHEMA",
+		"WRITE_VOLUME"},
+	"credential": {"ACCESS", "ALL_PRIVILEGES", "CREATE_EXTERNAL_LOCATION", "CREATE_EXTERNAL_TABLE", "MANAGE",
+		"READ_FILES", "WRITE_FILES"},
+	"external_location": {"ALL_PRIVILEGES", "BROWSE", "CREATE_EXTERNAL_TABLE", "CREATE_EXTERNAL_VOLUME",
+		"CREATE_FOREIGN_CATALOG", "CREATE_FOREIGN_SECURABLE", "CREATE_MANAGED_STORAGE", "EXTERNAL_USE_LOCATION",
+		"MANAGE", "READ_FILES", "WRITE_FILES"},
+	"foreign_connection": {"ALL_PRIVILEGES", "CREATE_FOREIGN_CATALOG", "CREATE_FOREIGN_SECURABLE", "MANAGE",
+		"USE_CONNECTION"},
+	"function": {"ALL_PRIVILEGES", "APPLY_TAG", "EXECUTE", "MANAGE"},
+	"metastore": {"CREATE_CATALOG", "CREATE_CLEAN_ROOM", "CREATE_CONNECTION", "CREATE_EXTERNAL_LOCATION",
+		"CREATE_PROVIDER", "CREATE_RECIPIENT", "CREATE_SERVICE_CREDENTIAL", "CREATE_SHARE",
+		"CREATE_STORAGE_CREDENTIAL", "MANAGE_ALLOWLIST", "SET_SHARE_PERMISSION", "USE_MARKETPLACE_ASSETS",
+		"USE_PROVIDER", "USE_RECIPIENT", "USE_SHARE"},
+	"model": {"ALL_PRIVILEGES", "APPLY_TAG", "EXECUTE", "MANAGE"},
+	"schema": {"ALL_PRIVILEGES", "APPLY_TAG", "CREATE_FUNCTION", "CREATE_MATERIALIZED_VIEW", "CREATE_MODEL",
+		"CREATE_MODEL_VERSION", "CREATE_TABLE", "CREATE_VOLUME", "EXECUTE", "EXTERNAL_USE_SCHEMA", "MANAGE",
+		"MODIFY", "READ_VOLUME", "REFRESH", "SELECT", "USE_SCHEMA", "WRITE_VOLUME"},
+	"share":              {"SELECT"},
+	"storage_credential": {"ALL_PRIVILEGES", "CREATE_EXTERNAL_LOCATION", "CREATE_EXTERNAL_TABLE", "MANAGE", "READ_FILES", "WRITE_FILES"},
+	"table":              {"ALL_PRIVILEGES", "APPLY_TAG", "MANAGE", "MODIFY", "REFRESH", "SELECT"},
+	"volume":             {"ALL_PRIVILEGES", "APPLY_TAG", "MANAGE", "READ_VOLUME", "WRITE_VOLUME"},
+}
+
+// DeprecatedPrivileges are privileges from the earlier versions of Unity Catalog, that are still
+// accepted on some securables, mapped to their replacements
+var DeprecatedPrivileges = map[string]map[string]string{
+	"catalog": {
+		"CREATE": "CREATE_SCHEMA",
+		"USAGE":  "USE_CATALOG",
+	},
+	"external_location": {
+		"CREATE_TABLE":        "CREATE_EXTERNAL_TABLE",
+		"READ_PRIVATE_FILES":  "READ_FILES",
+		"WRITE_PRIVATE_FILES": "WRITE_FILES",
+	},
+	"schema": {
+		"CREATE": "CREATE_TABLE",
+		"USAGE":  "USE_SCHEMA",
+	},
+	"storage_credential": {
+		"CREATE_TABLE":        "CREATE_EXTERNAL_TABLE",
+		"READ_PRIVATE_FILES":  "READ_FILES",
+		"WRITE_PRIVATE_FILES": "WRITE_FILES",
+	},
+}
+
+// configuredSecurable returns the securable attribute, that is set in the resource. The raw configuration
+// is checked first, because the name of the securable is often unknown during plan.
+func (sm SecurableMapping) configuredSecurable(d attributeGetter) string {
+	if rc, ok := d.(interface{ GetRawConfig() cty.Value }); ok {
+		raw := rc.GetRawConfig()
+		if !raw.IsNull() && raw.IsKnown() && raw.Type().IsObjectType() {
+			for field := range sm {
+				if raw.Type().HasAttribute(field) && !raw.GetAttr(field).IsNull() {
+					return field
+				}
+			}
+		}
+	}
+	for field := range sm {
+		if v, ok := d.Get(field).(string); ok && v != "" {
+			return field
+		}
+	}
+	return ""
+}
+
+// ValidatePrivileges checks that privileges of the principal are valid for the securable, that
+// is configured in the resource
+func (sm SecurableMapping) ValidatePrivileges(d attributeGetter, principal string, privileges []string) error {
+	securable := sm.configuredSecurable(d)
+	if securable == "" {
+		return nil
+	}
+	return ValidateSecurablePrivileges(securable, principal, privileges)
+}
+
+// ValidateSecurablePrivileges checks that privileges of the principal are valid for the securable
+func ValidateSecurablePrivileges(securable, principal string, privileges []string) error {
+	valid, ok := Privileges[securable]
+	if !ok {
+		return nil
+	}
+	for _, privilege := range privileges {
+		normalized := NormalizePrivilege(privilege)
+		if normalized == "" || slices.Contains(valid, normalized) {
+			continue
+		}
+		if _, ok := DeprecatedPrivileges[securable][normalized]; ok {
+			continue
+		}
+		return fmt.Errorf("privilege %s granted to %s is not valid for %s, valid privileges are: %s",
+			normalized, principal, securable, strings.Join(valid, ", "))
+	}
+	return nil
+}
+
 // Unity Catalog accepts privileges with spaces, but will automatically convert them to underscores
 func NormalizePrivilege(privilege string) string {
 	return strings.ToUpper(strings.Replace(privilege, " ", "_", -1))
 }
 
+// NormalizeSecurablePrivilege normalizes the privilege and replaces a deprecated alias with the privilege,
+// that Unity Catalog returns for the securable instead, i.e. USAGE with USE_SCHEMA on a schema
+func NormalizeSecurablePrivilege(securable, privilege string) string {
+	normalized := NormalizePrivilege(privilege)
+	if replacement, ok := DeprecatedPrivileges[securable][normalized]; ok {
+		return replacement
+	}
+	return normalized
+}
+
 // Utils for Slice and Set
 func SliceToSet(in []catalog.Privilege) *schema.Set {
 	var out []any
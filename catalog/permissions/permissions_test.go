@@ -0,0 +1,95 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSecurablePrivileges(t *testing.T) {
+	for _, tc := range []struct {
+		securable string
+		valid     []string
+		invalid   []string
+	}{
+		{
+			securable: "metastore",
+			valid:     []string{"CREATE_CATALOG", "CREATE_CONNECTION", "CREATE_STORAGE_CREDENTIAL", "USE_SHARE"},
+			invalid:   []string{"ALL_PRIVILEGES", "USE_CATALOG"},
+		},
+		{
+			securable: "catalog",
+			valid:     []string{"ALL_PRIVILEGES", "USE_CATALOG", "CREATE_SCHEMA", "SELECT", "use catalog", "USAGE", "CREATE"},
+			invalid:   []string{"CREATE_CONNECTION", "CREATE_STORAGE_CREDENTIAL", "READ_FILES"},
+		},
+		{
+			securable: "credential",
+			valid:     []string{"ACCESS", "ALL_PRIVILEGES", "CREATE_EXTERNAL_LOCATION", "MANAGE", "READ_FILES"},
+			invalid:   []string{"CREATE_CONNECTION", "CREATE_STORAGE_CREDENTIAL", "USE_CATALOG"},
+		},
+		{
+			securable: "storage_credential",
+			valid:     []string{"CREATE_EXTERNAL_LOCATION", "READ_FILES", "READ_PRIVATE_FILES"},
+			invalid:   []string{"ACCESS", "CREATE_STORAGE_CREDENTIAL"},
+		},
+		{
+			securable: "external_location",
+			valid:     []string{"CREATE_EXTERNAL_TABLE", "CREATE_MANAGED_STORAGE", "CREATE_TABLE"},
+			invalid:   []string{"CREATE_CONNECTION", "SELECT"},
+		},
+		{
+			securable: "foreign_connection",
+			valid:     []string{"CREATE_FOREIGN_CATALOG", "USE_CONNECTION"},
+			invalid:   []string{"CREATE_CONNECTION", "SELECT"},
+		},
+		{
+			securable: "schema",
+			valid:     []string{"USE_SCHEMA", "CREATE_TABLE", "USAGE", "CREATE"},
+			invalid:   []string{"USE_CATALOG", "CREATE_SCHEMA"},
+		},
+		{
+			securable: "table",
+			valid:     []string{"SELECT", "MODIFY"},
+			invalid:   []string{"USE_SCHEMA", "READ_VOLUME", "USAGE"},
+		},
+		{
+			securable: "volume",
+			valid:     []string{"READ_VOLUME", "WRITE_VOLUME"},
+			invalid:   []string{"SELECT"},
+		},
+		{
+			securable: "function",
+			valid:     []string{"EXECUTE"},
+			invalid:   []string{"SELECT"},
+		},
+		{
+			securable: "model",
+			valid:     []string{"EXECUTE", "APPLY_TAG"},
+			invalid:   []string{"MODIFY"},
+		},
+		{
+			securable: "share",
+			valid:     []string{"SELECT"},
+			invalid:   []string{"MODIFY"},
+		},
+	} {
+		t.Run(tc.securable, func(t *testing.T) {
+			assert.NoError(t, ValidateSecurablePrivileges(tc.securable, "me", tc.valid))
+			for _, privilege := range tc.invalid {
+				assert.ErrorContains(t, ValidateSecurablePrivileges(tc.securable, "me", []string{privilege}),
+					"is not valid for "+tc.securable, privilege)
+			}
+		})
+	}
+}
+
+func TestValidateSecurablePrivilegesSkipsUnknownSecurables(t *testing.T) {
+	assert.NoError(t, ValidateSecurablePrivileges("pipeline", "me", []string{"ANYTHING"}))
+}
+
+func TestNormalizeSecurablePrivilege(t *testing.T) {
+	assert.Equal(t, "USE_SCHEMA", NormalizeSecurablePrivilege("schema", "usage"))
+	assert.Equal(t, "USE_CATALOG", NormalizeSecurablePrivilege("catalog", "USAGE"))
+	assert.Equal(t, "USAGE", NormalizeSecurablePrivilege("table", "USAGE"))
+	assert.Equal(t, "USE_SCHEMA", NormalizeSecurablePrivilege("schema", "use schema"))
+}
@@ -244,5 +244,6 @@ func ResourceCatalog() common.Resource {
 			}
 			return nil
 		},
+		WithIdentity: true,
 	}
 }
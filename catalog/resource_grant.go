@@ -155,6 +155,13 @@ func ResourceGrant() common.Resource {
 
 	return common.Resource{
 		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			privileges, ok := d.Get("privileges").(*schema.Set)
+			if !ok {
+				return nil
+			}
+			return permissions.Mappings.ValidatePrivileges(d, d.Get("principal").(string), toStringSlice(privileges))
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			w, err := c.WorkspaceClient()
 			if err != nil {
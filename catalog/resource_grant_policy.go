@@ -0,0 +1,275 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/terraform-provider-databricks/catalog/permissions"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// GrantPolicy grants privileges to a principal on all securables, which names match the pattern
+type GrantPolicy struct {
+	Catalog       string   `json:"catalog" tf:"force_new"`
+	Schemas       []string `json:"schemas,omitempty" tf:"force_new,slice_set"`
+	SecurableType string   `json:"securable_type" tf:"force_new"`
+	NamePattern   string   `json:"name_pattern" tf:"force_new"`
+	Principal     string   `json:"principal" tf:"force_new"`
+	Privileges    []string `json:"privileges" tf:"slice_set"`
+	// hash of privileges of the principal on each matching securable
+	Securables map[string]string `json:"securables,omitempty" tf:"computed"`
+}
+
+// securables, that could be matched by the grant policy
+var grantPolicySecurableTypes = []string{"function", "model", "schema", "table", "volume"}
+
+// normalizePrivileges returns the sorted set of privileges, in which deprecated aliases are replaced with
+// the privileges returned by Unity Catalog for the securable type
+func normalizePrivileges(securableType string, privileges []string) []string {
+	normalized := []string{}
+	for _, p := range privileges {
+		normalized = append(normalized, permissions.NormalizeSecurablePrivilege(securableType, p))
+	}
+	sort.Strings(normalized)
+	return slices.Compact(normalized)
+}
+
+// privilegesHash returns a compact hash of the normalized set of privileges
+func privilegesHash(securableType string, privileges []string) string {
+	return strconv.Itoa(schema.HashString(strings.Join(normalizePrivileges(securableType, privileges), ",")))
+}
+
+func (gp GrantPolicy) matches(name string) bool {
+	matched, err := path.Match(gp.NamePattern, name)
+	return err == nil && matched
+}
+
+// listSchemas returns schemas in the scope of the grant policy
+func (gp GrantPolicy) listSchemas(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+	if len(gp.Schemas) > 0 {
+		schemas := append([]string{}, gp.Schemas...)
+		sort.Strings(schemas)
+		return schemas, nil
+	}
+	all, err := w.Schemas.ListAll(ctx, catalog.ListSchemasRequest{CatalogName: gp.Catalog})
+	if err != nil {
+		return nil, err
+	}
+	schemas := []string{}
+	for _, s := range all {
+		if s.Name == "information_schema" {
+			continue
+		}
+		schemas = append(schemas, s.Name)
+	}
+	sort.Strings(schemas)
+	return schemas, nil
+}
+
+// listMatchingSecurables returns full names of securables in the scope, which names match the pattern
+func (gp GrantPolicy) listMatchingSecurables(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+	schemas, err := gp.listSchemas(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+	matching := []string{}
+	add := func(name, fullName string) {
+		if gp.matches(name) {
+			matching = append(matching, fullName)
+		}
+	}
+	for _, schemaName := range schemas {
+		switch gp.SecurableType {
+		case "schema":
+			add(schemaName, fmt.Sprintf("%s.%s", gp.Catalog, schemaName))
+		case "table":
+			tables, err := w.Tables.ListAll(ctx, catalog.ListTablesRequest{
+				CatalogName: gp.Catalog,
+				SchemaName:  schemaName,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range tables {
+				add(v.Name, v.FullName)
+			}
+		case "volume":
+			volumes, err := w.Volumes.ListAll(ctx, catalog.ListVolumesRequest{
+				CatalogName: gp.Catalog,
+				SchemaName:  schemaName,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range volumes {
+				add(v.Name, v.FullName)
+			}
+		case "function":
+			functions, err := w.Functions.ListAll(ctx, catalog.ListFunctionsRequest{
+				CatalogName: gp.Catalog,
+				SchemaName:  schemaName,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range functions {
+				add(v.Name, v.FullName)
+			}
+		case "model":
+			models, err := w.RegisteredModels.ListAll(ctx, catalog.ListRegisteredModelsRequest{
+				CatalogName: gp.Catalog,
+				SchemaName:  schemaName,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range models {
+				add(v.Name, v.FullName)
+			}
+		}
+	}
+	sort.Strings(matching)
+	return matching, nil
+}
+
+// reconcile grants configured privileges on each matching securable, removing other privileges of the principal
+func (gp GrantPolicy) reconcile(ctx context.Context, c *common.DatabricksClient, privileges []string) error {
+	w, err := c.WorkspaceClient()
+	if err != nil {
+		return err
+	}
+	securables, err := gp.listMatchingSecurables(ctx, w)
+	if err != nil {
+		return err
+	}
+	desired := catalog.GetPermissionsResponse{}
+	if len(privileges) > 0 {
+		assignment := catalog.PrivilegeAssignment{Principal: gp.Principal}
+		for _, p := range normalizePrivileges(gp.SecurableType, privileges) {
+			assignment.Privileges = append(assignment.Privileges, catalog.Privilege(p))
+		}
+		desired.PrivilegeAssignments = append(desired.PrivilegeAssignments, assignment)
+	}
+	api := permissions.NewUnityCatalogPermissionsAPI(ctx, c)
+	for _, fullName := range securables {
+		log.Printf("[DEBUG] Reconciling grants of %s on %s %s", gp.Principal, gp.SecurableType, fullName)
+		err = replacePermissionsForPrincipal(api, gp.SecurableType, fullName, gp.Principal, desired)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", gp.SecurableType, fullName, err)
+		}
+	}
+	return nil
+}
+
+func ResourceGrantPolicy() common.Resource {
+	s := common.StructToSchema(GrantPolicy{}, func(m map[string]*schema.Schema) map[string]*schema.Schema {
+		common.MustSchemaPath(m, "privileges").Set = func(i any) int {
+			return schema.HashString(permissions.NormalizePrivilege(i.(string)))
+		}
+		common.MustSchemaPath(m, "securable_type").ValidateFunc = validation.StringInSlice(grantPolicySecurableTypes, false)
+		common.MustSchemaPath(m, "name_pattern").ValidateFunc = func(i any, k string) ([]string, []error) {
+			if _, err := path.Match(i.(string), ""); err != nil {
+				return nil, []error{fmt.Errorf("%s is not a valid pattern: %w", k, err)}
+			}
+			return nil, nil
+		}
+		return m
+	})
+	return common.Resource{
+		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			privileges, ok := d.Get("privileges").(*schema.Set)
+			if !ok {
+				return nil
+			}
+			list := toStringSlice(privileges)
+			err := permissions.ValidateSecurablePrivileges(d.Get("securable_type").(string), d.Get("principal").(string), list)
+			if err != nil {
+				return err
+			}
+			// any securable, where privileges of the principal differ from the configured ones, requires reconciliation
+			desired := privilegesHash(d.Get("securable_type").(string), list)
+			for _, hash := range d.Get("securables").(map[string]any) {
+				if hash != desired {
+					return d.SetNewComputed("securables")
+				}
+			}
+			return nil
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var gp GrantPolicy
+			common.DataToStructPointer(d, s, &gp)
+			err := gp.reconcile(ctx, c, gp.Privileges)
+			if err != nil {
+				return err
+			}
+			d.SetId(fmt.Sprintf("%s/%s/%s/%s", gp.Catalog, gp.SecurableType, gp.NamePattern, gp.Principal))
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var gp GrantPolicy
+			common.DataToStructPointer(d, s, &gp)
+			imported := gp.Catalog == ""
+			if imported {
+				// restore scope from the ID after import
+				split := strings.SplitN(d.Id(), "/", 4)
+				if len(split) != 4 {
+					return fmt.Errorf("ID must be in form of <catalog>/<securable_type>/<name_pattern>/<principal>: %s", d.Id())
+				}
+				gp.Catalog, gp.SecurableType, gp.NamePattern, gp.Principal = split[0], split[1], split[2], split[3]
+			}
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			securables, err := gp.listMatchingSecurables(ctx, w)
+			if err != nil {
+				return err
+			}
+			api := permissions.NewUnityCatalogPermissionsAPI(ctx, c)
+			securableType := permissions.Mappings.GetSecurableType(gp.SecurableType)
+			gp.Securables = map[string]string{}
+			for _, fullName := range securables {
+				current, err := api.GetPermissions(securableType, fullName)
+				if err != nil {
+					return fmt.Errorf("%s %s: %w", gp.SecurableType, fullName, err)
+				}
+				privileges := []string{}
+				for _, v := range current.PrivilegeAssignments {
+					if v.Principal != gp.Principal {
+						continue
+					}
+					for _, p := range v.Privileges {
+						privileges = append(privileges, p.String())
+					}
+				}
+				gp.Securables[fullName] = privilegesHash(gp.SecurableType, privileges)
+				if imported && len(gp.Privileges) == 0 {
+					// privileges are restored from the first matching securable, that has any
+					gp.Privileges = normalizePrivileges(gp.SecurableType, privileges)
+				}
+			}
+			return common.StructToData(gp, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var gp GrantPolicy
+			common.DataToStructPointer(d, s, &gp)
+			return gp.reconcile(ctx, c, gp.Privileges)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var gp GrantPolicy
+			common.DataToStructPointer(d, s, &gp)
+			return gp.reconcile(ctx, c, nil)
+		},
+	}
+}
@@ -0,0 +1,264 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+var grantPolicyTablesFixture = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.1/unity-catalog/tables?catalog_name=foo&schema_name=bar",
+	ReuseRequest: true,
+	Response: catalog.ListTablesResponse{
+		Tables: []catalog.TableInfo{
+			{Name: "fact_sales", FullName: "foo.bar.fact_sales"},
+			{Name: "dim_customer", FullName: "foo.bar.dim_customer"},
+		},
+	},
+}
+
+func TestResourceGrantPolicyCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			grantPolicyTablesFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/table/foo.bar.fact_sales?",
+				Response: catalog.GetPermissionsResponse{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "analysts",
+							Privileges: []catalog.Privilege{"MODIFY"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/permissions/table/foo.bar.fact_sales",
+				ExpectedRequest: catalog.UpdatePermissions{
+					Changes: []catalog.PermissionsChange{
+						{
+							Principal: "analysts",
+							Add:       []catalog.Privilege{"SELECT"},
+							Remove:    []catalog.Privilege{"MODIFY"},
+						},
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/unity-catalog/permissions/table/foo.bar.fact_sales?",
+				ReuseRequest: true,
+				Response: catalog.GetPermissionsResponse{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "analysts",
+							Privileges: []catalog.Privilege{"SELECT"},
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGrantPolicy(),
+		Create:   true,
+		HCL: `
+		catalog = "foo"
+		schemas = ["bar"]
+		securable_type = "table"
+		name_pattern = "fact_*"
+		principal = "analysts"
+		privileges = ["SELECT"]
+		`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo/table/fact_*/analysts", d.Id())
+	assert.Equal(t, map[string]any{
+		"foo.bar.fact_sales": privilegesHash("table", []string{"SELECT"}),
+	}, d.Get("securables"))
+}
+
+func TestResourceGrantPolicyReadDrift(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/schemas?catalog_name=foo",
+				Response: catalog.ListSchemasResponse{
+					Schemas: []catalog.SchemaInfo{
+						{Name: "bar"},
+						{Name: "information_schema"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/foo.bar?",
+				Response: catalog.GetPermissionsResponse{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "analysts",
+							Privileges: []catalog.Privilege{"USE_SCHEMA", "MODIFY"},
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGrantPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "foo/schema/*/analysts",
+		HCL: `
+		catalog = "foo"
+		securable_type = "schema"
+		name_pattern = "*"
+		principal = "analysts"
+		privileges = ["USE_SCHEMA"]
+		`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"foo.bar": privilegesHash("schema", []string{"MODIFY", "USE_SCHEMA"}),
+	}, d.Get("securables"))
+}
+
+func TestResourceGrantPolicyDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			grantPolicyTablesFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/table/foo.bar.fact_sales?",
+				Response: catalog.GetPermissionsResponse{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "analysts",
+							Privileges: []catalog.Privilege{"SELECT"},
+						},
+						{
+							Principal:  "admins",
+							Privileges: []catalog.Privilege{"MODIFY"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/permissions/table/foo.bar.fact_sales",
+				ExpectedRequest: catalog.UpdatePermissions{
+					Changes: []catalog.PermissionsChange{
+						{
+							Principal: "analysts",
+							Remove:    []catalog.Privilege{"SELECT"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/table/foo.bar.fact_sales?",
+				Response: catalog.GetPermissionsResponse{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "admins",
+							Privileges: []catalog.Privilege{"MODIFY"},
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGrantPolicy(),
+		Delete:   true,
+		ID:       "foo/table/fact_*/analysts",
+		HCL: `
+		catalog = "foo"
+		schemas = ["bar"]
+		securable_type = "table"
+		name_pattern = "fact_*"
+		principal = "analysts"
+		privileges = ["SELECT"]
+		`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceGrantPolicyInvalidPrivilege(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceGrantPolicy(),
+		Create:   true,
+		HCL: `
+		catalog = "foo"
+		securable_type = "volume"
+		name_pattern = "*"
+		principal = "analysts"
+		privileges = ["SELECT"]
+		`,
+	}.ExpectError(t, "privilege SELECT granted to analysts is not valid for volume, valid privileges are: ALL_PRIVILEGES, APPLY_TAG, MANAGE, READ_VOLUME, WRITE_VOLUME")
+}
+
+func TestResourceGrantPolicyInvalidPattern(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceGrantPolicy(),
+		Create:   true,
+		HCL: `
+		catalog = "foo"
+		securable_type = "table"
+		name_pattern = "fact_["
+		principal = "analysts"
+		privileges = ["SELECT"]
+		`,
+	}.ExpectError(t, "invalid config supplied. [name_pattern] name_pattern is not a valid pattern: syntax error in pattern")
+}
+
+func TestResourceGrantPolicyImport(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/schemas?catalog_name=foo",
+				Response: catalog.ListSchemasResponse{
+					Schemas: []catalog.SchemaInfo{
+						{Name: "bar"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/foo.bar?",
+				Response: catalog.GetPermissionsResponse{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "analysts",
+							Privileges: []catalog.Privilege{"USE_SCHEMA"},
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGrantPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "foo/schema/*/analysts",
+	}.ApplyAndExpectData(t, map[string]any{
+		"catalog":        "foo",
+		"securable_type": "schema",
+		"name_pattern":   "*",
+		"principal":      "analysts",
+		"privileges":     []any{"USE_SCHEMA"},
+		"securables": map[string]any{
+			"foo.bar": privilegesHash("schema", []string{"USE_SCHEMA"}),
+		},
+	})
+}
+
+func TestPrivilegesHashNormalizesDeprecatedAliases(t *testing.T) {
+	assert.Equal(t, privilegesHash("schema", []string{"USE_SCHEMA", "CREATE_TABLE"}),
+		privilegesHash("schema", []string{"usage", "CREATE"}))
+	assert.Equal(t, privilegesHash("schema", []string{"USE_SCHEMA"}),
+		privilegesHash("schema", []string{"USAGE", "USE_SCHEMA"}))
+	// aliases of other securables are kept as they are
+	assert.NotEqual(t, privilegesHash("table", []string{"USE_SCHEMA"}),
+		privilegesHash("table", []string{"USAGE"}))
+}
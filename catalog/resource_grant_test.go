@@ -1,10 +1,13 @@
 package catalog
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/service/catalog"
 	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -105,11 +108,11 @@ func TestResourceGrantCreateMetastoreId(t *testing.T) {
 					PrivilegeAssignments: []catalog.PrivilegeAssignment{
 						{
 							Principal:  "me",
-							Privileges: []catalog.Privilege{"SELECT"},
+							Privileges: []catalog.Privilege{"CREATE_SHARE"},
 						},
 						{
 							Principal:  "someone-else",
-							Privileges: []catalog.Privilege{"MODIFY", "SELECT"},
+							Privileges: []catalog.Privilege{"CREATE_CATALOG", "CREATE_SHARE"},
 						},
 					},
 				},
@@ -121,8 +124,8 @@ func TestResourceGrantCreateMetastoreId(t *testing.T) {
 					Changes: []catalog.PermissionsChange{
 						{
 							Principal: "me",
-							Add:       []catalog.Privilege{"MODIFY"},
-							Remove:    []catalog.Privilege{"SELECT"},
+							Add:       []catalog.Privilege{"CREATE_CATALOG"},
+							Remove:    []catalog.Privilege{"CREATE_SHARE"},
 						},
 					},
 				},
@@ -134,11 +137,11 @@ func TestResourceGrantCreateMetastoreId(t *testing.T) {
 					PrivilegeAssignments: []catalog.PrivilegeAssignment{
 						{
 							Principal:  "me",
-							Privileges: []catalog.Privilege{"MODIFY"},
+							Privileges: []catalog.Privilege{"CREATE_CATALOG"},
 						},
 						{
 							Principal:  "someone-else",
-							Privileges: []catalog.Privilege{"MODIFY", "SELECT"},
+							Privileges: []catalog.Privilege{"CREATE_CATALOG", "CREATE_SHARE"},
 						},
 					},
 				},
@@ -150,11 +153,11 @@ func TestResourceGrantCreateMetastoreId(t *testing.T) {
 					PrivilegeAssignments: []catalog.PrivilegeAssignment{
 						{
 							Principal:  "me",
-							Privileges: []catalog.Privilege{"MODIFY"},
+							Privileges: []catalog.Privilege{"CREATE_CATALOG"},
 						},
 						{
 							Principal:  "someone-else",
-							Privileges: []catalog.Privilege{"MODIFY", "SELECT"},
+							Privileges: []catalog.Privilege{"CREATE_CATALOG", "CREATE_SHARE"},
 						},
 					},
 				},
@@ -165,7 +168,7 @@ func TestResourceGrantCreateMetastoreId(t *testing.T) {
 		HCL: `
 		metastore = "metastore_id"
 		principal = "me"
-		privileges = ["MODIFY"]
+		privileges = ["CREATE_CATALOG"]
 		`,
 	}.ApplyNoError(t)
 }
@@ -186,7 +189,7 @@ func TestFailsWhenWrongMetastoreId(t *testing.T) {
 		HCL: `
 		metastore = "new_id"
 		principal = "me"
-		privileges = ["MODIFY"]
+		privileges = ["CREATE_CATALOG"]
 		`,
 	}.ExpectError(t, "metastore_id must be empty or equal to the metastore id assigned to the workspace: old_id. "+
 		"If the metastore assigned to the workspace has changed, the new metastore id must be explicitly set")
@@ -849,3 +852,36 @@ func TestResourceGrantModelGrantCreate(t *testing.T) {
 		`,
 	}.ApplyNoError(t)
 }
+
+func TestGrantInvalidPrivilege(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceGrant(),
+		Create:   true,
+		HCL: `
+		volume = "foo.bar.baz"
+		principal = "me"
+		privileges = ["READ_VOLUME", "SELECT"]
+		`,
+	}.ExpectError(t, "privilege SELECT granted to me is not valid for volume, "+
+		"valid privileges are: ALL_PRIVILEGES, APPLY_TAG, MANAGE, READ_VOLUME, WRITE_VOLUME")
+}
+
+func TestGrantInvalidPrivilegeUnknownSecurable(t *testing.T) {
+	r := ResourceGrant().ToResource()
+	block := r.CoreConfigSchema()
+	attrs := map[string]cty.Value{}
+	for name, attrType := range block.ImpliedType().AttributeTypes() {
+		attrs[name] = cty.NullVal(attrType)
+	}
+	// catalog name refers to another resource, that isn't created yet
+	attrs["catalog"] = cty.UnknownVal(cty.String)
+	attrs["principal"] = cty.StringVal("me")
+	attrs["privileges"] = cty.SetVal([]cty.Value{cty.StringVal("USE_CATALOG"), cty.StringVal("READ_FILES")})
+	raw := cty.ObjectVal(attrs)
+	_, err := r.Diff(context.Background(), &terraform.InstanceState{RawConfig: raw},
+		terraform.NewResourceConfigShimmed(raw, block), nil)
+	assert.EqualError(t, err, "privilege READ_FILES granted to me is not valid for catalog, valid privileges are: "+
+		"ALL_PRIVILEGES, APPLY_TAG, BROWSE, CREATE_FUNCTION, CREATE_MATERIALIZED_VIEW, "+
+		"CREATE_MODEL, CREATE_MODEL_VERSION, CREATE_SCHEMA, CREATE_TABLE, CREATE_VOLUME, EXECUTE, EXTERNAL_USE_SCHEMA, "+
+		"MANAGE, MODIFY, READ_VOLUME, REFRESH, SELECT, USE_CATALOG, USE_SCHEMA, WRITE_VOLUME")
+}
@@ -117,6 +117,14 @@ func sdkPermissionsListToPermissionsList(sdkPermissionsList []catalog.PrivilegeA
 	return
 }
 
+func toStringSlice(set *schema.Set) (out []string) {
+	for _, v := range set.List() {
+		s, _ := v.(string)
+		out = append(out, s)
+	}
+	return
+}
+
 func parseId(d *schema.ResourceData) (string, string, error) {
 	split := strings.SplitN(d.Id(), "/", 2)
 	if len(split) != 2 {
@@ -159,6 +167,25 @@ func ResourceGrants() common.Resource {
 		})
 	return common.Resource{
 		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			grants, ok := d.Get("grant").(*schema.Set)
+			if !ok {
+				return nil
+			}
+			for _, raw := range grants.List() {
+				grant := raw.(map[string]any)
+				principal, _ := grant["principal"].(string)
+				privileges, ok := grant["privileges"].(*schema.Set)
+				if !ok {
+					continue
+				}
+				err := permissions.Mappings.ValidatePrivileges(d, principal, toStringSlice(privileges))
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			w, err := c.WorkspaceClient()
 			if err != nil {
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/terraform-provider-databricks/catalog/permissions"
 	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -183,7 +185,7 @@ func TestErrorIfWrongMetastoreId(t *testing.T) {
 
 		grant {
 			principal = "me"
-			privileges = ["MODIFY"]
+			privileges = ["CREATE_CATALOG"]
 		}`,
 	}.ExpectError(t, "metastore_id must be empty or equal to the metastore id assigned to the workspace: old_id. "+
 		"If the metastore assigned to the workspace has changed, the new metastore id must be explicitly set")
@@ -698,3 +700,52 @@ func TestModelGrantCreate(t *testing.T) {
 		}`,
 	}.ApplyNoError(t)
 }
+
+func TestGrantsInvalidPrivilege(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceGrants(),
+		Create:   true,
+		HCL: `
+		table = "foo.bar.baz"
+
+		grant {
+			principal = "me"
+			privileges = ["SELECT"]
+		}
+		grant {
+			principal = "data engineers"
+			privileges = ["MODIFY", "create table"]
+		}`,
+	}.ExpectError(t, "privilege CREATE_TABLE granted to data engineers is not valid for table, "+
+		"valid privileges are: ALL_PRIVILEGES, APPLY_TAG, MANAGE, MODIFY, REFRESH, SELECT")
+}
+
+func TestGrantsInvalidPrivilegeOnSchema(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceGrants(),
+		Create:   true,
+		HCL: `
+		schema = "foo.bar"
+
+		grant {
+			principal = "me"
+			privileges = ["READ_VOLUME", "CREATE_CATALOG"]
+		}`,
+	}.ExpectError(t, "privilege CREATE_CATALOG granted to me is not valid for schema, valid privileges are: "+
+		"ALL_PRIVILEGES, APPLY_TAG, CREATE_FUNCTION, CREATE_MATERIALIZED_VIEW, CREATE_MODEL, CREATE_MODEL_VERSION, "+
+		"CREATE_TABLE, CREATE_VOLUME, EXECUTE, EXTERNAL_USE_SCHEMA, MANAGE, MODIFY, READ_VOLUME, REFRESH, SELECT, "+
+		"USE_SCHEMA, WRITE_VOLUME")
+}
+
+func TestGrantsDeprecatedPrivilege(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceGrants().Schema, map[string]any{
+		"schema": "foo.bar",
+	})
+	err := permissions.Mappings.ValidatePrivileges(d, "me", []string{"USAGE", "CREATE", "use schema"})
+	assert.NoError(t, err)
+	err = permissions.Mappings.ValidatePrivileges(d, "me", []string{"USE_CATALOG"})
+	assert.EqualError(t, err, "privilege USE_CATALOG granted to me is not valid for schema, valid privileges are: "+
+		"ALL_PRIVILEGES, APPLY_TAG, CREATE_FUNCTION, CREATE_MATERIALIZED_VIEW, CREATE_MODEL, CREATE_MODEL_VERSION, "+
+		"CREATE_TABLE, CREATE_VOLUME, EXECUTE, EXTERNAL_USE_SCHEMA, MANAGE, MODIFY, READ_VOLUME, REFRESH, SELECT, "+
+		"USE_SCHEMA, WRITE_VOLUME")
+}
@@ -159,5 +159,6 @@ func ResourceSchema() common.Resource {
 			}
 			return w.Schemas.Delete(ctx, catalog.DeleteSchemaRequest{FullName: name, Force: force})
 		},
+		WithIdentity: true,
 	}
 }
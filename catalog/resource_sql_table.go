@@ -769,5 +769,6 @@ func ResourceSqlTable() common.Resource {
 			}
 			return ti.deleteTable()
 		},
+		WithIdentity: true,
 	}
 }
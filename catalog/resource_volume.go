@@ -169,5 +169,6 @@ func ResourceVolume() common.Resource {
 			}
 			return w.Volumes.DeleteByName(ctx, d.Id())
 		},
+		WithIdentity: true,
 	}
 }
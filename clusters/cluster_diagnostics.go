@@ -0,0 +1,293 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+)
+
+// Categories of cluster start failures
+const (
+	FailureCloudQuota     = "cloud quota"
+	FailureInitScript     = "init script failure"
+	FailureDockerPull     = "Docker image pull failure"
+	FailureSpotLoss       = "spot instance loss"
+	FailureNetwork        = "network problem"
+	FailurePermission     = "permission problem"
+	FailureUnclassified   = "unclassified failure"
+	maxDiagnosticEvents   = 25
+	clusterEventsDocsLink = "https://docs.databricks.com/aws/en/compute/clusters-manage#view-compute-event-logs"
+)
+
+type failureClass struct {
+	category    string
+	remediation string
+}
+
+var (
+	cloudQuotaFailure = failureClass{FailureCloudQuota,
+		"Request a quota increase from the cloud provider, use a different instance type or availability zone, or reduce the number of workers."}
+	initScriptFailure = failureClass{FailureInitScript,
+		"Check the init script logs, fix the failing script, and make sure that it's accessible by the cluster."}
+	dockerPullFailure = failureClass{FailureDockerPull,
+		"Check that the Docker image URL is correct, the image exists, and the cluster has credentials and network access to pull it."}
+	spotLossFailure = failureClass{FailureSpotLoss,
+		"Use on-demand instances for the driver (`first_on_demand`), enable fallback to on-demand instances, or use a different instance type."}
+	networkFailure = failureClass{FailureNetwork,
+		"Check VPC/VNet configuration: subnets should have free IP addresses, DNS should be resolvable, and security groups, firewalls and routes should allow traffic to the Databricks control plane and storage."}
+	permissionFailure = failureClass{FailurePermission,
+		"Check that the cross-account role, service account or instance profile has required permissions, and that encryption keys are accessible."}
+)
+
+// termination codes are matched exactly, and then by prefixes
+var failureClassByCode = map[compute.TerminationReasonCode]failureClass{
+	compute.TerminationReasonCodeAwsInsufficientInstanceCapacityFailure:      cloudQuotaFailure,
+	compute.TerminationReasonCodeAwsMaxSpotInstanceCountExceededFailure:      cloudQuotaFailure,
+	compute.TerminationReasonCodeAwsResourceQuotaExceeded:                    cloudQuotaFailure,
+	compute.TerminationReasonCodeAwsRequestLimitExceeded:                     cloudQuotaFailure,
+	compute.TerminationReasonCodeAzureQuotaExceededException:                 cloudQuotaFailure,
+	compute.TerminationReasonCodeGcpQuotaExceeded:                            cloudQuotaFailure,
+	compute.TerminationReasonCodeGcpResourceQuotaExceeded:                    cloudQuotaFailure,
+	compute.TerminationReasonCodeGcpInsufficientCapacity:                     cloudQuotaFailure,
+	compute.TerminationReasonCodeCloudProviderResourceStockout:               cloudQuotaFailure,
+	compute.TerminationReasonCodeInstancePoolMaxCapacityReached:              cloudQuotaFailure,
+	compute.TerminationReasonCodeInitScriptFailure:                           initScriptFailure,
+	compute.TerminationReasonCodeGlobalInitScriptFailure:                     initScriptFailure,
+	compute.TerminationReasonCodeDockerImagePullFailure:                      dockerPullFailure,
+	compute.TerminationReasonCodeDockerContainerCreationException:            dockerPullFailure,
+	compute.TerminationReasonCodeDockerInvalidOsException:                    dockerPullFailure,
+	compute.TerminationReasonCodeImagePullPermissionDenied:                   dockerPullFailure,
+	compute.TerminationReasonCodeSpotInstanceTermination:                     spotLossFailure,
+	compute.TerminationReasonCodeAwsInsufficientFreeAddressesInSubnetFailure: networkFailure,
+	compute.TerminationReasonCodeSubnetExhaustedFailure:                      networkFailure,
+	compute.TerminationReasonCodeIpExhaustionFailure:                         networkFailure,
+	compute.TerminationReasonCodeGcpIpSpaceExhausted:                         networkFailure,
+	compute.TerminationReasonCodeDnsResolutionError:                          networkFailure,
+	compute.TerminationReasonCodeDriverDnsResolutionFailure:                  networkFailure,
+	compute.TerminationReasonCodeAzureVnetConfigurationFailure:               networkFailure,
+	compute.TerminationReasonCodeNpipTunnelSetupFailure:                      networkFailure,
+	compute.TerminationReasonCodeInstanceUnreachable:                         networkFailure,
+	compute.TerminationReasonCodeAwsAuthorizationFailure:                     permissionFailure,
+	compute.TerminationReasonCodeAwsInaccessibleKmsKeyFailure:                permissionFailure,
+	compute.TerminationReasonCodeAwsInstanceProfileUpdateFailure:             permissionFailure,
+	compute.TerminationReasonCodeAzureByokKeyPermissionFailure:               permissionFailure,
+	compute.TerminationReasonCodeGcpForbidden:                                permissionFailure,
+	compute.TerminationReasonCodeGcpDeniedByOrgPolicy:                        permissionFailure,
+	compute.TerminationReasonCodeGcpKmsKeyPermissionDenied:                   permissionFailure,
+	compute.TerminationReasonCodeGcpServiceAccountAccessDenied:               permissionFailure,
+	compute.TerminationReasonCodeSecretPermissionDenied:                      permissionFailure,
+	compute.TerminationReasonCodeUpdateInstanceProfileFailure:                permissionFailure,
+}
+
+var failureClassByPrefix = []struct {
+	prefix string
+	class  failureClass
+}{
+	{"NETWORK_CHECK_", networkFailure},
+	{"NETWORK_", networkFailure},
+	{"DOCKER_", dockerPullFailure},
+}
+
+func classifyTerminationReason(reason *compute.TerminationReason) failureClass {
+	if reason == nil {
+		return failureClass{category: FailureUnclassified}
+	}
+	if class, ok := failureClassByCode[reason.Code]; ok {
+		return class
+	}
+	for _, p := range failureClassByPrefix {
+		if strings.HasPrefix(string(reason.Code), p.prefix) {
+			return p.class
+		}
+	}
+	if strings.Contains(string(reason.Code), "QUOTA") {
+		return cloudQuotaFailure
+	}
+	return failureClass{category: FailureUnclassified}
+}
+
+// ClusterFailureError is a structured diagnostic for a cluster, that failed to start,
+// based on its termination reason and the most recent cluster events.
+type ClusterFailureError struct {
+	ClusterID         string
+	Category          string
+	Code              compute.TerminationReasonCode
+	Type              compute.TerminationReasonType
+	Parameters        map[string]string
+	Remediation       string
+	FailedInitScripts []string
+	InitScriptLogs    string
+	cause             error
+}
+
+func (e *ClusterFailureError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "cluster %s failed to start: %s", e.ClusterID, e.Category)
+	if e.Code != "" {
+		fmt.Fprintf(&sb, " (%s)", e.Code)
+	}
+	if e.cause != nil {
+		fmt.Fprintf(&sb, ": %s", e.cause)
+	}
+	if e.Type != "" {
+		fmt.Fprintf(&sb, "\nTermination type: %s", e.Type)
+	}
+	if len(e.Parameters) > 0 {
+		fmt.Fprintf(&sb, "\nTermination parameters: %v", e.Parameters)
+	}
+	for _, script := range e.FailedInitScripts {
+		fmt.Fprintf(&sb, "\nFailed init script: %s", script)
+	}
+	if e.InitScriptLogs != "" {
+		fmt.Fprintf(&sb, "\nInit script logs: %s", e.InitScriptLogs)
+	}
+	if e.Remediation != "" {
+		fmt.Fprintf(&sb, "\nRemediation: %s", e.Remediation)
+	}
+	fmt.Fprintf(&sb, "\nPlease see cluster event log for more details: %s", clusterEventsDocsLink)
+	return sb.String()
+}
+
+func (e *ClusterFailureError) Unwrap() error {
+	return e.cause
+}
+
+func initScriptLocation(script compute.InitScriptInfoAndExecutionDetails) string {
+	switch {
+	case script.Workspace != nil:
+		return "workspace:" + script.Workspace.Destination
+	case script.Volumes != nil:
+		return "volumes:" + script.Volumes.Destination
+	case script.S3 != nil:
+		return script.S3.Destination
+	case script.Abfss != nil:
+		return script.Abfss.Destination
+	case script.Gcs != nil:
+		return script.Gcs.Destination
+	case script.Dbfs != nil:
+		return "dbfs:" + strings.TrimPrefix(script.Dbfs.Destination, "dbfs:")
+	case script.File != nil:
+		return "file:" + script.File.Destination
+	}
+	return "unknown"
+}
+
+func failedInitScripts(details *compute.InitScriptEventDetails) (failed []string) {
+	if details == nil {
+		return
+	}
+	scripts := append([]compute.InitScriptInfoAndExecutionDetails{}, details.Global...)
+	scripts = append(scripts, details.Cluster...)
+	for _, script := range scripts {
+		switch script.Status {
+		case compute.InitScriptExecutionDetailsInitScriptExecutionStatusSucceeded,
+			compute.InitScriptExecutionDetailsInitScriptExecutionStatusSkipped,
+			compute.InitScriptExecutionDetailsInitScriptExecutionStatusNotExecuted:
+			continue
+		}
+		message := fmt.Sprintf("%s (%s)", initScriptLocation(script), script.Status)
+		if script.ErrorMessage != "" {
+			message += ": " + script.ErrorMessage
+		}
+		failed = append(failed, message)
+	}
+	return
+}
+
+// initScriptLogsLocation returns the location of init script logs, if cluster log delivery is configured
+func initScriptLogsLocation(cluster *compute.ClusterDetails) string {
+	if cluster == nil || cluster.ClusterLogConf == nil {
+		return ""
+	}
+	var destination string
+	switch {
+	case cluster.ClusterLogConf.Dbfs != nil:
+		destination = cluster.ClusterLogConf.Dbfs.Destination
+	case cluster.ClusterLogConf.S3 != nil:
+		destination = cluster.ClusterLogConf.S3.Destination
+	case cluster.ClusterLogConf.Volumes != nil:
+		destination = cluster.ClusterLogConf.Volumes.Destination
+	default:
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/init_scripts", strings.TrimSuffix(destination, "/"), cluster.ClusterId)
+}
+
+// newClusterFailureError builds the diagnostic from cluster details and its events, ordered from the most recent
+func newClusterFailureError(clusterID string, cluster *compute.ClusterDetails, events []compute.ClusterEvent, cause error) *ClusterFailureError {
+	var reason *compute.TerminationReason
+	if cluster != nil {
+		reason = cluster.TerminationReason
+	}
+	var failedScripts []string
+	for _, event := range events {
+		if event.Details == nil {
+			continue
+		}
+		if reason == nil && event.Type == compute.EventTypeTerminating {
+			reason = event.Details.Reason
+		}
+		if failedScripts == nil && event.Type == compute.EventTypeInitScriptsFinished {
+			failedScripts = failedInitScripts(event.Details.InitScripts)
+		}
+	}
+	class := classifyTerminationReason(reason)
+	if class.category == FailureUnclassified && len(failedScripts) > 0 {
+		class = initScriptFailure
+	}
+	diagnostic := &ClusterFailureError{
+		ClusterID:   clusterID,
+		Category:    class.category,
+		Remediation: class.remediation,
+		cause:       cause,
+	}
+	if reason != nil {
+		diagnostic.Code = reason.Code
+		diagnostic.Type = reason.Type
+		diagnostic.Parameters = reason.Parameters
+	}
+	if class.category == FailureInitScript {
+		diagnostic.FailedInitScripts = failedScripts
+		diagnostic.InitScriptLogs = initScriptLogsLocation(cluster)
+	}
+	return diagnostic
+}
+
+// WithClusterFailureDiagnostics fetches the most recent events of the cluster, that failed to be created,
+// started or restarted, and returns a structured diagnostic with remediation hints. The original error
+// is returned if events can't be retrieved, or if the error comes from the API call itself.
+func WithClusterFailureDiagnostics(ctx context.Context, w *databricks.WorkspaceClient, clusterID string, err error) error {
+	if err == nil || clusterID == "" {
+		return err
+	}
+	var apiErr *apierr.APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	cluster, getErr := w.Clusters.GetByClusterId(ctx, clusterID)
+	if getErr != nil {
+		log.Printf("[WARN] Cannot get details of cluster %s: %s", clusterID, getErr)
+		return err
+	}
+	it := w.Clusters.Events(ctx, compute.GetEvents{
+		ClusterId: clusterID,
+		Order:     compute.GetEventsOrderDesc,
+		Limit:     maxDiagnosticEvents,
+	})
+	var events []compute.ClusterEvent
+	for len(events) < maxDiagnosticEvents && it.HasNext(ctx) {
+		event, eventsErr := it.Next(ctx)
+		if eventsErr != nil {
+			log.Printf("[WARN] Cannot get events of cluster %s: %s", clusterID, eventsErr)
+			break
+		}
+		events = append(events, event)
+	}
+	return newClusterFailureError(clusterID, cluster, events, err)
+}
@@ -0,0 +1,124 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyTerminationReason(t *testing.T) {
+	for code, category := range map[compute.TerminationReasonCode]string{
+		compute.TerminationReasonCodeAzureQuotaExceededException:             FailureCloudQuota,
+		compute.TerminationReasonCodeGcpQuotaExceeded:                        FailureCloudQuota,
+		compute.TerminationReasonCodeInitScriptFailure:                       FailureInitScript,
+		compute.TerminationReasonCodeDockerImagePullFailure:                  FailureDockerPull,
+		compute.TerminationReasonCodeDockerImageTooLargeForInstanceException: FailureDockerPull,
+		compute.TerminationReasonCodeSpotInstanceTermination:                 FailureSpotLoss,
+		compute.TerminationReasonCodeNetworkConfigurationFailure:             FailureNetwork,
+		compute.TerminationReasonCodeGcpForbidden:                            FailurePermission,
+		compute.TerminationReasonCodeUserRequest:                             FailureUnclassified,
+	} {
+		class := classifyTerminationReason(&compute.TerminationReason{Code: code})
+		assert.Equal(t, category, class.category, code)
+	}
+	assert.Equal(t, FailureUnclassified, classifyTerminationReason(nil).category)
+}
+
+func TestNewClusterFailureError_InitScript(t *testing.T) {
+	cause := errors.New("failed to reach RUNNING, got TERMINATED")
+	diagnostic := newClusterFailureError("abc", &compute.ClusterDetails{
+		ClusterId: "abc",
+		ClusterLogConf: &compute.ClusterLogConf{
+			Dbfs: &compute.DbfsStorageInfo{
+				Destination: "dbfs:/cluster-logs/",
+			},
+		},
+	}, []compute.ClusterEvent{
+		{
+			Type: compute.EventTypeTerminating,
+			Details: &compute.EventDetails{
+				Reason: &compute.TerminationReason{
+					Code: compute.TerminationReasonCodeInitScriptFailure,
+					Type: compute.TerminationReasonTypeClientError,
+				},
+			},
+		},
+		{
+			Type: compute.EventTypeInitScriptsFinished,
+			Details: &compute.EventDetails{
+				InitScripts: &compute.InitScriptEventDetails{
+					Cluster: []compute.InitScriptInfoAndExecutionDetails{
+						{
+							Workspace: &compute.WorkspaceStorageInfo{
+								Destination: "/Shared/ok.sh",
+							},
+							Status: compute.InitScriptExecutionDetailsInitScriptExecutionStatusSucceeded,
+						},
+						{
+							Volumes: &compute.VolumesStorageInfo{
+								Destination: "/Volumes/main/default/scripts/install.sh",
+							},
+							Status:       compute.InitScriptExecutionDetailsInitScriptExecutionStatusFailedExecution,
+							ErrorMessage: "Script exit status is non-zero",
+						},
+					},
+				},
+			},
+		},
+	}, cause)
+	assert.Equal(t, FailureInitScript, diagnostic.Category)
+	assert.Equal(t, []string{
+		"volumes:/Volumes/main/default/scripts/install.sh (FAILED_EXECUTION): Script exit status is non-zero",
+	}, diagnostic.FailedInitScripts)
+	assert.Equal(t, "dbfs:/cluster-logs/abc/init_scripts", diagnostic.InitScriptLogs)
+	assert.ErrorIs(t, diagnostic, cause)
+	assert.Contains(t, diagnostic.Error(), "cluster abc failed to start: init script failure (INIT_SCRIPT_FAILURE)")
+	assert.Contains(t, diagnostic.Error(), "Init script logs: dbfs:/cluster-logs/abc/init_scripts")
+}
+
+func TestStartClusterAndGetInfo_Diagnostics(t *testing.T) {
+	terminated := compute.ClusterDetails{
+		ClusterId:    "abc",
+		State:        compute.StateTerminated,
+		StateMessage: "Docker image pull failed",
+		TerminationReason: &compute.TerminationReason{
+			Code: compute.TerminationReasonCodeDockerImagePullFailure,
+			Type: compute.TerminationReasonTypeClientError,
+		},
+	}
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.1/clusters/get?cluster_id=abc",
+			ReuseRequest: true,
+			Response:     terminated,
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/clusters/start",
+			ExpectedRequest: compute.StartCluster{
+				ClusterId: "abc",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/clusters/events",
+			Response: compute.GetEventsResponse{},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	w, err := client.WorkspaceClient()
+	require.NoError(t, err)
+	_, err = StartClusterAndGetInfo(context.Background(), w, "abc")
+	var diagnostic *ClusterFailureError
+	require.ErrorAs(t, err, &diagnostic)
+	assert.Equal(t, FailureDockerPull, diagnostic.Category)
+	assert.Contains(t, err.Error(), "Docker image pull failed")
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // AutoScale is a struct the describes auto scaling for clusters
@@ -725,6 +727,11 @@ func wrapMissingClusterError(err error, id string) error {
 }
 
 func (a ClustersAPI) waitForClusterStatus(clusterID string, desired ClusterState) (result ClusterInfo, err error) {
+	var span trace.Span
+	a.context, span = common.StartSpan(a.context, "waitForClusterStatus",
+		attribute.String("databricks.cluster_id", clusterID),
+		attribute.String("databricks.desired_state", string(desired)))
+	defer func() { common.EndSpan(span, err) }()
 	// this tangles client with terraform more, which is inevitable
 	// nolint should be a bigger context-aware refactor
 	return result, resource.RetryContext(a.context, a.defaultTimeout(), func() *resource.RetryError {
@@ -749,9 +756,13 @@ func (a ClustersAPI) waitForClusterStatus(clusterID string, desired ClusterState
 					clusterInfo.TerminationReason.Code, clusterInfo.TerminationReason.Type,
 					clusterInfo.TerminationReason.Parameters)
 			}
-			return resource.NonRetryableError(fmt.Errorf(
-				"%s is not able to transition from %s to %s: %s%s. Please see %s for more details",
-				clusterID, clusterInfo.State, desired, clusterInfo.StateMessage, details, docLink))
+			err = fmt.Errorf("%s is not able to transition from %s to %s: %s%s. Please see %s for more details",
+				clusterID, clusterInfo.State, desired, clusterInfo.StateMessage, details, docLink)
+			w, wErr := a.client.WorkspaceClient()
+			if wErr != nil {
+				return resource.NonRetryableError(err)
+			}
+			return resource.NonRetryableError(WithClusterFailureDiagnostics(a.context, w, clusterID, err))
 		}
 		return resource.RetryableError(
 			fmt.Errorf("%s is %s, but has to be %s",
@@ -33,7 +33,11 @@ func StartClusterAndGetInfo(ctx context.Context, w *databricks.WorkspaceClient,
 		// most likely we can start error'ed cluster again...
 		log.Printf("[ERROR] Cluster %s: %s", cluster.State, cluster.StateMessage)
 	}
-	return w.Clusters.StartByClusterIdAndWait(ctx, clusterID)
+	info, err := w.Clusters.StartByClusterIdAndWait(ctx, clusterID)
+	if err != nil {
+		return info, WithClusterFailureDiagnostics(ctx, w, clusterID, err)
+	}
+	return info, nil
 }
 
 // LatestSparkVersionOrDefault returns Spark version matching the definition, or default in case of error
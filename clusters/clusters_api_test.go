@@ -231,6 +231,20 @@ func TestWaitForClusterStatus_NotReachable(t *testing.T) {
 					Parameters: map[string]string{"abc": "def"}},
 			},
 		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/get?cluster_id=abc",
+			Response: compute.ClusterDetails{
+				ClusterId:    "abc",
+				State:        compute.StateUnknown,
+				StateMessage: "Something strange is going on",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/clusters/events",
+			Response: compute.GetEventsResponse{},
+		},
 	})
 	defer server.Close()
 	require.NoError(t, err)
@@ -240,6 +254,8 @@ func TestWaitForClusterStatus_NotReachable(t *testing.T) {
 	ctx := context.Background()
 	_, err = NewClustersAPI(ctx, client).waitForClusterStatus("abc", ClusterStateRunning)
 	require.Error(t, err)
+	var diagnostic *ClusterFailureError
+	assert.ErrorAs(t, err, &diagnostic)
 	assert.Contains(t, err.Error(), "abc is not able to transition from UNKNOWN to RUNNING: Something strange is going on")
 	assert.Contains(t, err.Error(), "code: unknown, type: broken")
 }
@@ -37,6 +37,7 @@ func ResourceCluster() common.Resource {
 		Delete:        resourceClusterDelete,
 		Schema:        clusterSchema,
 		SchemaVersion: clusterSchemaVersion,
+		TagsPath:      []string{"custom_tags"},
 		Timeouts:      resourceClusterTimeouts(),
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -45,6 +46,7 @@ func ResourceCluster() common.Resource {
 				Upgrade: removeZeroAwsEbsVolumeAttributes,
 			},
 		},
+		WithIdentity: true,
 	}
 }
 
@@ -395,6 +397,7 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 	clusters := w.Clusters
 	var createClusterRequest compute.CreateCluster
 	common.DataToStructPointer(d, clusterSchema, &createClusterRequest)
+	createClusterRequest.CustomTags = c.MergeDefaultTags(createClusterRequest.CustomTags)
 	if err = ModifyRequestOnInstancePool(&createClusterRequest); err != nil {
 		return err
 	}
@@ -438,6 +441,7 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 
 	clusterInfo, err := clusterWaiter.GetWithTimeout(timeout)
 	if err != nil {
+		err = WithClusterFailureDiagnostics(ctx, w, d.Id(), err)
 		// In case of "ERROR" or "TERMINATED" state, WaitGetClusterRunning returns an error and we should delete the cluster before returning
 		deleteError := resourceClusterDelete(ctx, d, c)
 		if deleteError != nil {
@@ -519,6 +523,10 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 }
 
 func hasClusterConfigChanged(d *schema.ResourceData) bool {
+	// `default_tags` of the provider were changed
+	if d.HasChange(common.TagsAllField) {
+		return true
+	}
 	for k := range clusterSchema {
 		// TODO: create a map if we'll add more non-cluster config parameters in the future
 		if k == "library" || k == "is_pinned" || k == "no_wait" {
@@ -539,6 +547,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	clusters := w.Clusters
 	var cluster compute.EditCluster
 	common.DataToStructPointer(d, clusterSchema, &cluster)
+	cluster.CustomTags = c.MergeDefaultTags(cluster.CustomTags)
 	clusterId := d.Id()
 	cluster.ClusterId = clusterId
 	var clusterInfo *compute.ClusterDetails
@@ -557,7 +566,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 		// and only the cluster size (ie num_workers OR autoscale) is being changed
 		hasNumWorkersChanged := d.HasChange("num_workers")
 		hasAutoscaleChanged := d.HasChange("autoscale")
-		hasOnlyResizeClusterConfigChanged := true
+		hasOnlyResizeClusterConfigChanged := !d.HasChange(common.TagsAllField)
 		for k := range clusterSchema {
 			if k == "library" ||
 				k == "is_pinned" ||
@@ -673,7 +682,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	if len(libsToUninstall) > 0 || len(libsToInstall) > 0 {
 		if !clusterInfo.IsRunningOrResizing() {
 			if _, err = clusters.StartByClusterIdAndWait(ctx, clusterId); err != nil {
-				return err
+				return WithClusterFailureDiagnostics(ctx, w, clusterId, err)
 			}
 		}
 		// clusters.StartAndGetInfo() always returns a running cluster
@@ -0,0 +1,167 @@
+package clusters
+
+import (
+	"context"
+	"log"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/libraries"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ClusterLibraryStatus is the installation status of a single library on a cluster
+type ClusterLibraryStatus struct {
+	Library  string   `json:"library" tf:"computed"`
+	Status   string   `json:"status" tf:"computed"`
+	Messages []string `json:"messages,omitempty" tf:"computed"`
+}
+
+// ClusterLibraries is the complete set of libraries managed on a cluster
+type ClusterLibraries struct {
+	ClusterId string `json:"cluster_id" tf:"force_new"`
+	LibraryWithAlias
+	RestartOnUninstall bool                   `json:"restart_on_uninstall,omitempty"`
+	LibraryStatuses    []ClusterLibraryStatus `json:"library_status,omitempty" tf:"computed"`
+}
+
+// isManagedLibrary returns true for libraries that could be installed or uninstalled
+// on the cluster level, skipping libraries installed on all clusters and the ones
+// that are already marked for removal.
+func isManagedLibrary(status compute.LibraryFullStatus) bool {
+	return status.Library != nil && !status.IsLibraryForAllClusters &&
+		status.Status != compute.LibraryInstallStatusUninstallOnRestart
+}
+
+func managedLibraryStatuses(cls *compute.ClusterLibraryStatuses) *compute.ClusterLibraryStatuses {
+	managed := &compute.ClusterLibraryStatuses{
+		ClusterId: cls.ClusterId,
+	}
+	for _, status := range cls.LibraryStatuses {
+		if isManagedLibrary(status) {
+			managed.LibraryStatuses = append(managed.LibraryStatuses, status)
+		}
+	}
+	return managed
+}
+
+// updateClusterLibraries installs and uninstalls libraries on the cluster, starting it
+// if necessary. Terminated clusters are terminated again after libraries are updated.
+func updateClusterLibraries(ctx context.Context, w *databricks.WorkspaceClient, clusterID string,
+	toInstall, toUninstall []compute.Library, restart bool) error {
+	if len(toInstall) == 0 && len(toUninstall) == 0 {
+		return nil
+	}
+	clusterInfo, err := w.Clusters.GetByClusterId(ctx, clusterID)
+	if err != nil {
+		return wrapMissingClusterError(err, clusterID)
+	}
+	if !clusterInfo.IsRunningOrResizing() {
+		if _, err = StartClusterAndGetInfo(ctx, w, clusterID); err != nil {
+			return err
+		}
+	}
+	err = w.Libraries.UpdateAndWait(ctx, compute.Update{
+		ClusterId: clusterID,
+		Install:   toInstall,
+		Uninstall: toUninstall,
+	})
+	if err != nil {
+		return err
+	}
+	if clusterInfo.State == compute.StateTerminated {
+		log.Printf("[INFO] %s was in TERMINATED state, so terminating it again", clusterID)
+		return w.Clusters.DeleteByClusterId(ctx, clusterID)
+	}
+	if restart && len(toUninstall) > 0 {
+		log.Printf("[INFO] Restarting %s to finish uninstallation of %d libraries", clusterID, len(toUninstall))
+		_, err = w.Clusters.RestartAndWait(ctx, compute.RestartCluster{
+			ClusterId: clusterID,
+		})
+		return WithClusterFailureDiagnostics(ctx, w, clusterID, err)
+	}
+	return nil
+}
+
+func ResourceClusterLibraries() common.Resource {
+	s := common.StructToSchema(ClusterLibraries{}, func(m map[string]*schema.Schema) map[string]*schema.Schema {
+		common.CustomizeSchemaPath(m, "library").Schema.Set = func(i any) int {
+			lib := libraries.NewLibraryFromInstanceState(i)
+			return schema.HashString(lib.String())
+		}
+		common.CustomizeSchemaPath(m, "library", "egg").SetDeprecated(EggDeprecationWarning)
+		return m
+	})
+	apply := func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+		w, err := c.WorkspaceClient()
+		if err != nil {
+			return err
+		}
+		var cl ClusterLibraries
+		common.DataToStructPointer(d, s, &cl)
+		cls, err := w.Libraries.ClusterStatusByClusterId(ctx, cl.ClusterId)
+		if err != nil {
+			return err
+		}
+		toInstall, toUninstall := libraries.GetLibrariesToInstallAndUninstall(cl.Libraries, managedLibraryStatuses(cls))
+		return updateClusterLibraries(ctx, w, cl.ClusterId, toInstall, toUninstall, cl.RestartOnUninstall)
+	}
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			err := apply(ctx, d, c)
+			if err != nil {
+				return err
+			}
+			d.SetId(d.Get("cluster_id").(string))
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			cls, err := libraries.WaitForLibrariesInstalledSdk(ctx, w, compute.Wait{
+				ClusterID: d.Id(),
+			}, d.Timeout(schema.TimeoutRead))
+			if err != nil {
+				return err
+			}
+			cl := ClusterLibraries{
+				ClusterId: d.Id(),
+			}
+			for _, status := range cls.LibraryStatuses {
+				if status.Library == nil || status.IsLibraryForAllClusters {
+					continue
+				}
+				cl.LibraryStatuses = append(cl.LibraryStatuses, ClusterLibraryStatus{
+					Library:  status.Library.String(),
+					Status:   string(status.Status),
+					Messages: status.Messages,
+				})
+			}
+			cl.Libraries = managedLibraryStatuses(cls).ToLibraryList().Libraries
+			return common.StructToData(cl, s, d)
+		},
+		Update: apply,
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			cls, err := w.Libraries.ClusterStatusByClusterId(ctx, d.Id())
+			if err != nil {
+				return common.IgnoreNotFoundError(err)
+			}
+			toUninstall := managedLibraryStatuses(cls).ToLibraryList().Libraries
+			err = updateClusterLibraries(ctx, w, d.Id(), nil, toUninstall, d.Get("restart_on_uninstall").(bool))
+			return common.IgnoreNotFoundError(err)
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultProvisionTimeout),
+			Update: schema.DefaultTimeout(DefaultProvisionTimeout),
+			Delete: schema.DefaultTimeout(DefaultProvisionTimeout),
+		},
+	}
+}
@@ -0,0 +1,232 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+func TestClusterLibrariesCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceClusterLibraries(), qa.CornerCaseID("abc"))
+}
+
+func TestClusterLibrariesCreate(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterLibraries(),
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterInfo{
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: compute.ClusterLibraryStatuses{
+					LibraryStatuses: []compute.LibraryFullStatus{
+						{
+							Library: &compute.Library{
+								Whl: "manual.whl",
+							},
+							Status: "INSTALLED",
+						},
+						{
+							Library: &compute.Library{
+								Jar: "global.jar",
+							},
+							IsLibraryForAllClusters: true,
+							Status:                  "INSTALLED",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/uninstall",
+				ExpectedRequest: compute.UninstallLibraries{
+					ClusterId: "abc",
+					Libraries: []compute.Library{
+						{
+							Whl: "manual.whl",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+				ExpectedRequest: compute.InstallLibraries{
+					ClusterId: "abc",
+					Libraries: []compute.Library{
+						{
+							Pypi: &compute.PythonPyPiLibrary{
+								Package: "requests",
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				ReuseRequest: true,
+				Response: compute.ClusterLibraryStatuses{
+					LibraryStatuses: []compute.LibraryFullStatus{
+						{
+							Library: &compute.Library{
+								Whl: "manual.whl",
+							},
+							Status: "UNINSTALL_ON_RESTART",
+						},
+						{
+							Library: &compute.Library{
+								Pypi: &compute.PythonPyPiLibrary{
+									Package: "requests",
+								},
+							},
+							Status: "INSTALLED",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/restart",
+				ExpectedRequest: compute.RestartCluster{
+					ClusterId: "abc",
+				},
+			},
+		},
+		Create: true,
+		HCL: `
+		cluster_id = "abc"
+		restart_on_uninstall = true
+		library {
+			pypi {
+				package = "requests"
+			}
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                      "abc",
+		"library.#":               1,
+		"library_status.#":        2,
+		"library_status.0.status": "UNINSTALL_ON_RESTART",
+		"library_status.1.status": "INSTALLED",
+	})
+}
+
+func TestClusterLibrariesRead(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterLibraries(),
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: compute.ClusterLibraryStatuses{
+					LibraryStatuses: []compute.LibraryFullStatus{
+						{
+							Library: &compute.Library{
+								Whl: "foo.whl",
+							},
+							Status:   "FAILED",
+							Messages: []string{"Library installation failed"},
+						},
+					},
+				},
+			},
+		},
+		Read: true,
+		New:  true,
+		ID:   "abc",
+	}.ApplyAndExpectData(t, map[string]any{
+		"cluster_id":                "abc",
+		"library.#":                 1,
+		"library_status.0.library":  "whl:foo.whl",
+		"library_status.0.status":   "FAILED",
+		"library_status.0.messages": []any{"Library installation failed"},
+	})
+}
+
+func TestClusterLibrariesDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterLibraries(),
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: compute.ClusterLibraryStatuses{
+					LibraryStatuses: []compute.LibraryFullStatus{
+						{
+							Library: &compute.Library{
+								Whl: "foo.whl",
+							},
+							Status: "INSTALLED",
+						},
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterInfo{
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/uninstall",
+				ExpectedRequest: compute.UninstallLibraries{
+					ClusterId: "abc",
+					Libraries: []compute.Library{
+						{
+							Whl: "foo.whl",
+						},
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				ReuseRequest: true,
+				Response: compute.ClusterLibraryStatuses{
+					LibraryStatuses: []compute.LibraryFullStatus{
+						{
+							Library: &compute.Library{
+								Whl: "foo.whl",
+							},
+							Status: "UNINSTALL_ON_RESTART",
+						},
+					},
+				},
+			},
+		},
+		Delete: true,
+		ID:     "abc",
+	}.ApplyNoError(t)
+}
+
+func TestClusterLibrariesDeleteClusterNotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterLibraries(),
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: apierr.APIError{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Cluster abc does not exist",
+				},
+				Status: 404,
+			},
+		},
+		Delete: true,
+		ID:     "abc",
+	}.ApplyNoError(t)
+}
@@ -192,6 +192,29 @@ func TestResourceClusterCreateErrorFollowedByDeletion(t *testing.T) {
 					State:                  compute.StateTerminated,
 				},
 			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/events",
+				ExpectedRequest: compute.GetEvents{
+					ClusterId: "abc",
+					Order:     compute.GetEventsOrderDesc,
+					Limit:     25,
+				},
+				Response: compute.GetEventsResponse{
+					Events: []compute.ClusterEvent{
+						{
+							ClusterId: "abc",
+							Type:      compute.EventTypeTerminating,
+							Details: &compute.EventDetails{
+								Reason: &compute.TerminationReason{
+									Code: compute.TerminationReasonCodeAwsInsufficientInstanceCapacityFailure,
+									Type: compute.TerminationReasonTypeCloudFailure,
+								},
+							},
+						},
+					},
+				},
+			},
 			{
 				Method:   "POST",
 				Resource: "/api/2.1/clusters/permanent-delete",
@@ -211,6 +234,8 @@ func TestResourceClusterCreateErrorFollowedByDeletion(t *testing.T) {
 		},
 	}.Apply(t)
 	assert.ErrorContains(t, err, "failed to reach RUNNING, got TERMINATED")
+	assert.ErrorContains(t, err, "cluster abc failed to start: cloud quota (AWS_INSUFFICIENT_INSTANCE_CAPACITY_FAILURE)")
+	assert.ErrorContains(t, err, "Remediation: Request a quota increase")
 	assert.Equal(t, "abc", d.Id())
 }
 
@@ -245,6 +270,29 @@ func TestResourceClusterCreateErrorFollowedByDeletionError(t *testing.T) {
 					State:                  compute.StateTerminated,
 				},
 			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/events",
+				ExpectedRequest: compute.GetEvents{
+					ClusterId: "abc",
+					Order:     compute.GetEventsOrderDesc,
+					Limit:     25,
+				},
+				Response: compute.GetEventsResponse{
+					Events: []compute.ClusterEvent{
+						{
+							ClusterId: "abc",
+							Type:      compute.EventTypeTerminating,
+							Details: &compute.EventDetails{
+								Reason: &compute.TerminationReason{
+									Code: compute.TerminationReasonCodeAwsInsufficientInstanceCapacityFailure,
+									Type: compute.TerminationReasonTypeCloudFailure,
+								},
+							},
+						},
+					},
+				},
+			},
 			{
 				Method:   "POST",
 				Resource: "/api/2.1/clusters/permanent-delete",
@@ -268,7 +316,8 @@ func TestResourceClusterCreateErrorFollowedByDeletionError(t *testing.T) {
 			"num_workers":             100,
 		},
 	}.Apply(t)
-	assert.ErrorContains(t, err, "failed to create cluster: failed to reach RUNNING, got TERMINATED:  and failed to delete it during cleanup: Internal error happened")
+	assert.ErrorContains(t, err, "failed to create cluster: cluster abc failed to start: cloud quota (AWS_INSUFFICIENT_INSTANCE_CAPACITY_FAILURE): failed to reach RUNNING, got TERMINATED")
+	assert.ErrorContains(t, err, "and failed to delete it during cleanup: Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
 
@@ -68,6 +68,16 @@ type DatabricksClient struct {
 	// configured for the provider
 	cachedAccountClient *databricks.AccountClient
 
+	// deletionProtectedResourceTypes are resource types, that can't be deleted without
+	// explicitly clearing the `deletion_protection` attribute
+	deletionProtectedResourceTypes []string
+
+	// defaultTags are tags, that are added to all taggable resources
+	defaultTags map[string]string
+
+	// retryPolicies are policies for classes of errors configured in the `retry` provider block
+	retryPolicies RetryPolicies
+
 	// mu synchronizes access to all cached clients.
 	mu sync.Mutex
 }
@@ -140,6 +150,31 @@ func (c *DatabricksClient) SetWorkspaceClientForWorkspace(workspaceId int64, w *
 	c.cachedWorkspaceClients[workspaceId] = w
 }
 
+// InWorkspace returns a DatabricksClient for the given workspace, that is derived from the account-level
+// client, so that workspace-level resources could be managed from an account-level provider.
+func (c *DatabricksClient) InWorkspace(ctx context.Context, workspaceId int64) (*DatabricksClient, error) {
+	if !c.Config.IsAccountClient() {
+		return nil, fmt.Errorf("workspace_id can only be used with an account-level provider")
+	}
+	w, err := c.WorkspaceClientForWorkspace(ctx, workspaceId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get client for workspace %d: %w", workspaceId, err)
+	}
+	client, err := client.New(w.Config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure client for workspace %d: %w", workspaceId, err)
+	}
+	return &DatabricksClient{
+		DatabricksClient:      client,
+		commandFactory:        c.commandFactory,
+		cachedWorkspaceClient: w,
+
+		deletionProtectedResourceTypes: c.deletionProtectedResourceTypes,
+		defaultTags:                    c.defaultTags,
+		retryPolicies:                  c.retryPolicies,
+	}, nil
+}
+
 // Set the cached account client.
 func (c *DatabricksClient) SetAccountClient(a *databricks.AccountClient) {
 	c.mu.Lock()
@@ -356,6 +391,10 @@ func (c *DatabricksClient) ClientForHost(ctx context.Context, url string) (*Data
 	return &DatabricksClient{
 		DatabricksClient: client,
 		commandFactory:   c.commandFactory,
+
+		deletionProtectedResourceTypes: c.deletionProtectedResourceTypes,
+		defaultTags:                    c.defaultTags,
+		retryPolicies:                  c.retryPolicies,
 	}, nil
 }
 
@@ -2,12 +2,14 @@ package common
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"github.com/databricks/databricks-sdk-go/useragent"
 	"github.com/databricks/terraform-provider-databricks/internal/providers/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const sdkName = "sdkv2"
@@ -16,12 +18,12 @@ const sdkName = "sdkv2"
 func AddContextToAllResources(p *schema.Provider, prefix string) {
 	for k, r := range p.DataSourcesMap {
 		name := strings.ReplaceAll(k, prefix+"_", "")
-		wrap := op(r.ReadContext).addContext(ResourceName, name).addContext(IsData, "yes").addContext(Sdk, sdkName)
+		wrap := op(r.ReadContext).withSpan("data."+k, "read").
+			addContext(ResourceName, name).addContext(IsData, "yes").addContext(Sdk, sdkName)
 		r.ReadContext = schema.ReadContextFunc(wrap)
 	}
 	for k, r := range p.ResourcesMap {
-		k = strings.ReplaceAll(k, prefix+"_", "")
-		addContextToResource(k, r)
+		addContextToResource(strings.ReplaceAll(k, prefix+"_", ""), k, r)
 	}
 }
 
@@ -44,20 +46,40 @@ func (f op) addContext(k contextKey, v string) op {
 	}
 }
 
-func addContextToResource(name string, r *schema.Resource) {
-	addName := func(a op) func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-		return a.addContext(ResourceName, name).addContext(Sdk, sdkName)
+// wrap operation invocations with a tracing span, that is a parent of spans for all HTTP requests made by it
+func (f op) withSpan(resourceType, operation string) op {
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		ctx, span := StartResourceSpan(ctx, resourceType, operation)
+		diags := f(ctx, d, m)
+		if d != nil {
+			span.SetAttributes(attribute.String("terraform.resource.id", d.Id()))
+		}
+		var err error
+		for _, v := range diags {
+			if v.Severity == diag.Error {
+				err = errors.New(v.Summary)
+				break
+			}
+		}
+		EndSpan(span, err)
+		return diags
+	}
+}
+
+func addContextToResource(name, resourceType string, r *schema.Resource) {
+	addName := func(a op, operation string) func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		return a.withSpan(resourceType, operation).addContext(ResourceName, name).addContext(Sdk, sdkName)
 	}
 	if r.CreateContext != nil {
-		r.CreateContext = addName(op(r.CreateContext))
+		r.CreateContext = addName(op(r.CreateContext), "create")
 	}
 	if r.ReadContext != nil {
-		r.ReadContext = addName(op(r.ReadContext))
+		r.ReadContext = addName(op(r.ReadContext), "read")
 	}
 	if r.UpdateContext != nil {
-		r.UpdateContext = addName(op(r.UpdateContext))
+		r.UpdateContext = addName(op(r.UpdateContext), "update")
 	}
 	if r.DeleteContext != nil {
-		r.DeleteContext = addName(op(r.DeleteContext))
+		r.DeleteContext = addName(op(r.DeleteContext), "delete")
 	}
 }
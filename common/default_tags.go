@@ -0,0 +1,178 @@
+package common
+
+import (
+	"context"
+	"maps"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DefaultTagsField is the provider block with tags, that are added to all taggable resources
+const DefaultTagsField = "default_tags"
+
+// DefaultTagsTagsField is the map of tags in the `default_tags` provider block
+const DefaultTagsTagsField = "tags"
+
+// TagsAllField is the attribute with all tags of the resource, including `default_tags` of the provider
+const TagsAllField = "tags_all"
+
+// TagsAllSchema returns schema of the `tags_all` attribute
+func TagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "All tags of the object, including `default_tags` of the provider configuration.",
+	}
+}
+
+// SetDefaultTags sets tags, that are added to all taggable resources
+func (c *DatabricksClient) SetDefaultTags(tags map[string]string) {
+	c.defaultTags = tags
+}
+
+// MergeDefaultTags returns tags of the resource merged with `default_tags` of the provider. Tags of the resource
+// take precedence over default tags with the same key. Nil is returned if there are no tags at all.
+func (c *DatabricksClient) MergeDefaultTags(tags map[string]string) map[string]string {
+	var defaultTags map[string]string
+	if c != nil {
+		defaultTags = c.defaultTags
+	}
+	if len(defaultTags) == 0 && len(tags) == 0 {
+		return tags
+	}
+	merged := maps.Clone(defaultTags)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	maps.Copy(merged, tags)
+	return merged
+}
+
+// withDefaultTags adds the `tags_all` attribute to the resource schema. After read, tags from `default_tags`
+// of the provider are removed from the tags of the resource, unless they were set explicitly, so that tags
+// added with the request don't show up as a diff.
+func (r Resource) withDefaultTags() Resource {
+	s := make(map[string]*schema.Schema, len(r.Schema)+1)
+	for k, v := range r.Schema {
+		s[k] = v
+	}
+	s[TagsAllField] = TagsAllSchema()
+	r.Schema = s
+	read := r.Read
+	r.Read = func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		configured := getTags(d.Get(r.TagsPath[0]), r.TagsPath[1:])
+		err := read(ctx, d, c)
+		if err != nil || d.Id() == "" {
+			return err
+		}
+		root := d.Get(r.TagsPath[0])
+		tags := getTags(root, r.TagsPath[1:])
+		if err = d.Set(TagsAllField, tags); err != nil {
+			return err
+		}
+		stripped := maps.Clone(tags)
+		for k, v := range c.MergeDefaultTags(nil) {
+			if _, ok := configured[k]; !ok && stripped[k] == v {
+				delete(stripped, k)
+			}
+		}
+		if len(stripped) == len(tags) {
+			return nil
+		}
+		return d.Set(r.TagsPath[0], setTags(root, r.TagsPath[1:], stripped))
+	}
+	return r
+}
+
+// tagsAllCustomizeDiff plans `tags_all` as tags of the resource merged with `default_tags` of the provider
+func tagsAllCustomizeDiff(path []string, next schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+		if next != nil {
+			if err := next(ctx, d, m); err != nil {
+				return err
+			}
+		}
+		if !isConfigKnown(d, path[0]) {
+			return d.SetNewComputed(TagsAllField)
+		}
+		c, _ := m.(*DatabricksClient)
+		merged := c.MergeDefaultTags(getTags(d.Get(path[0]), path[1:]))
+		old := map[string]string{}
+		for k, v := range d.Get(TagsAllField).(map[string]any) {
+			old[k] = v.(string)
+		}
+		if len(merged) == 0 && len(old) == 0 || reflect.DeepEqual(merged, old) {
+			return nil
+		}
+		return d.SetNew(TagsAllField, merged)
+	}
+}
+
+// isConfigKnown returns false, if the configuration of the top-level attribute depends on unknown values
+func isConfigKnown(d *schema.ResourceDiff, name string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(name) {
+		return true
+	}
+	return raw.GetAttr(name).IsWhollyKnown()
+}
+
+// getTags returns tags from the value of the top-level attribute. Tags are either a map, or a list of objects
+// with `key` and `value` attributes. The path contains names of nested blocks with a single element.
+func getTags(v any, path []string) map[string]string {
+	for _, name := range path {
+		list, ok := v.([]any)
+		if !ok || len(list) == 0 || list[0] == nil {
+			return map[string]string{}
+		}
+		v = list[0].(map[string]any)[name]
+	}
+	tags := map[string]string{}
+	switch v := v.(type) {
+	case map[string]any:
+		for k, value := range v {
+			tags[k] = value.(string)
+		}
+	case []any:
+		for _, item := range v {
+			if pair, ok := item.(map[string]any); ok {
+				key, _ := pair["key"].(string)
+				value, _ := pair["value"].(string)
+				tags[key] = value
+			}
+		}
+	}
+	return tags
+}
+
+// setTags returns the value of the top-level attribute with tags replaced, keeping the shape of the original value
+// and the order of key-value pairs.
+func setTags(v any, path []string, tags map[string]string) any {
+	if len(path) > 0 {
+		list := v.([]any)
+		block := maps.Clone(list[0].(map[string]any))
+		block[path[0]] = setTags(block[path[0]], path[1:], tags)
+		return append([]any{block}, list[1:]...)
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		result := map[string]any{}
+		for k, value := range tags {
+			result[k] = value
+		}
+		return result
+	case []any:
+		result := []any{}
+		for _, item := range v {
+			pair, _ := item.(map[string]any)
+			key, _ := pair["key"].(string)
+			if _, ok := tags[key]; ok {
+				result = append(result, pair)
+			}
+		}
+		return result
+	}
+	return v
+}
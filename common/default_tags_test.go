@@ -0,0 +1,117 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeDefaultTags(t *testing.T) {
+	var c *DatabricksClient
+	assert.Nil(t, c.MergeDefaultTags(nil))
+	c = &DatabricksClient{}
+	assert.Equal(t, map[string]string{"a": "b"}, c.MergeDefaultTags(map[string]string{"a": "b"}))
+	c.SetDefaultTags(map[string]string{"a": "default", "c": "d"})
+	assert.Equal(t, map[string]string{"a": "b", "c": "d"}, c.MergeDefaultTags(map[string]string{"a": "b"}))
+	assert.Equal(t, map[string]string{"a": "default", "c": "d"}, c.MergeDefaultTags(nil))
+}
+
+func TestGetAndSetNestedTags(t *testing.T) {
+	v := []any{
+		map[string]any{
+			"custom_tags": []any{
+				map[string]any{"key": "a", "value": "b"},
+				map[string]any{"key": "c", "value": "d"},
+			},
+		},
+	}
+	path := []string{"custom_tags"}
+	assert.Equal(t, map[string]string{"a": "b", "c": "d"}, getTags(v, path))
+	assert.Equal(t, []any{
+		map[string]any{
+			"custom_tags": []any{
+				map[string]any{"key": "c", "value": "d"},
+			},
+		},
+	}, setTags(v, path, map[string]string{"c": "d"}))
+	assert.Equal(t, map[string]string{}, getTags([]any{}, path))
+}
+
+func defaultTagsTestResource() *schema.Resource {
+	return Resource{
+		Create: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			d.SetId("abc")
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			// tags are returned merged with default tags
+			return d.Set("custom_tags", map[string]any{"a": "b", "team": "data"})
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error { return nil },
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error { return nil },
+		Schema: map[string]*schema.Schema{
+			"custom_tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		TagsPath: []string{"custom_tags"},
+	}.ToResource()
+}
+
+func TestDefaultTagsAreRemovedAfterRead(t *testing.T) {
+	res := defaultTagsTestResource()
+	require.Contains(t, res.Schema, TagsAllField)
+	c := &DatabricksClient{}
+	c.SetDefaultTags(map[string]string{"team": "data"})
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]any{
+		"custom_tags": map[string]any{"a": "b"},
+	})
+	diags := res.CreateContext(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, map[string]any{"a": "b"}, d.Get("custom_tags"))
+	assert.Equal(t, map[string]any{"a": "b", "team": "data"}, d.Get(TagsAllField))
+
+	// explicitly configured tags are kept, even if they are the same as default tags
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]any{
+		"custom_tags": map[string]any{"a": "b", "team": "data"},
+	})
+	diags = res.CreateContext(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, map[string]any{"a": "b", "team": "data"}, d.Get("custom_tags"))
+}
+
+func TestDefaultTagsPlanTagsAll(t *testing.T) {
+	res := defaultTagsTestResource()
+	c := &DatabricksClient{}
+	c.SetDefaultTags(map[string]string{"team": "data"})
+	state := &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"id":            "abc",
+			"custom_tags.%": "1",
+			"custom_tags.a": "b",
+			"tags_all.%":    "2",
+			"tags_all.a":    "b",
+			"tags_all.team": "data",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"custom_tags": map[string]any{"a": "b"},
+	})
+	diff, err := res.Diff(context.Background(), state, config, c)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	c.SetDefaultTags(map[string]string{"team": "platform"})
+	diff, err = res.Diff(context.Background(), state, config, c)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Equal(t, "platform", diff.Attributes["tags_all.team"].New)
+	assert.NotContains(t, diff.Attributes, "custom_tags.team")
+}
@@ -0,0 +1,141 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DeletionProtectionField is the name of the provider block and of the attribute, that is added to all resources,
+// so that they couldn't be deleted by accident.
+const DeletionProtectionField = "deletion_protection"
+
+// DeletionProtectionResourceTypesField lists resource types, that are protected by the provider block
+const DeletionProtectionResourceTypesField = "resource_types"
+
+// DeletionProtectionSchema returns schema of the `deletion_protection` resource attribute
+func DeletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Description: "Prevents deletion of the object. When not set, resource types from " +
+			"`deletion_protection.resource_types` of the provider configuration are protected. " +
+			"It has to be set to `false` and applied before the object can be deleted.",
+	}
+}
+
+// SetDeletionProtectedResourceTypes sets resource types, i.e. `databricks_catalog`, that can't be deleted,
+// unless `deletion_protection = false` is explicitly set on the resource.
+func (c *DatabricksClient) SetDeletionProtectedResourceTypes(resourceTypes []string) error {
+	for _, resourceType := range resourceTypes {
+		if err := validateFullResourceType(resourceType); err != nil {
+			return err
+		}
+	}
+	c.deletionProtectedResourceTypes = resourceTypes
+	return nil
+}
+
+// ValidateDeletionProtectedResourceTypes checks, that resource types are known, so that a typo doesn't
+// silently leave resources unprotected
+func ValidateDeletionProtectedResourceTypes(resourceTypes, knownResourceTypes []string) error {
+	for _, resourceType := range resourceTypes {
+		if err := validateFullResourceType(resourceType); err != nil {
+			return err
+		}
+		if !slices.Contains(knownResourceTypes, resourceType) {
+			return fmt.Errorf("%s.%s contains unknown resource type %s",
+				DeletionProtectionField, DeletionProtectionResourceTypesField, resourceType)
+		}
+	}
+	return nil
+}
+
+func validateFullResourceType(resourceType string) error {
+	if !strings.HasPrefix(resourceType, "databricks_") {
+		return fmt.Errorf("%s.%s must contain full resource types, i.e. databricks_catalog, got %s",
+			DeletionProtectionField, DeletionProtectionResourceTypesField, resourceType)
+	}
+	return nil
+}
+
+// IsDeletionProtected returns true, if the resource type is protected by the provider configuration
+func (c *DatabricksClient) IsDeletionProtected(resourceType string) bool {
+	if c == nil {
+		return false
+	}
+	return slices.Contains(c.deletionProtectedResourceTypes, resourceType)
+}
+
+// supportsDeletionProtection checks if the `deletion_protection` attribute has to be added to the resource
+func (r Resource) supportsDeletionProtection() bool {
+	if r.Delete == nil {
+		return false
+	}
+	// don't clash with attributes, that have the same name in the API
+	_, ok := r.Schema[DeletionProtectionField]
+	return !ok
+}
+
+// withDeletionProtection adds the `deletion_protection` attribute to the resource schema and fails delete,
+// when the object is protected. Changes of the attribute are applied without calling the update of the resource.
+func (r Resource) withDeletionProtection() Resource {
+	if r.Update == nil {
+		// all other attributes still require replacement of the object
+		setForceNew(r.Schema)
+		r.Update = func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return nil
+		}
+	} else {
+		update := r.Update
+		r.Update = func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			if !d.HasChangesExcept(DeletionProtectionField) {
+				return nil
+			}
+			return update(ctx, d, c)
+		}
+	}
+	s := make(map[string]*schema.Schema, len(r.Schema)+1)
+	for k, v := range r.Schema {
+		s[k] = v
+	}
+	s[DeletionProtectionField] = DeletionProtectionSchema()
+	r.Schema = s
+	del := r.Delete
+	r.Delete = func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		resourceType := "databricks_" + ResourceName.GetOrUnknown(ctx)
+		protected, explicit := deletionProtectionFromState(d)
+		if !explicit {
+			protected = c.IsDeletionProtected(resourceType)
+		}
+		if protected {
+			return fmt.Errorf("%s is protected from deletion. Set %s = false on the resource and apply "+
+				"the change before deleting it", resourceType, DeletionProtectionField)
+		}
+		return del(ctx, d, c)
+	}
+	return r
+}
+
+// deletionProtectionFromState returns the value of the `deletion_protection` attribute in the prior state,
+// so that the attribute has to be cleared in a separate apply. The last return value is false, if the
+// attribute isn't set.
+func deletionProtectionFromState(d *schema.ResourceData) (bool, bool) {
+	raw := d.GetRawState()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() ||
+		!raw.Type().HasAttribute(DeletionProtectionField) {
+		// there's no prior state in unit tests
+		if d.Get(DeletionProtectionField).(bool) {
+			return true, true
+		}
+		return false, false
+	}
+	v := raw.GetAttr(DeletionProtectionField)
+	if v.IsNull() || !v.IsKnown() {
+		return false, false
+	}
+	return v.True(), true
+}
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func deletionProtectionTestResource(update func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error) Resource {
+	return Resource{
+		Create: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			d.SetId("abc")
+			return nil
+		},
+		Read:   func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error { return nil },
+		Update: update,
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error { return nil },
+		Schema: map[string]*schema.Schema{
+			"foo": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func TestDeletionProtectionIsAddedToSchema(t *testing.T) {
+	r := deletionProtectionTestResource(nil)
+	res := r.ToResource()
+	require.Contains(t, res.Schema, DeletionProtectionField)
+	assert.NotContains(t, r.Schema, DeletionProtectionField)
+	assert.False(t, res.Schema[DeletionProtectionField].ForceNew)
+	// the attribute can be changed in place, but all other changes still require replacement
+	assert.True(t, res.Schema["foo"].ForceNew)
+	assert.NotNil(t, res.UpdateContext)
+	assert.NoError(t, res.InternalValidate(nil, true))
+
+	r.Delete = nil
+	assert.NotContains(t, r.ToResource().Schema, DeletionProtectionField)
+}
+
+func TestDeletionProtectionSkipsUpdate(t *testing.T) {
+	updated := false
+	res := deletionProtectionTestResource(func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		updated = true
+		return nil
+	}).ToResource()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]any{
+		DeletionProtectionField: true,
+	})
+	diags := res.UpdateContext(context.Background(), d, &DatabricksClient{})
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.False(t, updated)
+
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]any{
+		DeletionProtectionField: true,
+		"foo":                   "bar",
+	})
+	diags = res.UpdateContext(context.Background(), d, &DatabricksClient{})
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.True(t, updated)
+}
+
+func TestDeletionProtection(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ResourceName, "catalog")
+	res := deletionProtectionTestResource(nil).ToResource()
+	protectedClient := &DatabricksClient{}
+	require.NoError(t, protectedClient.SetDeletionProtectedResourceTypes([]string{"databricks_catalog"}))
+	tests := []struct {
+		name      string
+		value     cty.Value
+		client    *DatabricksClient
+		protected bool
+	}{
+		{"not set", cty.NullVal(cty.Bool), &DatabricksClient{}, false},
+		{"set", cty.True, &DatabricksClient{}, true},
+		{"cleared", cty.False, &DatabricksClient{}, false},
+		{"provider", cty.NullVal(cty.Bool), protectedClient, true},
+		{"cleared with provider", cty.False, protectedClient, false},
+		{"set with provider", cty.True, protectedClient, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := res.Data(&terraform.InstanceState{
+				ID: "abc",
+				RawState: cty.ObjectVal(map[string]cty.Value{
+					"id":                    cty.StringVal("abc"),
+					DeletionProtectionField: tt.value,
+				}),
+			})
+			diags := res.DeleteContext(ctx, d, tt.client)
+			if tt.protected {
+				require.True(t, diags.HasError())
+				assert.Equal(t, "cannot delete catalog: databricks_catalog is protected from deletion. "+
+					"Set deletion_protection = false on the resource and apply the change before deleting it", diags[0].Summary)
+			} else {
+				assert.False(t, diags.HasError(), "%v", diags)
+			}
+		})
+	}
+}
+
+func TestSetDeletionProtectedResourceTypes(t *testing.T) {
+	c := &DatabricksClient{}
+	assert.False(t, c.IsDeletionProtected("databricks_catalog"))
+	require.NoError(t, c.SetDeletionProtectedResourceTypes([]string{"databricks_catalog", "databricks_metastore"}))
+	assert.True(t, c.IsDeletionProtected("databricks_metastore"))
+	assert.False(t, c.IsDeletionProtected("databricks_schema"))
+	err := c.SetDeletionProtectedResourceTypes([]string{"catalog"})
+	assert.EqualError(t, err, "deletion_protection.resource_types must contain full resource types, "+
+		"i.e. databricks_catalog, got catalog")
+}
+
+func TestValidateDeletionProtectedResourceTypes(t *testing.T) {
+	known := []string{"databricks_catalog", "databricks_mws_workspaces"}
+	assert.NoError(t, ValidateDeletionProtectedResourceTypes([]string{"databricks_catalog"}, known))
+	assert.EqualError(t, ValidateDeletionProtectedResourceTypes([]string{"databricks_catalogs"}, known),
+		"deletion_protection.resource_types contains unknown resource type databricks_catalogs")
+	assert.EqualError(t, ValidateDeletionProtectedResourceTypes([]string{"databricks_mws_workspace"}, known),
+		"deletion_protection.resource_types contains unknown resource type databricks_mws_workspace")
+	assert.EqualError(t, ValidateDeletionProtectedResourceTypes([]string{"catalog"}, known),
+		"deletion_protection.resource_types must contain full resource types, i.e. databricks_catalog, got catalog")
+}
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/databricks/databricks-sdk-go/config"
+)
+
+// transportWrapper is embedded into transports, that wrap the transport of the config
+type transportWrapper struct {
+	inner http.RoundTripper
+}
+
+// SkipRetryOnIO is forwarded to the wrapped transport, so that HTTP fixtures in unit tests keep working
+func (t transportWrapper) SkipRetryOnIO() bool {
+	skippable, ok := t.inner.(interface {
+		SkipRetryOnIO() bool
+	})
+	return ok && skippable.SkipRetryOnIO()
+}
+
+// TransportOptions configure transports, that EnableTransports wraps around the transport of the config
+type TransportOptions struct {
+	// RetryPolicies are classes of errors configured in the `retry` provider block
+	RetryPolicies RetryPolicies
+	// ReadOnly blocks all requests, that could change objects
+	ReadOnly bool
+}
+
+// EnableTransports makes all clients created from the given config record spans for HTTP requests, retry them
+// according to the `retry` provider block and block changes in read-only mode. Transports are wrapped in this
+// order, so that every retry of a request has its own span, and requests blocked in read-only mode are never
+// sent, retried or traced.
+func EnableTransports(cfg *config.Config, opts TransportOptions) {
+	enableTracing(cfg)
+	enableRetryPolicies(cfg, opts.RetryPolicies)
+	if opts.ReadOnly {
+		enableReadOnlyMode(cfg)
+	}
+}
+
+// defaultHTTPTransport returns the transport of the config, or the same transport as the default one
+// of the Databricks SDK, so that it could be wrapped
+func defaultHTTPTransport(cfg *config.Config) http.RoundTripper {
+	if cfg.HTTPTransport != nil {
+		return cfg.HTTPTransport
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.InsecureSkipVerify {
+		t.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return t
+}
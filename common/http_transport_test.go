@@ -0,0 +1,54 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type skipRetryOnIOTransport struct {
+	roundTripFunc
+}
+
+func (skipRetryOnIOTransport) SkipRetryOnIO() bool {
+	return true
+}
+
+func TestEnableTransportsOrder(t *testing.T) {
+	recordSpans(t)
+	previous := tracingEnabled.Swap(true)
+	t.Cleanup(func() { tracingEnabled.Store(previous) })
+
+	calls := 0
+	base := skipRetryOnIOTransport{func(r *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: 200, Body: http.NoBody, Request: r}, nil
+	}}
+	cfg := &config.Config{HTTPTransport: base}
+	EnableTransports(cfg, TransportOptions{
+		RetryPolicies: RetryPolicies{RetryServerError: {MaxAttempts: 2}},
+		ReadOnly:      true,
+	})
+
+	readOnly, ok := cfg.HTTPTransport.(*readOnlyTransport)
+	require.True(t, ok, "%T", cfg.HTTPTransport)
+	retry, ok := readOnly.inner.(*retryTransport)
+	require.True(t, ok, "%T", readOnly.inner)
+	tracing, ok := retry.inner.(*tracingTransport)
+	require.True(t, ok, "%T", retry.inner)
+	assert.IsType(t, base, tracing.inner)
+
+	for _, transport := range []http.RoundTripper{readOnly, retry, tracing} {
+		assert.True(t, transport.(interface{ SkipRetryOnIO() bool }).SkipRetryOnIO(), "%T", transport)
+	}
+	assert.False(t, transportWrapper{inner: http.DefaultTransport}.SkipRetryOnIO())
+
+	// blocked requests never reach the wrapped transports
+	_, err := cfg.HTTPTransport.RoundTrip(httptest.NewRequest("POST", "https://x/api/2.1/clusters/create", nil))
+	assert.ErrorIs(t, err, ErrReadOnly)
+	assert.Equal(t, 0, calls)
+}
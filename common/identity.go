@@ -0,0 +1,79 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// IdentityIdField is the only attribute of the resource identity. It has the same value as the resource ID,
+// so that resources could be imported either by ID or by identity.
+const IdentityIdField = "id"
+
+// IdentitySchema returns the schema of the resource identity
+func IdentitySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		IdentityIdField: {
+			Type:              schema.TypeString,
+			RequiredForImport: true,
+			Description:       "ID of the resource.",
+		},
+	}
+}
+
+// withIdentity sets the resource identity after every create, read and update
+func (r Resource) withIdentity() Resource {
+	r.Create = setIdentity(r.Create)
+	r.Read = setIdentity(r.Read)
+	r.Update = setIdentity(r.Update)
+	return r
+}
+
+func setIdentity(cb func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error) func(
+	ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+	if cb == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		err := cb(ctx, d, c)
+		if err != nil || d.Id() == "" {
+			return err
+		}
+		return SetIdentityFromId(d)
+	}
+}
+
+// SetIdentityFromId sets the resource identity from the resource ID
+func SetIdentityFromId(d *schema.ResourceData) error {
+	identity, err := d.Identity()
+	if err != nil {
+		// the identity isn't available outside of Terraform operations, e.g. in unit tests
+		return nil
+	}
+	return identity.Set(IdentityIdField, d.Id())
+}
+
+// importerWithIdentity sets the resource ID from the resource identity, when the resource is imported by identity
+func importerWithIdentity(importer *schema.ResourceImporter) *schema.ResourceImporter {
+	if importer == nil || importer.StateContext == nil {
+		return importer
+	}
+	stateContext := importer.StateContext
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+			if d.Id() == "" {
+				identity, err := d.Identity()
+				if err != nil {
+					return nil, err
+				}
+				id, ok := identity.Get(IdentityIdField).(string)
+				if !ok || id == "" {
+					return nil, fmt.Errorf("expected identity to contain %s", IdentityIdField)
+				}
+				d.SetId(id)
+			}
+			return stateContext(ctx, d, m)
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func identityTestResource() *schema.Resource {
+	return Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return d.Set("name", "from "+d.Id())
+		},
+		WithIdentity: true,
+	}.ToResource()
+}
+
+func TestResourceWithIdentity_Read(t *testing.T) {
+	r := identityTestResource()
+	require.NotNil(t, r.Identity)
+	d := r.Data(&terraform.InstanceState{ID: "abc"})
+	diags := r.ReadContext(context.Background(), d, &DatabricksClient{})
+	require.False(t, diags.HasError(), diags)
+	identity, err := d.Identity()
+	require.NoError(t, err)
+	assert.Equal(t, "abc", identity.Get(IdentityIdField))
+}
+
+func TestResourceWithIdentity_Import(t *testing.T) {
+	r := identityTestResource()
+	d := r.Data(nil)
+	identity, err := d.Identity()
+	require.NoError(t, err)
+	require.NoError(t, identity.Set(IdentityIdField, "abc"))
+	imported, err := r.Importer.StateContext(context.Background(), d, &DatabricksClient{})
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Equal(t, "abc", imported[0].Id())
+	assert.Equal(t, "from abc", imported[0].Get("name"))
+}
+
+func TestResourceWithoutIdentity(t *testing.T) {
+	r := Resource{
+		Schema: map[string]*schema.Schema{},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return nil
+		},
+	}.ToResource()
+	assert.Nil(t, r.Identity)
+	d := r.Data(&terraform.InstanceState{ID: "abc"})
+	assert.NoError(t, SetIdentityFromId(d))
+}
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+)
+
+// NameFilter selects objects by their names in the same way as the `-match`, `-matchRegex`
+// and `-excludeRegex` flags of the exporter.
+type NameFilter struct {
+	// Match is a case-insensitive substring of the name
+	Match string
+	// MatchRegex takes precedence over Match
+	MatchRegex *regexp.Regexp
+	// ExcludeRegex excludes matching names even if they match MatchRegex or Match
+	ExcludeRegex *regexp.Regexp
+}
+
+// Matches checks if the name matches the filter. Empty filter matches all names.
+func (f NameFilter) Matches(name string) bool {
+	if f.Match == "" && f.MatchRegex == nil && f.ExcludeRegex == nil {
+		return true
+	}
+	if f.ExcludeRegex != nil && f.ExcludeRegex.MatchString(name) {
+		return false
+	}
+	if f.MatchRegex != nil {
+		return f.MatchRegex.MatchString(name)
+	}
+	return strings.Contains(strings.ToLower(name), strings.ToLower(f.Match))
+}
@@ -0,0 +1,25 @@
+package common
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameFilterMatches(t *testing.T) {
+	assert.True(t, NameFilter{}.Matches("anything"))
+	assert.True(t, NameFilter{Match: "shar"}.Matches("Shared Warehouse"))
+	assert.False(t, NameFilter{Match: "shar"}.Matches("Other"))
+
+	f := NameFilter{
+		Match:        "ignored",
+		MatchRegex:   regexp.MustCompile("^prod_"),
+		ExcludeRegex: regexp.MustCompile("_tmp$"),
+	}
+	assert.True(t, f.Matches("prod_sales"))
+	assert.False(t, f.Matches("dev_sales"))
+	assert.False(t, f.Matches("prod_sales_tmp"))
+	assert.False(t, NameFilter{ExcludeRegex: regexp.MustCompile("tmp")}.Matches("tmp"))
+	assert.True(t, NameFilter{ExcludeRegex: regexp.MustCompile("tmp")}.Matches("sales"))
+}
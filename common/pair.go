@@ -41,28 +41,34 @@ func (p *Pair) Schema(do func(map[string]*schema.Schema) map[string]*schema.Sche
 	return p
 }
 
-// Unpack ID into two strings and set data
-func (p *Pair) Unpack(d *schema.ResourceData) (string, string, error) {
-	id := d.Id()
+// Split ID into two non-empty strings
+func (p *Pair) Split(id string) (string, string, error) {
 	parts := strings.SplitN(id, p.separator, 2)
 	if len(parts) != 2 {
-		d.SetId("")
 		return "", "", fmt.Errorf("invalid ID: %s", id)
 	}
 	if parts[0] == "" {
-		d.SetId("")
 		return "", "", fmt.Errorf("%s cannot be empty", p.left)
 	}
 	if parts[1] == "" {
-		d.SetId("")
 		return "", "", fmt.Errorf("%s cannot be empty", p.right)
 	}
-	err := p.setField(d, p.left, parts[0])
+	return parts[0], parts[1], nil
+}
+
+// Unpack ID into two strings and set data
+func (p *Pair) Unpack(d *schema.ResourceData) (string, string, error) {
+	left, right, err := p.Split(d.Id())
+	if err != nil {
+		d.SetId("")
+		return "", "", err
+	}
+	err = p.setField(d, p.left, left)
 	if err != nil {
-		return parts[0], parts[1], err
+		return left, right, err
 	}
-	err = p.setField(d, p.right, parts[1])
-	return parts[0], parts[1], err
+	err = p.setField(d, p.right, right)
+	return left, right, err
 }
 
 func (p *Pair) setField(d *schema.ResourceData, col, val string) error {
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ReadOnlyField is the provider argument, that blocks all changes made by the provider
+const ReadOnlyField = "read_only"
+
+// ReadOnlyEnv is the environment variable, that sets `read_only` provider argument
+const ReadOnlyEnv = "DATABRICKS_READ_ONLY"
+
+// ErrReadOnly is returned when the provider is asked to change anything in read-only mode
+var ErrReadOnly = fmt.Errorf("the provider is configured with %s = true, so it can only read objects. "+
+	"Remove %s from the provider configuration or unset %s environment variable to apply changes",
+	ReadOnlyField, ReadOnlyField, ReadOnlyEnv)
+
+// readOnlyTransport blocks all requests, except those that only read data. OAuth token requests are allowed,
+// so that authentication works. Requests to Command Execution API (`/api/1.2/`) are allowed only from contexts
+// marked with WithReadOnlyCommands, that reads of mounts and `databricks_sql_permissions` use to execute read-only
+// commands on a cluster. Clusters for them must be running already, as creating or starting clusters is blocked
+// as any other change.
+type readOnlyTransport struct {
+	transportWrapper
+}
+
+func (t *readOnlyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !isReadOnlyRequest(r) {
+		return nil, fmt.Errorf("%s %s is blocked: %w", r.Method, r.URL.Path, ErrReadOnly)
+	}
+	return t.inner.RoundTrip(r)
+}
+
+// commandExecutionReadPaths are requests of CommandExecutor other than GET, that reads of mounts and
+// `databricks_sql_permissions` make
+var commandExecutionReadPaths = []string{
+	"/api/1.2/contexts/create",
+	"/api/1.2/commands/execute",
+	"/api/1.2/contexts/destroy",
+}
+
+type readOnlyCommandsKey struct{}
+
+// WithReadOnlyCommands marks the context of a read, that executes read-only commands on a cluster, so that
+// the command execution requests made with it aren't blocked in read-only mode
+func WithReadOnlyCommands(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyCommandsKey{}, true)
+}
+
+func isReadOnlyCommandsContext(ctx context.Context) bool {
+	allowed, _ := ctx.Value(readOnlyCommandsKey{}).(bool)
+	return allowed
+}
+
+// isTokenRequest returns true for requests to OAuth token endpoints of Databricks (`/oidc/.../token`)
+// and Microsoft Entra ID (`/<tenant>/oauth2/.../token`), that authentication needs
+func isTokenRequest(r *http.Request) bool {
+	path := r.URL.Path
+	if r.Method != http.MethodPost || !strings.HasSuffix(path, "/token") {
+		return false
+	}
+	return strings.HasPrefix(path, "/oidc/") || strings.Contains(path, "/oauth2/")
+}
+
+func isReadOnlyRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	if r.Method == http.MethodPost && slices.Contains(commandExecutionReadPaths, r.URL.Path) {
+		return isReadOnlyCommandsContext(r.Context())
+	}
+	return isTokenRequest(r)
+}
+
+// enableReadOnlyMode makes all clients created from the given config fail requests that could change objects.
+// Clients derived from it, i.e. for other workspaces, inherit the same transport.
+func enableReadOnlyMode(cfg *config.Config) {
+	if IsReadOnlyMode(cfg) {
+		return
+	}
+	cfg.HTTPTransport = &readOnlyTransport{transportWrapper{inner: defaultHTTPTransport(cfg)}}
+}
+
+// IsReadOnlyMode returns true, if the config was passed to EnableTransports with ReadOnly
+func IsReadOnlyMode(cfg *config.Config) bool {
+	if cfg == nil {
+		return false
+	}
+	_, ok := cfg.HTTPTransport.(*readOnlyTransport)
+	return ok
+}
+
+// IsReadOnly returns true, if the provider is configured with `read_only = true`
+func (c *DatabricksClient) IsReadOnly() bool {
+	if c == nil || c.DatabricksClient == nil {
+		return false
+	}
+	return IsReadOnlyMode(c.Config)
+}
+
+// withReadOnlyCheck fails create, update and delete before any request is made, if the provider is read-only
+func (r Resource) withReadOnlyCheck() Resource {
+	r.Create = failIfReadOnly(r.Create)
+	r.Update = failIfReadOnly(r.Update)
+	r.Delete = failIfReadOnly(r.Delete)
+	return r
+}
+
+func failIfReadOnly(cb func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error) func(
+	ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+	if cb == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		if c.IsReadOnly() {
+			return ErrReadOnly
+		}
+		return cb(ctx, d, c)
+	}
+}
@@ -0,0 +1,109 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestReadOnlyTransport(t *testing.T) {
+	cfg := &config.Config{
+		HTTPTransport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: http.NoBody, Request: r}, nil
+		}),
+	}
+	assert.False(t, IsReadOnlyMode(cfg))
+	EnableTransports(cfg, TransportOptions{ReadOnly: true})
+	EnableTransports(cfg, TransportOptions{ReadOnly: true})
+	assert.True(t, IsReadOnlyMode(cfg))
+	tests := []struct {
+		method, path string
+		commands     bool
+		allowed      bool
+	}{
+		{"GET", "/api/2.1/clusters/get", false, true},
+		{"HEAD", "/api/2.0/fs/files/a", false, true},
+		{"POST", "/oidc/v1/token", false, true},
+		{"POST", "/oidc/accounts/abc/v1/token", false, true},
+		{"POST", "/tenant/oauth2/v2.0/token", false, true},
+		{"POST", "/oidc/v1/authorize", false, false},
+		{"POST", "/graphql", false, false},
+		{"DELETE", "/oidc/v1/token", false, false},
+		{"POST", "/api/1.2/contexts/create", false, false},
+		{"POST", "/api/1.2/commands/execute", false, false},
+		{"POST", "/api/1.2/contexts/create", true, true},
+		{"POST", "/api/1.2/commands/execute", true, true},
+		{"POST", "/api/1.2/contexts/destroy", true, true},
+		{"POST", "/api/1.2/commands/cancel", true, false},
+		{"PUT", "/api/1.2/commands/execute", true, false},
+		{"POST", "/api/2.1/clusters/start", true, false},
+		{"PATCH", "/api/2.1/unity-catalog/catalogs/main", false, false},
+		{"DELETE", "/api/2.0/token/delete", false, false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "https://x"+tt.path, nil)
+		if tt.commands {
+			r = r.WithContext(WithReadOnlyCommands(r.Context()))
+		}
+		_, err := cfg.HTTPTransport.RoundTrip(r)
+		if tt.allowed {
+			assert.NoError(t, err, "%s %s", tt.method, tt.path)
+		} else {
+			assert.ErrorIs(t, err, ErrReadOnly, "%s %s", tt.method, tt.path)
+		}
+	}
+}
+
+func TestReadOnlyResource(t *testing.T) {
+	cfg := &config.Config{}
+	EnableTransports(cfg, TransportOptions{ReadOnly: true})
+	c := &DatabricksClient{DatabricksClient: &client.DatabricksClient{Config: cfg}}
+	assert.True(t, c.IsReadOnly())
+	called := false
+	mutate := func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		called = true
+		return nil
+	}
+	r := Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		Create: mutate,
+		Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return nil
+		},
+		Update: mutate,
+		Delete: mutate,
+	}.ToResource()
+	ctx := context.WithValue(context.Background(), ResourceName, "fake")
+	d := r.Data(&terraform.InstanceState{ID: "abc"})
+	diags := r.CreateContext(ctx, d, c)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "cannot create fake: the provider is configured with read_only = true")
+	assert.True(t, r.UpdateContext(ctx, d, c).HasError())
+	assert.True(t, r.DeleteContext(ctx, d, c).HasError())
+	assert.False(t, called)
+	assert.False(t, r.ReadContext(ctx, d, c).HasError())
+}
+
+func TestNotReadOnlyClient(t *testing.T) {
+	assert.False(t, (*DatabricksClient)(nil).IsReadOnly())
+	assert.False(t, (&DatabricksClient{DatabricksClient: &client.DatabricksClient{Config: &config.Config{}}}).IsReadOnly())
+}
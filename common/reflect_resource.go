@@ -336,6 +336,17 @@ func handleSensitive(typeField reflect.StructField, schema *schema.Schema) {
 	}
 }
 
+func handleWriteOnly(typeField reflect.StructField, schema *schema.Schema) {
+	tfTags := strings.Split(typeField.Tag.Get("tf"), ",")
+	for _, tag := range tfTags {
+		if tag == "write_only" {
+			schema.WriteOnly = true
+			schema.Computed = false
+			break
+		}
+	}
+}
+
 func handleSuppressDiff(typeField reflect.StructField, fieldName string, v *schema.Schema) {
 	tfTags := strings.Split(typeField.Tag.Get("tf"), ",")
 	for _, tag := range tfTags {
@@ -440,6 +451,7 @@ func typeToSchema(v reflect.Value, aliases map[string]map[string]string, tc trac
 		handleComputed(typeField, scm[fieldName])
 		handleForceNew(typeField, scm[fieldName])
 		handleSensitive(typeField, scm[fieldName])
+		handleWriteOnly(typeField, scm[fieldName])
 		switch typeField.Type.Kind() {
 		case reflect.Int, reflect.Int32, reflect.Int64:
 			scm[fieldName].Type = schema.TypeInt
@@ -17,11 +17,15 @@ import (
 
 // Resource aims to simplify things like error & deleted entities handling
 type Resource struct {
-	Create                          func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error
-	Read                            func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error
-	Update                          func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error
-	Delete                          func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error
-	CustomizeDiff                   func(ctx context.Context, d *schema.ResourceDiff) error
+	Create        func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error
+	Read          func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error
+	Update        func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error
+	Delete        func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error
+	CustomizeDiff func(ctx context.Context, d *schema.ResourceDiff) error
+	// CustomizeDiffWithClient is like CustomizeDiff, but also gets the client to check the diff against
+	// the provider configuration. It must not make any requests, as authentication isn't deterministic
+	// during plan.
+	CustomizeDiffWithClient         func(ctx context.Context, d *schema.ResourceDiff, c *DatabricksClient) error
 	StateUpgraders                  []schema.StateUpgrader
 	Schema                          map[string]*schema.Schema
 	SchemaVersion                   int
@@ -29,6 +33,16 @@ type Resource struct {
 	DeprecationMessage              string
 	Importer                        *schema.ResourceImporter
 	CanSkipReadAfterCreateAndUpdate func(d *schema.ResourceData) bool
+	// WorkspaceLevel marks resources and data sources that are managed only through the workspace API,
+	// so they get the `workspace_id` attribute and could be managed from an account-level provider.
+	WorkspaceLevel bool
+	// WithIdentity adds the resource identity with the `id` attribute, so that the resource
+	// could be listed with `terraform query` and imported by identity.
+	WithIdentity bool
+	// TagsPath is the path to tags of the resource, i.e. `custom_tags`, that are merged with `default_tags`
+	// of the provider. Tags are either a map, or a list of key-value objects in nested blocks with a single
+	// element. Create and Update have to send tags merged with DatabricksClient.MergeDefaultTags.
+	TagsPath []string
 }
 
 func nicerError(ctx context.Context, err error, action string) error {
@@ -59,10 +73,10 @@ func recoverable(cb func(
 }
 
 func (r Resource) saferCustomizeDiff() schema.CustomizeDiffFunc {
-	if r.CustomizeDiff == nil {
+	if r.CustomizeDiff == nil && r.CustomizeDiffWithClient == nil {
 		return nil
 	}
-	return func(ctx context.Context, rd *schema.ResourceDiff, _ any) (err error) {
+	return func(ctx context.Context, rd *schema.ResourceDiff, m any) (err error) {
 		defer func() {
 			// this is deliberate decision to convert a panic into error,
 			// so that any unforeseen bug would we visible to end-user
@@ -75,8 +89,15 @@ func (r Resource) saferCustomizeDiff() schema.CustomizeDiffFunc {
 		}()
 		// we don't propagate instance of SDK client to the diff function, because
 		// authentication is not deterministic at this stage with the recent Terraform
-		// versions. Diff customization must be limited to hermetic checks only anyway.
-		err = r.CustomizeDiff(ctx, rd)
+		// versions. Diff customization must be limited to hermetic checks only anyway,
+		// and CustomizeDiffWithClient may only check the provider configuration.
+		if r.CustomizeDiff != nil {
+			err = r.CustomizeDiff(ctx, rd)
+		}
+		if err == nil && r.CustomizeDiffWithClient != nil {
+			c, _ := m.(*DatabricksClient)
+			err = r.CustomizeDiffWithClient(ctx, rd, c)
+		}
 		if err != nil {
 			err = nicerError(ctx, err, "customize diff for")
 		}
@@ -86,53 +107,52 @@ func (r Resource) saferCustomizeDiff() schema.CustomizeDiffFunc {
 
 // ToResource converts to Terraform resource definition
 func (r Resource) ToResource() *schema.Resource {
+	// Ignore missing for read for resources, but not for data sources.
+	ignoreMissingForRead := (r.Create != nil || r.Update != nil || r.Delete != nil)
+	withWorkspaceId := r.supportsWorkspaceId()
+	if withWorkspaceId {
+		r = r.withWorkspaceId(ignoreMissingForRead)
+	}
+	if r.WithIdentity {
+		r = r.withIdentity()
+	}
+	if r.supportsDeletionProtection() {
+		r = r.withDeletionProtection()
+	}
+	if len(r.TagsPath) > 0 {
+		r = r.withDefaultTags()
+	}
+	r = r.withReadOnlyCheck()
+	r = r.withRetryPolicies()
 	var update func(ctx context.Context, d *schema.ResourceData,
 		m any) diag.Diagnostics
 	if r.Update != nil {
 		update = func(ctx context.Context, d *schema.ResourceData,
 			m any) diag.Diagnostics {
+			ctx, warnings := withWarnings(ctx)
 			c := m.(*DatabricksClient)
 			if err := recoverable(r.Update)(ctx, d, c); err != nil {
 				err = nicerError(ctx, err, "update")
-				return diag.FromErr(err)
+				return append(warnings.diagnostics(), diag.FromErr(err)...)
 			}
 			if r.CanSkipReadAfterCreateAndUpdate != nil && r.CanSkipReadAfterCreateAndUpdate(d) {
-				return nil
+				return warnings.diagnostics()
 			}
 			if err := recoverable(r.Read)(ctx, d, c); err != nil {
 				err = nicerError(ctx, err, "read")
-				return diag.FromErr(err)
+				return append(warnings.diagnostics(), diag.FromErr(err)...)
 			}
-			return nil
+			return warnings.diagnostics()
 		}
 	} else {
 		// set ForceNew to all attributes with CRD
-		queue := []*schema.Resource{
-			{Schema: r.Schema},
-		}
-		for {
-			head := queue[0]
-			queue = queue[1:]
-			for _, v := range head.Schema {
-				if v.Computed {
-					continue
-				}
-				if nested, ok := v.Elem.(*schema.Resource); ok {
-					queue = append(queue, nested)
-				}
-				v.ForceNew = true
-			}
-			if len(queue) == 0 {
-				break
-			}
-		}
+		setForceNew(r.Schema)
 	}
-	// Ignore missing for read for resources, but not for data sources.
-	ignoreMissingForRead := (r.Create != nil || r.Update != nil || r.Delete != nil)
 	generateReadFunc := func(ignoreMissing bool) func(ctx context.Context, d *schema.ResourceData,
 		m any) diag.Diagnostics {
 		return func(ctx context.Context, d *schema.ResourceData,
 			m any) diag.Diagnostics {
+			ctx, warnings := withWarnings(ctx)
 			err := recoverable(r.Read)(ctx, d, m.(*DatabricksClient))
 			// TODO: https://github.com/databricks/terraform-provider-databricks/issues/2021
 			if ignoreMissing && apierr.IsMissing(err) {
@@ -143,9 +163,9 @@ func (r Resource) ToResource() *schema.Resource {
 			}
 			if err != nil {
 				err = nicerError(ctx, err, "read")
-				return diag.FromErr(err)
+				return append(warnings.diagnostics(), diag.FromErr(err)...)
 			}
-			return nil
+			return warnings.diagnostics()
 		}
 	}
 	resource := &schema.Resource{
@@ -159,38 +179,43 @@ func (r Resource) ToResource() *schema.Resource {
 		Timeouts:           r.Timeouts,
 		DeprecationMessage: r.DeprecationMessage,
 	}
+	if len(r.TagsPath) > 0 {
+		resource.CustomizeDiff = tagsAllCustomizeDiff(r.TagsPath, resource.CustomizeDiff)
+	}
 	if r.Create != nil {
 		resource.CreateContext = func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+			ctx, warnings := withWarnings(ctx)
 			c := m.(*DatabricksClient)
 			err := recoverable(r.Create)(ctx, d, c)
 			if err != nil {
 				err = nicerError(ctx, err, "create")
-				return diag.FromErr(err)
+				return append(warnings.diagnostics(), diag.FromErr(err)...)
 			}
 			if r.CanSkipReadAfterCreateAndUpdate != nil && r.CanSkipReadAfterCreateAndUpdate(d) {
-				return nil
+				return warnings.diagnostics()
 			}
 			if err = recoverable(r.Read)(ctx, d, c); err != nil {
 				err = nicerError(ctx, err, "read")
-				return diag.FromErr(err)
+				return append(warnings.diagnostics(), diag.FromErr(err)...)
 			}
-			return nil
+			return warnings.diagnostics()
 		}
 	}
 	if r.Delete != nil {
 		resource.DeleteContext = func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+			ctx, warnings := withWarnings(ctx)
 			err := recoverable(r.Delete)(ctx, d, m.(*DatabricksClient))
 			if apierr.IsMissing(err) {
 				log.Printf("[INFO] %s[id=%s] is removed on backend",
 					ResourceName.GetOrUnknown(ctx), d.Id())
 				d.SetId("")
-				return nil
+				return warnings.diagnostics()
 			}
 			if err != nil {
 				err = nicerError(ctx, err, "delete")
-				return diag.FromErr(err)
+				return append(warnings.diagnostics(), diag.FromErr(err)...)
 			}
-			return nil
+			return warnings.diagnostics()
 		}
 	}
 	if resource.Importer == nil {
@@ -198,6 +223,11 @@ func (r Resource) ToResource() *schema.Resource {
 			StateContext: func(ctx context.Context, d *schema.ResourceData,
 				m any) (data []*schema.ResourceData, e error) {
 				d.MarkNewResource()
+				if withWorkspaceId {
+					if err := setWorkspaceIdFromResourceId(d); err != nil {
+						return nil, err
+					}
+				}
 				diags := generateReadFunc(false)(ctx, d, m)
 				var err error
 				if diags.HasError() {
@@ -207,9 +237,42 @@ func (r Resource) ToResource() *schema.Resource {
 			},
 		}
 	}
+	if r.WithIdentity {
+		resource.Identity = &schema.ResourceIdentity{
+			SchemaFunc: IdentitySchema,
+		}
+		resource.Importer = importerWithIdentity(resource.Importer)
+	}
 	return resource
 }
 
+// setForceNew sets ForceNew to all attributes, that can be set by the user
+func setForceNew(s map[string]*schema.Schema) {
+	queue := []*schema.Resource{
+		{Schema: s},
+	}
+	for {
+		head := queue[0]
+		queue = queue[1:]
+		for _, v := range head.Schema {
+			if v.Computed {
+				continue
+			}
+			if v.WriteOnly {
+				// write-only attributes can't force replacement, their `_wo_version` attributes do
+				continue
+			}
+			if nested, ok := v.Elem.(*schema.Resource); ok {
+				queue = append(queue, nested)
+			}
+			v.ForceNew = true
+		}
+		if len(queue) == 0 {
+			break
+		}
+	}
+}
+
 func MustCompileKeyRE(name string) *regexp.Regexp {
 	regexFromName := strings.ReplaceAll(name, ".", "\\.")
 	regexFromName = strings.ReplaceAll(regexFromName, ".0", ".\\d+")
@@ -256,9 +319,11 @@ func DataResource(sc any, read func(context.Context, any, *DatabricksClient) err
 //		...
 //	})
 func WorkspaceData[T any](read func(context.Context, *T, *databricks.WorkspaceClient) error) Resource {
-	return genericDatabricksData((*DatabricksClient).WorkspaceClient, func(ctx context.Context, s struct{}, t *T, wc *databricks.WorkspaceClient) error {
+	r := genericDatabricksData((*DatabricksClient).WorkspaceClient, func(ctx context.Context, s struct{}, t *T, wc *databricks.WorkspaceClient) error {
 		return read(ctx, t, wc)
 	}, false, NoCustomize)
+	r.WorkspaceLevel = true
+	return r
 }
 
 // WorkspaceDataWithParams defines a data source that can be used to read data from the workspace API.
@@ -293,7 +358,7 @@ func WorkspaceData[T any](read func(context.Context, *T, *databricks.WorkspaceCl
 //	         ...
 //	     })
 func WorkspaceDataWithParams[T, P any](read func(context.Context, P, *databricks.WorkspaceClient) (*T, error)) Resource {
-	return genericDatabricksData((*DatabricksClient).WorkspaceClient, func(ctx context.Context, o P, s *T, w *databricks.WorkspaceClient) error {
+	r := genericDatabricksData((*DatabricksClient).WorkspaceClient, func(ctx context.Context, o P, s *T, w *databricks.WorkspaceClient) error {
 		res, err := read(ctx, o, w)
 		if err != nil {
 			return err
@@ -301,6 +366,8 @@ func WorkspaceDataWithParams[T, P any](read func(context.Context, P, *databricks
 		*s = *res
 		return nil
 	}, true, NoCustomize)
+	r.WorkspaceLevel = true
+	return r
 }
 
 // WorkspaceDataWithCustomizeFunc defines a data source that can be used to read data from the workspace API.
@@ -312,9 +379,11 @@ func WorkspaceDataWithParams[T, P any](read func(context.Context, P, *databricks
 func WorkspaceDataWithCustomizeFunc[T any](
 	read func(context.Context, *T, *databricks.WorkspaceClient) error,
 	customizeSchemaFunc func(map[string]*schema.Schema) map[string]*schema.Schema) Resource {
-	return genericDatabricksData((*DatabricksClient).WorkspaceClient, func(ctx context.Context, s struct{}, t *T, wc *databricks.WorkspaceClient) error {
+	r := genericDatabricksData((*DatabricksClient).WorkspaceClient, func(ctx context.Context, s struct{}, t *T, wc *databricks.WorkspaceClient) error {
 		return read(ctx, t, wc)
 	}, false, customizeSchemaFunc)
+	r.WorkspaceLevel = true
+	return r
 }
 
 // AccountData is a generic way to define account data resources in Terraform provider.
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -227,6 +228,52 @@ func TestUpdate(t *testing.T) {
 	assert.Equal(t, "nope", diags[0].Summary)
 }
 
+func TestReadReportsWarnings(t *testing.T) {
+	r := Resource{
+		Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			AddWarning(ctx, "something is off", "details")
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"foo": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}.ToResource()
+	d := r.TestResourceData()
+	diags := r.ReadContext(context.Background(), d, &DatabricksClient{})
+	assert.False(t, diags.HasError())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "something is off", diags[0].Summary)
+	assert.Equal(t, "details", diags[0].Detail)
+}
+
+func TestDeleteReportsWarnings(t *testing.T) {
+	r := Resource{
+		Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return nil
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			AddWarning(ctx, "something is left", "details")
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"foo": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+		},
+	}.ToResource()
+	d := r.TestResourceData()
+	diags := r.DeleteContext(context.Background(), d, &DatabricksClient{})
+	assert.False(t, diags.HasError())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "something is left", diags[0].Summary)
+}
+
 func TestRecoverableFromPanic(t *testing.T) {
 	r := Resource{
 		Update: func(ctx context.Context,
@@ -280,6 +327,22 @@ func TestCustomizeDiffRobustness(t *testing.T) {
 	assert.EqualError(t, err, "cannot customize diff for sample: panic: oops")
 }
 
+func TestCustomizeDiffWithClient(t *testing.T) {
+	client := &DatabricksClient{}
+	r := Resource{
+		CustomizeDiffWithClient: func(ctx context.Context, d *schema.ResourceDiff, c *DatabricksClient) error {
+			assert.Same(t, client, c)
+			return fmt.Errorf("nope")
+		},
+	}.ToResource()
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, ResourceName, "sample")
+
+	err := r.CustomizeDiff(ctx, nil, client)
+	assert.EqualError(t, err, "cannot customize diff for sample: nope")
+}
+
 func TestWorkspacePathPrefixDiffSuppress(t *testing.T) {
 	assert.True(t, WorkspacePathPrefixDiffSuppress("k", "/Workspace/foo/bar", "/Workspace/foo/bar", nil))
 	assert.True(t, WorkspacePathPrefixDiffSuppress("k", "/Workspace/foo/bar", "/foo/bar", nil))
@@ -0,0 +1,371 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/databricks/databricks-sdk-go/logger"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// RetryField is the provider block, that configures how failed requests are retried for each class of errors
+const RetryField = "retry"
+
+// Fields of the blocks for each class of errors in the `retry` provider block
+const (
+	RetryMaxAttemptsField       = "max_attempts"
+	RetryMinBackoffSecondsField = "min_backoff_seconds"
+	RetryMaxBackoffSecondsField = "max_backoff_seconds"
+	RetryJitterField            = "jitter"
+)
+
+// RetryClass is a class of errors, that are retried with the same policy. It's also the name of the nested block
+// in the `retry` provider block.
+type RetryClass string
+
+const (
+	// RetryThrottling is for HTTP 429 Too Many Requests responses
+	RetryThrottling RetryClass = "throttling"
+	// RetryServerError is for HTTP 5xx responses, except 501 Not Implemented and 504 Gateway Timeout. POST and PATCH
+	// requests are resent only on 502 Bad Gateway and 503 Service Unavailable.
+	RetryServerError RetryClass = "server_error"
+	// RetryTimeout is for HTTP 504 Gateway Timeout responses and requests, that timed out because of inactivity
+	RetryTimeout RetryClass = "timeout"
+	// RetryConflict is for concurrent modifications, i.e. of workspace settings with an outdated etag
+	RetryConflict RetryClass = "conflict"
+	// RetryNotFound is for objects, that aren't visible yet right after they were created or changed
+	RetryNotFound RetryClass = "not_found"
+)
+
+// RetryClasses lists all classes of errors in the order of the `retry` provider block
+var RetryClasses = []RetryClass{RetryThrottling, RetryServerError, RetryTimeout, RetryConflict, RetryNotFound}
+
+// RetryPolicy configures how many times and how often a failed request is retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one. Zero means that the request is
+	// retried until the operation times out.
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry, that is doubled for every next retry
+	MinBackoff time.Duration
+	// MaxBackoff limits the wait between retries
+	MaxBackoff time.Duration
+	// Jitter randomizes the wait between half of the backoff and the full backoff, so that concurrent
+	// requests aren't retried at the same time
+	Jitter bool
+}
+
+// defaultRetryPolicies are used for classes of errors, that aren't configured in the `retry` provider block,
+// and for omitted fields of configured classes
+var defaultRetryPolicies = map[RetryClass]RetryPolicy{
+	RetryThrottling:  {MinBackoff: time.Second, MaxBackoff: 10 * time.Second, Jitter: true},
+	RetryServerError: {MaxAttempts: 5, MinBackoff: time.Second, MaxBackoff: 10 * time.Second, Jitter: true},
+	RetryTimeout:     {MinBackoff: time.Second, MaxBackoff: 10 * time.Second, Jitter: true},
+	RetryConflict:    {MaxAttempts: 2, MaxBackoff: 10 * time.Second},
+	RetryNotFound:    {MinBackoff: time.Second, MaxBackoff: 10 * time.Second, Jitter: true},
+}
+
+// DefaultRetryPolicy returns the policy for a class of errors, that isn't configured in the `retry` provider block
+func DefaultRetryPolicy(class RetryClass) RetryPolicy {
+	return defaultRetryPolicies[class]
+}
+
+// NewRetryPolicy validates the configuration of a class of errors in the `retry` provider block. Omitted fields
+// are nil and are taken from the default policy of the class.
+func NewRetryPolicy(class RetryClass, maxAttempts, minBackoffSeconds, maxBackoffSeconds *int64,
+	jitter *bool) (RetryPolicy, error) {
+	policy, ok := defaultRetryPolicies[class]
+	if !ok {
+		return policy, fmt.Errorf("unknown retry class: %s", class)
+	}
+	for field, value := range map[string]*int64{
+		RetryMaxAttemptsField:       maxAttempts,
+		RetryMinBackoffSecondsField: minBackoffSeconds,
+		RetryMaxBackoffSecondsField: maxBackoffSeconds,
+	} {
+		if value != nil && *value < 0 {
+			return policy, fmt.Errorf("%s.%s.%s must not be negative", RetryField, class, field)
+		}
+	}
+	if maxAttempts != nil {
+		policy.MaxAttempts = int(*maxAttempts)
+	}
+	if minBackoffSeconds != nil {
+		policy.MinBackoff = time.Duration(*minBackoffSeconds) * time.Second
+	}
+	if maxBackoffSeconds != nil {
+		policy.MaxBackoff = time.Duration(*maxBackoffSeconds) * time.Second
+		if policy.MaxBackoff < policy.MinBackoff {
+			return policy, fmt.Errorf("%s.%s.%s must not be less than %s", RetryField, class,
+				RetryMaxBackoffSecondsField, RetryMinBackoffSecondsField)
+		}
+	} else if policy.MaxBackoff < policy.MinBackoff {
+		// only the minimum is configured and it's above the default maximum
+		policy.MaxBackoff = policy.MinBackoff
+	}
+	if jitter != nil {
+		policy.Jitter = *jitter
+	}
+	return policy, nil
+}
+
+// CanRetry returns true, if another attempt is allowed after the given number of attempts
+func (p RetryPolicy) CanRetry(attempts int) bool {
+	return p.MaxAttempts == 0 || attempts < p.MaxAttempts
+}
+
+// Backoff returns the wait before the given retry, starting from 1
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	wait := p.MinBackoff
+	for i := 1; i < retry && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if p.Jitter && wait > 0 {
+		wait = wait/2 + rand.N(wait/2+1)
+	}
+	return wait
+}
+
+// RetryPolicies are policies for classes of errors configured in the `retry` provider block
+type RetryPolicies map[RetryClass]RetryPolicy
+
+// SetRetryPolicies sets policies for classes of errors configured in the `retry` provider block
+func (c *DatabricksClient) SetRetryPolicies(policies RetryPolicies) {
+	c.retryPolicies = policies
+}
+
+// RetryPolicy returns the configured or the default policy for the class of errors
+func (c *DatabricksClient) RetryPolicy(class RetryClass) RetryPolicy {
+	if c != nil {
+		if policy, ok := c.retryPolicies[class]; ok {
+			return policy
+		}
+	}
+	return DefaultRetryPolicy(class)
+}
+
+type retryPoliciesKey struct{}
+
+// WithRetryPolicies returns a context, in which RetryWithPolicy uses the given policies
+func WithRetryPolicies(ctx context.Context, policies RetryPolicies) context.Context {
+	if len(policies) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, retryPoliciesKey{}, policies)
+}
+
+// WithDefaultRetryPolicy returns a context, in which RetryWithPolicy uses the given policy for the class of errors,
+// unless the class is configured in the `retry` provider block
+func WithDefaultRetryPolicy(ctx context.Context, class RetryClass, policy RetryPolicy) context.Context {
+	policies, _ := ctx.Value(retryPoliciesKey{}).(RetryPolicies)
+	if _, ok := policies[class]; ok {
+		return ctx
+	}
+	withDefault := RetryPolicies{class: policy}
+	for k, v := range policies {
+		withDefault[k] = v
+	}
+	return context.WithValue(ctx, retryPoliciesKey{}, withDefault)
+}
+
+// RetryPolicyFromContext returns the policy for the class of errors configured for the provider,
+// that executes the current operation, or the default policy of the class
+func RetryPolicyFromContext(ctx context.Context, class RetryClass) RetryPolicy {
+	policies, _ := ctx.Value(retryPoliciesKey{}).(RetryPolicies)
+	if policy, ok := policies[class]; ok {
+		return policy
+	}
+	return DefaultRetryPolicy(class)
+}
+
+// withRetryPolicies makes `retry` provider block available to RetryWithPolicy within CRUD operations
+func (r Resource) withRetryPolicies() Resource {
+	r.Create = withRetryPoliciesInContext(r.Create)
+	r.Read = withRetryPoliciesInContext(r.Read)
+	r.Update = withRetryPoliciesInContext(r.Update)
+	r.Delete = withRetryPoliciesInContext(r.Delete)
+	return r
+}
+
+func withRetryPoliciesInContext(cb func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error) func(
+	ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+	if cb == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		if c != nil {
+			ctx = WithRetryPolicies(ctx, c.retryPolicies)
+		}
+		return cb(ctx, d, c)
+	}
+}
+
+// RetryWithPolicy calls f until it succeeds or returns an error, for which retriable returns false. Retries follow
+// the policy for the class of errors from the provider configuration. When the policy doesn't allow more attempts
+// or the context is done, the last error is returned.
+func RetryWithPolicy[T any](ctx context.Context, class RetryClass, retriable func(error) bool,
+	f func(context.Context) (*T, error)) (*T, error) {
+	policy := RetryPolicyFromContext(ctx, class)
+	for attempt := 1; ; attempt++ {
+		res, err := f(ctx)
+		if err == nil || !retriable(err) || !policy.CanRetry(attempt) {
+			return res, err
+		}
+		wait := policy.Backoff(attempt)
+		logger.Debugf(ctx, "Retrying %s error in %s: %s", class, wait.Round(time.Millisecond), err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, err
+		case <-timer.C:
+		}
+	}
+}
+
+// classifyResponse returns the class of errors for the HTTP response, or an empty string, if it isn't retried
+func classifyResponse(resp *http.Response) RetryClass {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return RetryThrottling
+	case resp.StatusCode == http.StatusGatewayTimeout:
+		return RetryTimeout
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return RetryServerError
+	}
+	return ""
+}
+
+// canResend returns true, if sending the request again can't repeat its side effects: either the method is
+// idempotent, or the response comes from throttling or from the gateway, so the request wasn't processed
+// by the service. Other server errors of POST and PATCH requests, i.e. creation of objects, are returned as is,
+// because the object could be already created.
+func canResend(r *http.Request, resp *http.Response) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// RetryExhaustedError is returned, when a request failed with the same class of errors more times,
+// than the `retry` provider block allows. It intentionally doesn't wrap the API error, so that
+// the Databricks SDK doesn't retry the request once again.
+type RetryExhaustedError struct {
+	Class    RetryClass
+	Attempts int
+	Status   string
+	Message  string
+}
+
+func (e *RetryExhaustedError) Error() string {
+	msg := fmt.Sprintf("%s %s after %d attempts", e.Class, e.Status, e.Attempts)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// retryTransport retries requests to the Databricks REST API, that failed with a class of errors configured
+// in the `retry` provider block. Responses with other classes of errors are returned as is, so that
+// the Databricks SDK retries them as usual.
+type retryTransport struct {
+	transportWrapper
+	policies RetryPolicies
+}
+
+func (t *retryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	// attempts are counted for each class of errors separately
+	attempts := map[RetryClass]int{}
+	for {
+		resp, err := t.inner.RoundTrip(r)
+		if err != nil {
+			return resp, err
+		}
+		class := classifyResponse(resp)
+		policy, ok := t.policies[class]
+		if !ok || !canResend(r, resp) {
+			return resp, nil
+		}
+		attempts[class]++
+		attempt := attempts[class]
+		if !policy.CanRetry(attempt) {
+			return nil, exhausted(resp, class, attempt)
+		}
+		if r.Body != nil && r.Body != http.NoBody {
+			if r.GetBody == nil {
+				// the body can't be sent again, so the Databricks SDK has to retry it
+				return resp, nil
+			}
+			body, err := r.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			r = r.Clone(r.Context())
+			r.Body = body
+		}
+		wait := policy.Backoff(attempt)
+		if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && retryAfter > 0 {
+			wait = max(wait, min(time.Duration(retryAfter)*time.Second, policy.MaxBackoff))
+		}
+		logger.Debugf(r.Context(), "Retrying %s %s after %s %s in %s", r.Method, r.URL.Path,
+			class, resp.Status, wait.Round(time.Millisecond))
+		drain(resp)
+		timer := time.NewTimer(wait)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, r.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func exhausted(resp *http.Response, class RetryClass, attempts int) error {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return &RetryExhaustedError{
+		Class:    class,
+		Attempts: attempts,
+		Status:   resp.Status,
+		Message:  string(bytes.TrimSpace(body)),
+	}
+}
+
+func drain(resp *http.Response) {
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+}
+
+// enableRetryPolicies makes all clients created from the given config retry throttling, server errors and
+// timeouts according to the `retry` provider block. Classes of errors, that aren't configured, are retried
+// by the Databricks SDK.
+func enableRetryPolicies(cfg *config.Config, policies RetryPolicies) {
+	transportPolicies := RetryPolicies{}
+	for _, class := range []RetryClass{RetryThrottling, RetryServerError, RetryTimeout} {
+		if policy, ok := policies[class]; ok {
+			transportPolicies[class] = policy
+		}
+	}
+	if len(transportPolicies) == 0 {
+		return
+	}
+	if t, ok := cfg.HTTPTransport.(*retryTransport); ok {
+		t.policies = transportPolicies
+		return
+	}
+	cfg.HTTPTransport = &retryTransport{transportWrapper: transportWrapper{inner: defaultHTTPTransport(cfg)}, policies: transportPolicies}
+}
@@ -0,0 +1,243 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	jitter := false
+	policy, err := NewRetryPolicy(RetryThrottling, int64Ptr(3), nil, int64Ptr(30), &jitter)
+	require.NoError(t, err)
+	assert.Equal(t, RetryPolicy{MaxAttempts: 3, MinBackoff: time.Second, MaxBackoff: 30 * time.Second}, policy)
+
+	policy, err = NewRetryPolicy(RetryConflict, nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRetryPolicy(RetryConflict), policy)
+
+	// the default maximum is raised to the configured minimum
+	policy, err = NewRetryPolicy(RetryNotFound, nil, int64Ptr(20), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 20*time.Second, policy.MaxBackoff)
+
+	_, err = NewRetryPolicy(RetryTimeout, int64Ptr(-1), nil, nil, nil)
+	assert.EqualError(t, err, "retry.timeout.max_attempts must not be negative")
+
+	_, err = NewRetryPolicy(RetryServerError, nil, int64Ptr(5), int64Ptr(2), nil)
+	assert.EqualError(t, err, "retry.server_error.max_backoff_seconds must not be less than min_backoff_seconds")
+
+	_, err = NewRetryPolicy("other", nil, nil, nil, nil)
+	assert.EqualError(t, err, "unknown retry class: other")
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	backoffs := []time.Duration{}
+	for retry := 1; retry <= 5; retry++ {
+		backoffs = append(backoffs, policy.Backoff(retry))
+	}
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second,
+		5 * time.Second}, backoffs)
+
+	policy.Jitter = true
+	for i := 0; i < 100; i++ {
+		wait := policy.Backoff(2)
+		assert.GreaterOrEqual(t, wait, time.Second)
+		assert.LessOrEqual(t, wait, 2*time.Second)
+	}
+
+	assert.Equal(t, time.Duration(0), RetryPolicy{MaxBackoff: time.Second}.Backoff(3))
+	assert.True(t, RetryPolicy{}.CanRetry(100))
+	assert.False(t, RetryPolicy{MaxAttempts: 2}.CanRetry(2))
+}
+
+func TestRetryWithPolicy(t *testing.T) {
+	ctx := WithRetryPolicies(context.Background(), RetryPolicies{
+		RetryConflict: {MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	})
+	calls := 0
+	_, err := RetryWithPolicy(ctx, RetryConflict, func(err error) bool {
+		return true
+	}, func(ctx context.Context) (*string, error) {
+		calls++
+		return nil, fmt.Errorf("conflict %d", calls)
+	})
+	assert.EqualError(t, err, "conflict 3")
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	_, err = RetryWithPolicy(ctx, RetryConflict, func(err error) bool {
+		return false
+	}, func(ctx context.Context) (*string, error) {
+		calls++
+		return nil, fmt.Errorf("other")
+	})
+	assert.EqualError(t, err, "other")
+	assert.Equal(t, 1, calls)
+}
+
+func TestWithDefaultRetryPolicy(t *testing.T) {
+	fallback := RetryPolicy{MaxAttempts: 5, MinBackoff: time.Second, MaxBackoff: 10 * time.Second, Jitter: true}
+	ctx := WithDefaultRetryPolicy(context.Background(), RetryConflict, fallback)
+	assert.Equal(t, fallback, RetryPolicyFromContext(ctx, RetryConflict))
+	assert.Equal(t, DefaultRetryPolicy(RetryNotFound), RetryPolicyFromContext(ctx, RetryNotFound))
+
+	configured := RetryPolicies{
+		RetryConflict: {MaxAttempts: 3},
+		RetryNotFound: {MaxAttempts: 7},
+	}
+	ctx = WithDefaultRetryPolicy(WithRetryPolicies(context.Background(), configured), RetryConflict, fallback)
+	assert.Equal(t, configured[RetryConflict], RetryPolicyFromContext(ctx, RetryConflict))
+
+	ctx = WithDefaultRetryPolicy(WithRetryPolicies(context.Background(), RetryPolicies{
+		RetryNotFound: {MaxAttempts: 7},
+	}), RetryConflict, fallback)
+	assert.Equal(t, fallback, RetryPolicyFromContext(ctx, RetryConflict))
+	assert.Equal(t, RetryPolicy{MaxAttempts: 7}, RetryPolicyFromContext(ctx, RetryNotFound))
+}
+
+func TestRetryWithPolicyReturnsLastErrorWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ctx = WithRetryPolicies(ctx, RetryPolicies{
+		RetryNotFound: {MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	})
+	_, err := RetryWithPolicy(ctx, RetryNotFound, func(err error) bool {
+		return true
+	}, func(ctx context.Context) (*string, error) {
+		return nil, fmt.Errorf("not yet")
+	})
+	assert.EqualError(t, err, "not yet")
+}
+
+func TestResourceOperationsHaveRetryPolicies(t *testing.T) {
+	policies := RetryPolicies{RetryNotFound: {MaxAttempts: 7}}
+	var policy RetryPolicy
+	r := Resource{
+		Create: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			policy = RetryPolicyFromContext(ctx, RetryNotFound)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return nil
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return nil
+		},
+		Schema: map[string]*schema.Schema{},
+	}.ToResource()
+	client := &DatabricksClient{}
+	client.SetRetryPolicies(policies)
+	r.CreateContext(context.Background(), r.TestResourceData(), client)
+	assert.Equal(t, policies[RetryNotFound], policy)
+	assert.Equal(t, DefaultRetryPolicy(RetryTimeout), client.RetryPolicy(RetryTimeout))
+}
+
+func TestRetryTransport(t *testing.T) {
+	statuses := []int{429, 503, 200}
+	bodies := []string{}
+	transport := &retryTransport{
+		policies: RetryPolicies{
+			RetryThrottling:  {MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+			RetryServerError: {MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		},
+		transportWrapper: transportWrapper{inner: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			bodies = append(bodies, string(body))
+			status := statuses[0]
+			statuses = statuses[1:]
+			return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: http.NoBody, Request: r}, nil
+		})},
+	}
+	r := httptest.NewRequest("POST", "https://x/api/2.0/clusters/create", strings.NewReader(`{"a":1}`))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(`{"a":1}`)), nil
+	}
+	resp, err := transport.RoundTrip(r)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []string{`{"a":1}`, `{"a":1}`, `{"a":1}`}, bodies)
+}
+
+func TestRetryTransportExhausted(t *testing.T) {
+	calls := 0
+	transport := &retryTransport{
+		policies: RetryPolicies{
+			RetryServerError: {MaxAttempts: 2},
+		},
+		transportWrapper: transportWrapper{inner: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{StatusCode: 500, Status: "500 Internal Server Error",
+				Body: io.NopCloser(strings.NewReader(`{"message":"nope"}`)), Request: r}, nil
+		})},
+	}
+	_, err := transport.RoundTrip(httptest.NewRequest("GET", "https://x/api/2.0/clusters/get", nil))
+	assert.EqualError(t, err, `server_error 500 Internal Server Error after 2 attempts: {"message":"nope"}`)
+	var exhausted *RetryExhaustedError
+	assert.True(t, errors.As(err, &exhausted))
+	assert.Equal(t, 2, calls)
+}
+
+func TestRetryTransportDoesNotResendPostOnServerError(t *testing.T) {
+	calls := 0
+	transport := &retryTransport{
+		policies: RetryPolicies{
+			RetryServerError: {MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		},
+		transportWrapper: transportWrapper{inner: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{StatusCode: 500, Status: "500 Internal Server Error", Body: http.NoBody, Request: r}, nil
+		})},
+	}
+	r := httptest.NewRequest("POST", "https://x/api/2.0/clusters/create", strings.NewReader(`{"a":1}`))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(`{"a":1}`)), nil
+	}
+	resp, err := transport.RoundTrip(r)
+	require.NoError(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryTransportSkipsUnconfiguredClasses(t *testing.T) {
+	calls := 0
+	transport := &retryTransport{
+		policies: RetryPolicies{
+			RetryThrottling: {MaxAttempts: 2},
+		},
+		transportWrapper: transportWrapper{inner: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{StatusCode: 504, Status: "504 Gateway Timeout", Body: http.NoBody, Request: r}, nil
+		})},
+	}
+	resp, err := transport.RoundTrip(httptest.NewRequest("GET", "https://x/api/2.0/clusters/get", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 504, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestEnableRetryPolicies(t *testing.T) {
+	cfg := &config.Config{}
+	EnableTransports(cfg, TransportOptions{RetryPolicies: RetryPolicies{RetryConflict: {MaxAttempts: 3}}})
+	assert.Nil(t, cfg.HTTPTransport)
+
+	EnableTransports(cfg, TransportOptions{RetryPolicies: RetryPolicies{RetryTimeout: {MaxAttempts: 3}}})
+	require.IsType(t, &retryTransport{}, cfg.HTTPTransport)
+}
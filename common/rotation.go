@@ -0,0 +1,85 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Rotation is the configuration of `rotation` block of credentials, that expire
+type Rotation struct {
+	// credential is rotated, when it's going to expire within this period
+	RotateBeforeExpiry time.Duration
+	// previous credential stays valid for this period after the rotation
+	Overlap time.Duration
+}
+
+func validateDuration(i any, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid duration: %w", k, err)}
+	}
+	if d < 0 {
+		return nil, []error{fmt.Errorf("%s must not be negative", k)}
+	}
+	return nil, nil
+}
+
+// RotationSchema returns schema of the `rotation` block
+func RotationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"rotate_before_expiry": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateDuration,
+				},
+				"overlap": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "0s",
+					ValidateFunc: validateDuration,
+				},
+			},
+		},
+	}
+}
+
+// GetRotation returns configuration of `rotation` block or nil, if the block isn't configured
+func GetRotation(d attributeGetter) *Rotation {
+	v, ok := d.GetOk("rotation.0.rotate_before_expiry")
+	if !ok {
+		return nil
+	}
+	// values are already validated by the schema
+	rotateBeforeExpiry, _ := time.ParseDuration(v.(string))
+	r := &Rotation{RotateBeforeExpiry: rotateBeforeExpiry}
+	if overlap, ok := d.GetOk("rotation.0.overlap"); ok {
+		r.Overlap, _ = time.ParseDuration(overlap.(string))
+	}
+	return r
+}
+
+// IsDue checks if a credential, that expires at the given time in milliseconds, has to be rotated.
+// Credentials without an expiry time are never rotated.
+func (r Rotation) IsDue(expiryTimeMillis int64, now time.Time) bool {
+	if expiryTimeMillis <= 0 {
+		return false
+	}
+	return !now.Before(time.UnixMilli(expiryTimeMillis).Add(-r.RotateBeforeExpiry))
+}
+
+// OverlapElapsed checks if the previous credential could be revoked, when the current one
+// was created at the given time in milliseconds
+func (r Rotation) OverlapElapsed(rotatedAtMillis int64, now time.Time) bool {
+	return !now.Before(time.UnixMilli(rotatedAtMillis).Add(r.Overlap))
+}
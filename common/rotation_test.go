@@ -0,0 +1,56 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotationIsDue(t *testing.T) {
+	now := time.Now()
+	r := Rotation{RotateBeforeExpiry: 24 * time.Hour}
+	assert.False(t, r.IsDue(0, now))
+	assert.False(t, r.IsDue(-1, now))
+	assert.False(t, r.IsDue(now.Add(48*time.Hour).UnixMilli(), now))
+	assert.True(t, r.IsDue(now.Add(23*time.Hour).UnixMilli(), now))
+	assert.True(t, r.IsDue(now.Add(-time.Hour).UnixMilli(), now))
+}
+
+func TestRotationOverlapElapsed(t *testing.T) {
+	now := time.Now()
+	r := Rotation{Overlap: time.Hour}
+	assert.False(t, r.OverlapElapsed(now.Add(-30*time.Minute).UnixMilli(), now))
+	assert.True(t, r.OverlapElapsed(now.Add(-2*time.Hour).UnixMilli(), now))
+}
+
+func TestGetRotation(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"rotation": RotationSchema(),
+	}
+	d := schema.TestResourceDataRaw(t, s, map[string]any{})
+	assert.Nil(t, GetRotation(d))
+
+	d = schema.TestResourceDataRaw(t, s, map[string]any{
+		"rotation": []any{
+			map[string]any{
+				"rotate_before_expiry": "72h",
+				"overlap":              "30m",
+			},
+		},
+	})
+	assert.Equal(t, &Rotation{
+		RotateBeforeExpiry: 72 * time.Hour,
+		Overlap:            30 * time.Minute,
+	}, GetRotation(d))
+}
+
+func TestRotationSchemaValidatesDuration(t *testing.T) {
+	_, errs := validateDuration("1d", "rotate_before_expiry")
+	assert.Len(t, errs, 1)
+	_, errs = validateDuration("-1h", "overlap")
+	assert.EqualError(t, errs[0], "overlap must not be negative")
+	_, errs = validateDuration("36h", "overlap")
+	assert.Len(t, errs, 0)
+}
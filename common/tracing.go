@@ -0,0 +1,189 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/databricks/databricks-sdk-go/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/databricks/terraform-provider-databricks"
+
+var tracingEnabled atomic.Bool
+
+// InitTracing configures export of traces to an OpenTelemetry collector over OTLP/HTTP. Tracing is enabled, when
+// either `OTEL_EXPORTER_OTLP_ENDPOINT`, `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` or `OTEL_TRACES_EXPORTER=otlp`
+// environment variable is set. All other standard `OTEL_*` environment variables, like `OTEL_SERVICE_NAME`,
+// `OTEL_RESOURCE_ATTRIBUTES` or `OTEL_EXPORTER_OTLP_HEADERS`, are respected as well. The returned function
+// flushes remaining spans and has to be called before the provider exits.
+func InitTracing(ctx context.Context) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if !tracingRequested() {
+		return noop, nil
+	}
+	for _, env := range []string{"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL"} {
+		protocol := os.Getenv(env)
+		if protocol == "" {
+			continue
+		}
+		if protocol != "http/protobuf" {
+			return noop, fmt.Errorf("%s=%s is not supported, only http/protobuf is", env, protocol)
+		}
+		break
+	}
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, fmt.Errorf("cannot create OTLP trace exporter: %w", err)
+	}
+	// resource attributes from the environment take precedence over the defaults
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", "terraform-provider-databricks"),
+			attribute.String("service.version", Version())),
+		resource.WithFromEnv())
+	if err != nil {
+		return noop, fmt.Errorf("cannot detect tracing resource: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res))
+	otel.SetTracerProvider(tp)
+	tracingEnabled.Store(true)
+	return tp.Shutdown, nil
+}
+
+// tracingRequested checks if export of traces is configured with environment variables
+func tracingRequested() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+	switch os.Getenv("OTEL_TRACES_EXPORTER") {
+	case "otlp":
+		return true
+	case "":
+		return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+	default:
+		// other exporters aren't supported
+		return false
+	}
+}
+
+// IsTracingEnabled returns true, if InitTracing has configured export of traces
+func IsTracingEnabled() bool {
+	return tracingEnabled.Load()
+}
+
+// StartSpan starts a span, i.e. for waiting until an object reaches the desired state.
+// The span has to be ended with EndSpan.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName, trace.WithInstrumentationVersion(Version())).Start(ctx, name,
+		trace.WithAttributes(attrs...))
+}
+
+// EndSpan records the error, if any, and ends the span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartResourceSpan starts a span for create, read, update or delete of a resource or data source.
+// HTTP requests made within the span are recorded as its children, including retries of the same request.
+func StartResourceSpan(ctx context.Context, resourceType, operation string) (context.Context, trace.Span) {
+	ctx = context.WithValue(ctx, requestAttemptsKey{}, &requestAttempts{})
+	return StartSpan(ctx, operation+" "+resourceType,
+		attribute.String("terraform.resource.type", resourceType),
+		attribute.String("terraform.operation", operation))
+}
+
+type requestAttemptsKey struct{}
+
+// requestAttempts tracks consecutive attempts of the same request within a resource operation, so that retries
+// made by the Databricks SDK could be told apart from new requests, i.e. polling for the state of an object.
+type requestAttempts struct {
+	mu     sync.Mutex
+	last   string
+	failed bool
+	count  int
+}
+
+// next returns the number of times the request was already sent
+func (a *requestAttempts) next(request string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.failed && a.last == request {
+		a.count++
+	} else {
+		a.count = 0
+	}
+	a.last = request
+	return a.count
+}
+
+func (a *requestAttempts) done(failed bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.failed = failed
+}
+
+// tracingTransport records a span for every HTTP request sent to Databricks REST API and propagates
+// the trace context in the `traceparent` header.
+type tracingTransport struct {
+	transportWrapper
+}
+
+func (t *tracingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, span := StartSpan(r.Context(), r.Method+" "+r.URL.Path,
+		attribute.String("http.request.method", r.Method),
+		attribute.String("url.path", r.URL.Path),
+		attribute.String("server.address", r.URL.Hostname()))
+	attempts, _ := ctx.Value(requestAttemptsKey{}).(*requestAttempts)
+	if attempts != nil {
+		if resendCount := attempts.next(r.Method + " " + r.URL.String()); resendCount > 0 {
+			span.SetAttributes(attribute.Int("http.request.resend_count", resendCount))
+		}
+	}
+	r = r.Clone(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(r.Header))
+	resp, err := t.inner.RoundTrip(r)
+	failed := err != nil
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			failed = true
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	if attempts != nil {
+		attempts.done(failed)
+	}
+	EndSpan(span, err)
+	return resp, err
+}
+
+// enableTracing makes all clients created from the given config record spans for HTTP requests,
+// if InitTracing has configured export of traces
+func enableTracing(cfg *config.Config) {
+	if !IsTracingEnabled() {
+		return
+	}
+	if _, ok := cfg.HTTPTransport.(*tracingTransport); ok {
+		return
+	}
+	cfg.HTTPTransport = &tracingTransport{transportWrapper{inner: defaultHTTPTransport(cfg)}}
+}
@@ -0,0 +1,188 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a tracer provider, that keeps all ended spans in memory
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	previous := otel.GetTracerProvider()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+	return recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestInitTracingIsDisabledByDefault(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	shutdown, err := InitTracing(context.Background())
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	assert.False(t, IsTracingEnabled())
+
+	cfg := &config.Config{}
+	EnableTransports(cfg, TransportOptions{})
+	assert.Nil(t, cfg.HTTPTransport)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+	t.Setenv("OTEL_SDK_DISABLED", "true")
+	assert.False(t, tracingRequested())
+}
+
+func TestInitTracingUnsupportedProtocol(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4317")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+	_, err := InitTracing(context.Background())
+	assert.EqualError(t, err, "OTEL_EXPORTER_OTLP_PROTOCOL=grpc is not supported, only http/protobuf is")
+}
+
+func TestInitTracingExportsToCollector(t *testing.T) {
+	var mu sync.Mutex
+	requests := []string{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, r.Header.Get("Content-Type")))
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer collector.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", collector.URL+"/v1/traces")
+	previous := otel.GetTracerProvider()
+	defer func() {
+		otel.SetTracerProvider(previous)
+		tracingEnabled.Store(false)
+	}()
+
+	ctx := context.Background()
+	shutdown, err := InitTracing(ctx)
+	require.NoError(t, err)
+	assert.True(t, IsTracingEnabled())
+	_, span := StartResourceSpan(ctx, "databricks_cluster", "create")
+	EndSpan(span, nil)
+	require.NoError(t, shutdown(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"POST /v1/traces application/x-protobuf"}, requests)
+}
+
+func TestTracingTransport(t *testing.T) {
+	recorder := recordSpans(t)
+	statuses := []int{503, 429, 200, 200}
+	traceparents := []string{}
+	transport := &tracingTransport{transportWrapper{inner: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		status := statuses[0]
+		statuses = statuses[1:]
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: http.NoBody, Request: r}, nil
+	})}}
+	ctx, resourceSpan := StartResourceSpan(context.Background(), "databricks_cluster", "read")
+	for i := 0; i < 4; i++ {
+		r := httptest.NewRequest("GET", "https://abc.cloud.databricks.com/api/2.1/clusters/get?cluster_id=x", nil)
+		_, err := transport.RoundTrip(r.WithContext(ctx))
+		require.NoError(t, err)
+	}
+	EndSpan(resourceSpan, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 5)
+	assert.Equal(t, "read databricks_cluster", spans[4].Name())
+	resendCounts := []int64{}
+	for i, span := range spans[:4] {
+		assert.Equal(t, "GET /api/2.1/clusters/get", span.Name())
+		assert.Equal(t, resourceSpan.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Contains(t, traceparents[i], span.SpanContext().SpanID().String())
+		attrs := spanAttributes(span)
+		assert.Equal(t, "abc.cloud.databricks.com", attrs["server.address"].AsString())
+		resendCounts = append(resendCounts, attrs["http.request.resend_count"].AsInt64())
+	}
+	// polling after a successful response isn't a retry
+	assert.Equal(t, []int64{0, 1, 2, 0}, resendCounts)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, int64(503), spanAttributes(spans[0])["http.response.status_code"].AsInt64())
+	assert.Equal(t, codes.Unset, spans[2].Status().Code)
+}
+
+func TestTracingTransportError(t *testing.T) {
+	recorder := recordSpans(t)
+	transport := &tracingTransport{transportWrapper{inner: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("connection refused")
+	})}}
+	_, err := transport.RoundTrip(httptest.NewRequest("POST", "https://x/api/2.1/clusters/create", nil))
+	assert.EqualError(t, err, "connection refused")
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "connection refused", spans[0].Status().Description)
+}
+
+func TestResourceSpans(t *testing.T) {
+	recorder := recordSpans(t)
+	p := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"databricks_foo": Resource{
+				Create: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+					return fmt.Errorf("nope")
+				},
+				Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+					return nil
+				},
+				Delete: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+					return nil
+				},
+				Schema: map[string]*schema.Schema{},
+			}.ToResource(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"databricks_bar": {
+				ReadContext: func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+					d.SetId("bar")
+					return nil
+				},
+			},
+		},
+	}
+	AddContextToAllResources(p, "databricks")
+	r := p.ResourcesMap["databricks_foo"]
+	r.CreateContext(context.Background(), r.TestResourceData(), &DatabricksClient{})
+	ds := p.DataSourcesMap["databricks_bar"]
+	ds.ReadContext(context.Background(), ds.TestResourceData(), &DatabricksClient{})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "create databricks_foo", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "cannot create foo: nope", spans[0].Status().Description)
+	assert.Equal(t, "databricks_foo", spanAttributes(spans[0])["terraform.resource.type"].AsString())
+	assert.Equal(t, "read data.databricks_bar", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, "bar", spanAttributes(spans[1])["terraform.resource.id"].AsString())
+}
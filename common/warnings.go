@@ -0,0 +1,45 @@
+package common
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+type warningsKey struct{}
+
+// warnings collects non-fatal diagnostics of a single CRUD operation
+type warnings struct {
+	mu    sync.Mutex
+	diags diag.Diagnostics
+}
+
+func withWarnings(ctx context.Context) (context.Context, *warnings) {
+	w := &warnings{}
+	return context.WithValue(ctx, warningsKey{}, w), w
+}
+
+// AddWarning reports a warning to the user. Warnings are shown for CRUD operations of resources,
+// otherwise they are just logged.
+func AddWarning(ctx context.Context, summary, detail string) {
+	w, ok := ctx.Value(warningsKey{}).(*warnings)
+	if !ok {
+		log.Printf("[WARN] %s: %s", summary, detail)
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.diags = append(w.diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  summary,
+		Detail:   detail,
+	})
+}
+
+func (w *warnings) diagnostics() diag.Diagnostics {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.diags
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// WorkspaceIdField is the name of the attribute, that is added to workspace-level resources and data sources,
+// so that they could be managed from an account-level provider.
+const WorkspaceIdField = "workspace_id"
+
+// workspaceIdInResourceId matches resource IDs in the form of `<workspace_id>:<id>`
+var workspaceIdInResourceId = regexp.MustCompile(`^(\d+):(.+)$`)
+
+// WorkspaceIdSchema returns schema of the `workspace_id` attribute
+func WorkspaceIdSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		ForceNew: true,
+		Description: "ID of the workspace to manage this object in. " +
+			"Can only be used with an account-level provider.",
+	}
+}
+
+// ResourceIdWithWorkspaceId returns resource ID in the form of `<workspace_id>:<id>`
+func ResourceIdWithWorkspaceId(workspaceId int64, id string) string {
+	return fmt.Sprintf("%d:%s", workspaceId, id)
+}
+
+// ParseResourceIdWithWorkspaceId splits resource ID in the form of `<workspace_id>:<id>`.
+// The last return value is false, if the ID doesn't contain a workspace ID.
+func ParseResourceIdWithWorkspaceId(id string) (int64, string, bool) {
+	match := workspaceIdInResourceId.FindStringSubmatch(id)
+	if match == nil {
+		return 0, id, false
+	}
+	return MustInt64(match[1]), match[2], true
+}
+
+// supportsWorkspaceId checks if the `workspace_id` attribute has to be added to the resource
+func (r Resource) supportsWorkspaceId() bool {
+	if !r.WorkspaceLevel {
+		return false
+	}
+	// resources like databricks_metastore_assignment already have `workspace_id` with a different meaning
+	_, ok := r.Schema[WorkspaceIdField]
+	return !ok
+}
+
+// withWorkspaceId adds the `workspace_id` attribute to the resource schema and routes all operations
+// through the client of that workspace, when it's set. IDs of resources with `workspace_id` are prefixed
+// with the workspace ID, so that they could be imported.
+func (r Resource) withWorkspaceId(isResource bool) Resource {
+	s := make(map[string]*schema.Schema, len(r.Schema)+1)
+	for k, v := range r.Schema {
+		s[k] = v
+	}
+	s[WorkspaceIdField] = WorkspaceIdSchema()
+	r.Schema = s
+	r.Create = inWorkspace(r.Create, isResource)
+	r.Read = inWorkspace(r.Read, isResource)
+	r.Update = inWorkspace(r.Update, isResource)
+	r.Delete = inWorkspace(r.Delete, isResource)
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importer := r.Importer.StateContext
+		r.Importer = &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+				workspaceId, id, ok := ParseResourceIdWithWorkspaceId(d.Id())
+				if !ok {
+					return importer(ctx, d, m)
+				}
+				c, err := m.(*DatabricksClient).InWorkspace(ctx, workspaceId)
+				if err != nil {
+					return nil, err
+				}
+				d.SetId(id)
+				data, err := importer(ctx, d, c)
+				for _, rd := range data {
+					rd.Set(WorkspaceIdField, workspaceId)
+					if rd.Id() != "" {
+						rd.SetId(ResourceIdWithWorkspaceId(workspaceId, rd.Id()))
+					}
+				}
+				return data, err
+			},
+		}
+	}
+	return r
+}
+
+// setWorkspaceIdFromResourceId sets the `workspace_id` attribute during the import with the default importer
+func setWorkspaceIdFromResourceId(d *schema.ResourceData) error {
+	workspaceId, _, ok := ParseResourceIdWithWorkspaceId(d.Id())
+	if !ok {
+		return nil
+	}
+	return d.Set(WorkspaceIdField, workspaceId)
+}
+
+func inWorkspace(cb func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error,
+	isResource bool) func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+	if cb == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		workspaceId := int64(d.Get(WorkspaceIdField).(int))
+		if workspaceId == 0 {
+			return cb(ctx, d, c)
+		}
+		wc, err := c.InWorkspace(ctx, workspaceId)
+		if err != nil {
+			return err
+		}
+		if !isResource {
+			return cb(ctx, d, wc)
+		}
+		// the resource implementation works with the ID without the workspace ID
+		prefix := ResourceIdWithWorkspaceId(workspaceId, "")
+		d.SetId(strings.TrimPrefix(d.Id(), prefix))
+		err = cb(ctx, d, wc)
+		if d.Id() != "" {
+			d.SetId(prefix + d.Id())
+		}
+		return err
+	}
+}
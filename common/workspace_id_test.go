@@ -0,0 +1,162 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResourceIdWithWorkspaceId(t *testing.T) {
+	workspaceId, id, ok := ParseResourceIdWithWorkspaceId("123:abc/def")
+	assert.True(t, ok)
+	assert.Equal(t, int64(123), workspaceId)
+	assert.Equal(t, "abc/def", id)
+
+	_, id, ok = ParseResourceIdWithWorkspaceId("/jobs/123")
+	assert.False(t, ok)
+	assert.Equal(t, "/jobs/123", id)
+
+	assert.Equal(t, "123:abc", ResourceIdWithWorkspaceId(123, "abc"))
+}
+
+func workspaceIdTestResource(read func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error) Resource {
+	return Resource{
+		WorkspaceLevel: true,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			d.SetId("abc")
+			return nil
+		},
+		Read:   read,
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error { return nil },
+		Schema: map[string]*schema.Schema{
+			"foo": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func accountClientWithWorkspace(workspaceId int64, host string) *DatabricksClient {
+	c := &DatabricksClient{
+		DatabricksClient: &client.DatabricksClient{
+			Config: &config.Config{
+				Host:      "https://accounts.cloud.databricks.com",
+				AccountID: "abc",
+				Token:     "dapi123",
+			},
+		},
+	}
+	c.SetWorkspaceClientForWorkspace(workspaceId, &databricks.WorkspaceClient{
+		Config: &config.Config{
+			Host:  host,
+			Token: "dapi456",
+		},
+	})
+	return c
+}
+
+func TestWorkspaceIdIsAddedToSchema(t *testing.T) {
+	r := workspaceIdTestResource(nil)
+	assert.Contains(t, r.ToResource().Schema, WorkspaceIdField)
+	assert.NotContains(t, r.Schema, WorkspaceIdField)
+
+	r.WorkspaceLevel = false
+	assert.NotContains(t, r.ToResource().Schema, WorkspaceIdField)
+
+	r = workspaceIdTestResource(nil)
+	r.Schema[WorkspaceIdField] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	assert.Equal(t, schema.TypeString, r.ToResource().Schema[WorkspaceIdField].Type)
+}
+
+func TestWorkspaceIdRoutesToWorkspace(t *testing.T) {
+	r := workspaceIdTestResource(func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		assert.Equal(t, "https://ws.cloud.databricks.com", c.Config.Host)
+		assert.Equal(t, "abc", d.Id())
+		return d.Set("foo", "bar")
+	}).ToResource()
+	d := r.TestResourceData()
+	d.Set(WorkspaceIdField, 123)
+	diags := r.CreateContext(context.Background(), d, accountClientWithWorkspace(123, "https://ws.cloud.databricks.com"))
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "123:abc", d.Id())
+	assert.Equal(t, "bar", d.Get("foo"))
+}
+
+func TestWorkspaceIdIsParsedOnImport(t *testing.T) {
+	r := workspaceIdTestResource(func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		assert.Equal(t, "https://ws.cloud.databricks.com", c.Config.Host)
+		assert.Equal(t, "abc", d.Id())
+		return nil
+	}).ToResource()
+	d := r.TestResourceData()
+	d.SetId("123:abc")
+	datas, err := r.Importer.StateContext(context.Background(), d,
+		accountClientWithWorkspace(123, "https://ws.cloud.databricks.com"))
+	require.NoError(t, err)
+	assert.Len(t, datas, 1)
+	assert.Equal(t, "123:abc", d.Id())
+	assert.Equal(t, 123, d.Get(WorkspaceIdField))
+}
+
+func TestWorkspaceIdIsNotAddedToIdOfDataSources(t *testing.T) {
+	r := Resource{
+		WorkspaceLevel: true,
+		Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			assert.Equal(t, "https://ws.cloud.databricks.com", c.Config.Host)
+			d.SetId("abc")
+			return nil
+		},
+		Schema: map[string]*schema.Schema{},
+	}.ToResource()
+	d := r.TestResourceData()
+	d.Set(WorkspaceIdField, 123)
+	diags := r.ReadContext(context.Background(), d, accountClientWithWorkspace(123, "https://ws.cloud.databricks.com"))
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "abc", d.Id())
+}
+
+func TestWorkspaceIdKeepsSchemaVersion(t *testing.T) {
+	base := workspaceIdTestResource(nil)
+	base.SchemaVersion = 1
+	base.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    (&schema.Resource{Schema: base.Schema}).CoreConfigSchema().ImpliedType(),
+			Upgrade: func(ctx context.Context, rawState map[string]any, meta any) (map[string]any, error) {
+				return rawState, nil
+			},
+		},
+	}
+	r := base.ToResource()
+	require.NoError(t, r.InternalValidate(nil, true))
+	// states with `workspace_id` are never written by older versions, so they don't need an upgrade
+	assert.Equal(t, 1, r.SchemaVersion)
+	assert.Len(t, r.StateUpgraders, 1)
+}
+
+func TestWorkspaceIdRequiresAccountProvider(t *testing.T) {
+	r := workspaceIdTestResource(func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+		return nil
+	}).ToResource()
+	d := r.TestResourceData()
+	d.Set(WorkspaceIdField, 123)
+	diags := r.CreateContext(context.Background(), d, &DatabricksClient{
+		DatabricksClient: &client.DatabricksClient{
+			Config: &config.Config{
+				Host: "https://ws.cloud.databricks.com",
+			},
+		},
+	})
+	require.True(t, diags.HasError())
+	assert.Equal(t, "workspace_id can only be used with an account-level provider", diags[0].Summary)
+}
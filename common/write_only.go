@@ -0,0 +1,87 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// WriteOnlySuffix is appended to the name of an attribute to get the name of its write-only variant.
+// The write-only variant is paired with the `<name>_wo_version` attribute, that triggers an update
+// when it's changed, because Terraform doesn't store write-only values to detect their changes.
+const WriteOnlySuffix = "_wo"
+
+// WriteOnlyVersionSuffix is appended to the name of an attribute to get the name of its version attribute.
+const WriteOnlyVersionSuffix = "_wo_version"
+
+// AddWriteOnlyVariant adds `<name>_wo` and `<name>_wo_version` attributes for a string attribute, that holds
+// secret material. It works only for top-level attributes. If the original attribute is required, it becomes
+// optional, and exactly one of both has to be configured.
+// Write-only attributes require Terraform 1.11 or newer and are never persisted in the plan or state.
+func AddWriteOnlyVariant(s map[string]*schema.Schema, name string) {
+	original := MustSchemaPath(s, name)
+	writeOnly := name + WriteOnlySuffix
+	version := name + WriteOnlyVersionSuffix
+	if original.Required {
+		original.Required = false
+		original.Optional = true
+		original.ExactlyOneOf = []string{name, writeOnly}
+	} else {
+		original.ConflictsWith = append(original.ConflictsWith, writeOnly)
+	}
+	s[writeOnly] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		WriteOnly:     true,
+		Sensitive:     true,
+		ValidateFunc:  original.ValidateFunc,
+		ConflictsWith: []string{name},
+		Description: "Write-only variant of `" + name + "`, that is never persisted in the state. " +
+			"Requires Terraform 1.11 or newer.",
+	}
+	s[version] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: []string{writeOnly},
+		Description:  "Version of `" + writeOnly + "`. Change it to send the new value of `" + writeOnly + "`.",
+	}
+}
+
+// GetWriteOnlyString returns the value of a write-only string attribute from the configuration, as write-only
+// values are never available in the plan or state. The key has the same format as for `d.Get()`,
+// i.e. `block.0.attribute`.
+func GetWriteOnlyString(d *schema.ResourceData, key string) string {
+	if d.GetRawConfig().IsNull() {
+		// the configuration isn't available outside of Terraform operations, e.g. in unit tests
+		v, _ := d.Get(key).(string)
+		return v
+	}
+	v, diags := d.GetRawConfigAt(writeOnlyPath(key))
+	if diags.HasError() || !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+		return ""
+	}
+	return v.AsString()
+}
+
+// GetWriteOnlyStringOrValue returns the value of the attribute, or of its write-only variant, if the attribute
+// isn't set. See AddWriteOnlyVariant.
+func GetWriteOnlyStringOrValue(d *schema.ResourceData, name string) string {
+	if v, ok := d.GetOk(name); ok {
+		return v.(string)
+	}
+	return GetWriteOnlyString(d, name+WriteOnlySuffix)
+}
+
+func writeOnlyPath(key string) cty.Path {
+	var path cty.Path
+	for _, part := range strings.Split(key, ".") {
+		if idx, err := strconv.ParseInt(part, 10, 64); err == nil {
+			path = path.IndexInt(int(idx))
+		} else {
+			path = path.GetAttr(part)
+		}
+	}
+	return path
+}
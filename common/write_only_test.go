@@ -0,0 +1,89 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type writeOnlyStruct struct {
+	Name     string `json:"name"`
+	Password string `json:"password,omitempty" tf:"write_only,sensitive"`
+}
+
+func TestStructToSchema_write_only(t *testing.T) {
+	s := StructToSchema(writeOnlyStruct{}, nil)
+	assert.True(t, s["password"].WriteOnly)
+	assert.True(t, s["password"].Optional)
+	assert.True(t, s["password"].Sensitive)
+	assert.False(t, s["name"].WriteOnly)
+}
+
+func TestAddWriteOnlyVariant(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"token": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		"password": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+	AddWriteOnlyVariant(s, "token")
+	AddWriteOnlyVariant(s, "password")
+
+	assert.True(t, s["token"].Optional)
+	assert.Equal(t, []string{"token", "token_wo"}, s["token"].ExactlyOneOf)
+	assert.True(t, s["token_wo"].WriteOnly)
+	assert.Equal(t, schema.TypeInt, s["token_wo_version"].Type)
+	assert.Equal(t, []string{"password_wo"}, s["password"].ConflictsWith)
+}
+
+func TestWriteOnlyPath(t *testing.T) {
+	assert.Equal(t, cty.GetAttrPath("a").IndexInt(0).GetAttr("b"), writeOnlyPath("a.0.b"))
+}
+
+func TestGetWriteOnlyStringOrValue(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"token": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+	AddWriteOnlyVariant(s, "token")
+	d := schema.TestResourceDataRaw(t, s, map[string]any{
+		"token_wo": "abc",
+	})
+	assert.Equal(t, "abc", GetWriteOnlyStringOrValue(d, "token"))
+
+	d = schema.TestResourceDataRaw(t, s, map[string]any{
+		"token": "def",
+	})
+	assert.Equal(t, "def", GetWriteOnlyStringOrValue(d, "token"))
+}
+
+func TestWriteOnlyIsNotForceNew(t *testing.T) {
+	noop := func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error { return nil }
+	r := Resource{
+		Create: noop,
+		Read:   noop,
+		Delete: noop,
+		Schema: map[string]*schema.Schema{
+			"token": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+	AddWriteOnlyVariant(r.Schema, "token")
+	tr := r.ToResource()
+	assert.False(t, tr.Schema["token_wo"].ForceNew)
+	assert.True(t, tr.Schema["token_wo_version"].ForceNew)
+	require.NoError(t, tr.InternalValidate(nil, true))
+}
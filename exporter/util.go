@@ -32,16 +32,11 @@ func (ic *importContext) isServiceInListing(service string) bool {
 }
 
 func (ic *importContext) MatchesName(n string) bool {
-	if ic.match == "" && ic.matchRegex == nil && ic.excludeRegex == nil {
-		return true
-	}
-	if ic.excludeRegex != nil && ic.excludeRegex.MatchString(n) {
-		return false
-	}
-	if ic.matchRegex != nil {
-		return ic.matchRegex.MatchString(n)
-	}
-	return strings.Contains(strings.ToLower(n), strings.ToLower(ic.match))
+	return common.NameFilter{
+		Match:        ic.match,
+		MatchRegex:   ic.matchRegex,
+		ExcludeRegex: ic.excludeRegex,
+	}.Matches(n)
 }
 
 func (ic *importContext) emitFilesFromSlice(slice []string) {
@@ -3,6 +3,8 @@ package client
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/databricks/databricks-sdk-go/client"
 	"github.com/databricks/databricks-sdk-go/config"
@@ -38,7 +40,9 @@ func PrepareDatabricksClient(ctx context.Context, cfg *config.Config, configCust
 	}
 	cfg.EnsureResolved()
 	// Unless set explicitly, the provider will retry indefinitely until context is cancelled
-	// by either a timeout or interrupt.
+	// by either a timeout or interrupt. Classes of errors configured in the `retry` block
+	// are retried by the transport from common.EnableTransports instead and never reach
+	// the retries of the Databricks SDK.
 	if cfg.RetryTimeoutSeconds == 0 {
 		cfg.RetryTimeoutSeconds = -1
 	}
@@ -65,3 +69,21 @@ func PrepareDatabricksClient(ctx context.Context, cfg *config.Config, configCust
 	})
 	return pc, nil
 }
+
+// ResolveReadOnly returns true, if either the `read_only` provider argument or the DATABRICKS_READ_ONLY
+// environment variable is true. The environment variable can't be overridden from the configuration,
+// so that plan-only pipelines could rely on it.
+func ResolveReadOnly(value bool) (bool, error) {
+	if value {
+		return true, nil
+	}
+	env := os.Getenv(common.ReadOnlyEnv)
+	if env == "" {
+		return false, nil
+	}
+	readOnly, err := strconv.ParseBool(env)
+	if err != nil {
+		return false, fmt.Errorf("invalid value of %s environment variable: %w", common.ReadOnlyEnv, err)
+	}
+	return readOnly, nil
+}
@@ -0,0 +1,109 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nullObject returns the value of the object type with all attributes null, except the given ones
+func nullObject(t tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	attributes := map[string]tftypes.Value{}
+	for k, at := range t.AttributeTypes {
+		attributes[k] = tftypes.NewValue(at, nil)
+	}
+	for k, v := range values {
+		attributes[k] = v
+	}
+	return tftypes.NewValue(t, attributes)
+}
+
+func TestListResource_SqlEndpoint(t *testing.T) {
+	ctx := context.Background()
+	_, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/sql/warehouses?",
+			Response: sql.ListWarehousesResponse{
+				Warehouses: []sql.EndpointInfo{
+					{Id: "abc", Name: "Shared"},
+					{Id: "def", Name: "Other"},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/sql/warehouses/abc?",
+			Response: sql.GetWarehouseResponse{
+				Id:          "abc",
+				Name:        "Shared",
+				ClusterSize: "Small",
+				State:       "RUNNING",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/sql/data_sources",
+			Response: []sql.DataSource{{Id: "d", WarehouseId: "abc"}},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	s, err := GetProviderServer(ctx)
+	require.NoError(t, err)
+	schemas, err := s.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	providerConfig, err := tfprotov6.NewDynamicValue(schemas.Provider.ValueType(),
+		nullObject(schemas.Provider.ValueType().(tftypes.Object), map[string]tftypes.Value{
+			"host":  tftypes.NewValue(tftypes.String, server.URL),
+			"token": tftypes.NewValue(tftypes.String, "x"),
+		}))
+	require.NoError(t, err)
+	configured, err := s.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &providerConfig})
+	require.NoError(t, err)
+	require.Empty(t, configured.Diagnostics)
+
+	listSchema := schemas.ListResourceSchemas["databricks_sql_endpoint"]
+	require.NotNil(t, listSchema)
+	listConfig, err := tfprotov6.NewDynamicValue(listSchema.ValueType(),
+		nullObject(listSchema.ValueType().(tftypes.Object), map[string]tftypes.Value{
+			"match": tftypes.NewValue(tftypes.String, "shar"),
+		}))
+	require.NoError(t, err)
+	listed, err := s.(tfprotov6.ProviderServerWithListResource).ListResource(ctx, &tfprotov6.ListResourceRequest{
+		TypeName:        "databricks_sql_endpoint",
+		Config:          &listConfig,
+		IncludeResource: true,
+		Limit:           10,
+	})
+	require.NoError(t, err)
+
+	var results []tfprotov6.ListResourceResult
+	for result := range listed.Results {
+		results = append(results, result)
+	}
+	require.Len(t, results, 1)
+	assert.Empty(t, results[0].Diagnostics)
+	assert.Equal(t, "Shared", results[0].DisplayName)
+
+	identityType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String}}
+	identity, err := results[0].Identity.IdentityData.Unmarshal(identityType)
+	require.NoError(t, err)
+	assert.Equal(t, tftypes.NewValue(identityType, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "abc"),
+	}), identity)
+
+	resource, err := results[0].Resource.Unmarshal(schemas.ResourceSchemas["databricks_sql_endpoint"].ValueType())
+	require.NoError(t, err)
+	var attributes map[string]tftypes.Value
+	require.NoError(t, resource.As(&attributes))
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "Shared"), attributes["name"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "Small"), attributes["cluster_size"])
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
@@ -47,6 +48,25 @@ func ConfigureResource(req resource.ConfigureRequest, resp *resource.ConfigureRe
 	return client
 }
 
+// ConfigureEphemeralResource is a helper function for configuring a general ephemeral resource.
+// It returns the DatabricksClient if it can be successfully fetched from the ProviderData in the request;
+// otherwise, the error is appended to the diagnostics of the response.
+func ConfigureEphemeralResource(req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) *common.DatabricksClient {
+	// Nil case for acceptance tests.
+	if req.ProviderData == nil {
+		return nil
+	}
+	client, ok := req.ProviderData.(*common.DatabricksClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *common.DatabricksClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return nil
+	}
+	return client
+}
+
 // GetDatabricksStagingName returns the resource name for a given resource with _pluginframework suffix.
 // Once a migrated resource is ready to be used as default, the Metadata method for that resource should be updated to use GetDatabricksProductionName.
 func GetDatabricksStagingName(name string) string {
@@ -19,3 +19,13 @@ func SetUserAgentInDataSourceContext(ctx context.Context, dataSourceName string)
 	ctx = common.SetSDKInContext(ctx, sdkName)
 	return useragent.InContext(ctx, "data", dataSourceName)
 }
+
+func SetUserAgentInEphemeralResourceContext(ctx context.Context, ephemeralResourceName string) context.Context {
+	ctx = common.SetSDKInContext(ctx, sdkName)
+	return useragent.InContext(ctx, "ephemeral", ephemeralResourceName)
+}
+
+func SetUserAgentInListResourceContext(ctx context.Context, listResourceName string) context.Context {
+	ctx = common.SetSDKInContext(ctx, sdkName)
+	return useragent.InContext(ctx, "list", listResourceName)
+}
@@ -27,3 +27,13 @@ func TestSetUserAgentInDataSourceContext(t *testing.T) {
 	expectedContext = useragent.InContext(expectedContext, dataSourceKey, dataSourceName)
 	assert.Equal(t, expectedContext, actualContext)
 }
+
+func TestSetUserAgentInEphemeralResourceContext(t *testing.T) {
+	ctx := context.Background()
+	ephemeralResourceKey := "ephemeral"
+	ephemeralResourceName := "test-ephemeral-resource"
+	actualContext := SetUserAgentInEphemeralResourceContext(ctx, ephemeralResourceName)
+	expectedContext := useragent.InContext(ctx, "sdk", "pluginframework")
+	expectedContext = useragent.InContext(expectedContext, ephemeralResourceKey, ephemeralResourceName)
+	assert.Equal(t, expectedContext, actualContext)
+}
@@ -0,0 +1,26 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/stretchr/testify/require"
+)
+
+// runFunction runs the function with the given arguments and returns its result
+func runFunction(t *testing.T, f function.Function, result attr.Value, args ...attr.Value) (attr.Value, *function.FuncError) {
+	ctx := context.Background()
+	var defResp function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &defResp)
+	require.False(t, defResp.Diagnostics.HasError())
+	require.Len(t, defResp.Definition.Parameters, len(args))
+	resp := function.RunResponse{
+		Result: function.NewResultData(result),
+	}
+	f.Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData(args),
+	}, &resp)
+	return resp.Result.Value(), resp.Error
+}
@@ -0,0 +1,60 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewParsePairIdFunction() function.Function {
+	return &parsePairIdFunction{}
+}
+
+type parsePairIdFunction struct{}
+
+func (f *parsePairIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_pair_id"
+}
+
+func (f *parsePairIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Splits the ID of a resource into two parts",
+		Description: "Splits the ID of a resource, that consists of two parts, i.e. `metastore_id|name` or " +
+			"`scope|||key`, at the first occurrence of the separator. Returns a list with both parts.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "ID of the resource.",
+			},
+			function.StringParameter{
+				Name:        "separator",
+				Description: "Separator of both parts, i.e. `|`, `|||` or `/`.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *parsePairIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id, separator string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &id, &separator))
+	if resp.Error != nil {
+		return
+	}
+	if separator == "" {
+		resp.Error = function.NewArgumentFuncError(1, "separator cannot be empty")
+		return
+	}
+	left, right, err := common.NewPairSeparatedID("first part", "second part", separator).Split(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, []string{left, right}))
+}
+
+var _ function.Function = &parsePairIdFunction{}
@@ -0,0 +1,40 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePairIdFunction(t *testing.T) {
+	result, err := runFunction(t, NewParsePairIdFunction(), types.ListUnknown(types.StringType),
+		types.StringValue("abc|def|ghi"), types.StringValue("|"))
+	require.Nil(t, err)
+	assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("abc"),
+		types.StringValue("def|ghi"),
+	}), result)
+}
+
+func TestParsePairIdFunction_Errors(t *testing.T) {
+	for id, expected := range map[string]string{
+		"abc":  "invalid ID: abc",
+		"/abc": "first part cannot be empty",
+		"abc/": "second part cannot be empty",
+	} {
+		_, err := runFunction(t, NewParsePairIdFunction(), types.ListUnknown(types.StringType),
+			types.StringValue(id), types.StringValue("/"))
+		require.NotNil(t, err, id)
+		assert.Equal(t, expected, err.Text, id)
+	}
+}
+
+func TestParsePairIdFunction_EmptySeparator(t *testing.T) {
+	_, err := runFunction(t, NewParsePairIdFunction(), types.ListUnknown(types.StringType),
+		types.StringValue("abc"), types.StringValue(""))
+	require.NotNil(t, err)
+	assert.Equal(t, "separator cannot be empty", err.Text)
+}
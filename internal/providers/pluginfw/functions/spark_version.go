@@ -0,0 +1,78 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"golang.org/x/mod/semver"
+)
+
+// sparkVersionRegex matches the same Databricks Runtime version keys as the Go SDK does, i.e. `15.4.x-scala2.12`
+var sparkVersionRegex = regexp.MustCompile(`^(\d+\.\d+)\.x-.*`)
+
+// sparkVersionToSemver extracts the Databricks Runtime version from the Spark version key,
+// or accepts a plain `major.minor` version.
+func sparkVersionToSemver(sparkVersion string) (string, error) {
+	version := sparkVersion
+	if m := sparkVersionRegex.FindStringSubmatch(sparkVersion); m != nil {
+		version = m[1]
+	}
+	if !semver.IsValid("v" + version) {
+		return "", fmt.Errorf("%s isn't a valid Spark version", sparkVersion)
+	}
+	return "v" + version, nil
+}
+
+func NewSparkVersionCompareFunction() function.Function {
+	return &sparkVersionCompareFunction{}
+}
+
+type sparkVersionCompareFunction struct{}
+
+func (f *sparkVersionCompareFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "spark_version_compare"
+}
+
+func (f *sparkVersionCompareFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compares Databricks Runtime versions",
+		Description: "Compares two Spark version keys, i.e. `15.4.x-scala2.12`, or plain Databricks Runtime " +
+			"versions, i.e. `15.4`. Returns -1, if the first version is older than the second one, 0, if " +
+			"they are the same, and 1, if the first version is newer.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "a",
+				Description: "First Spark version.",
+			},
+			function.StringParameter{
+				Name:        "b",
+				Description: "Second Spark version.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *sparkVersionCompareFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+	va, err := sparkVersionToSemver(a)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+	}
+	vb, err := sparkVersionToSemver(b)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, err.Error()))
+	}
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, int64(semver.Compare(va, vb))))
+}
+
+var _ function.Function = &sparkVersionCompareFunction{}
@@ -0,0 +1,36 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparkVersionCompareFunction(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		expected int64
+	}{
+		{"15.4.x-scala2.12", "15.4.x-scala2.12", 0},
+		{"15.4.x-scala2.12", "15.4.x-gpu-ml-scala2.12", 0},
+		{"9.1.x-scala2.12", "15.4.x-scala2.12", -1},
+		{"16.0.x-scala2.12", "15.4", 1},
+		{"13.3", "13.10", -1},
+	} {
+		result, err := runFunction(t, NewSparkVersionCompareFunction(), types.Int64Unknown(),
+			types.StringValue(tc.a), types.StringValue(tc.b))
+		require.Nil(t, err)
+		assert.Equal(t, types.Int64Value(tc.expected), result, "%s vs %s", tc.a, tc.b)
+	}
+}
+
+func TestSparkVersionCompareFunction_Error(t *testing.T) {
+	_, err := runFunction(t, NewSparkVersionCompareFunction(), types.Int64Unknown(),
+		types.StringValue("15.4.x-scala2.12"), types.StringValue("latest"))
+	require.NotNil(t, err)
+	assert.Equal(t, "latest isn't a valid Spark version", err.Text)
+	require.NotNil(t, err.FunctionArgument)
+	assert.Equal(t, int64(1), *err.FunctionArgument)
+}
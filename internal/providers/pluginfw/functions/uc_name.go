@@ -0,0 +1,180 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/catalog"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// splitUcName splits the Unity Catalog name into up to three parts. Parts could be quoted with backticks,
+// in which case they can contain dots, and backticks inside of them are escaped by doubling them.
+func splitUcName(name string) ([]string, error) {
+	var parts []string
+	var part strings.Builder
+	quoted, wasQuoted := false, false
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case quoted && c == '`' && i+1 < len(name) && name[i+1] == '`':
+			part.WriteByte(c)
+			i++
+		case quoted && c == '`':
+			quoted = false
+		case quoted:
+			part.WriteByte(c)
+		case c == '`' && part.Len() == 0 && !wasQuoted:
+			quoted, wasQuoted = true, true
+		case c == '.':
+			parts = append(parts, part.String())
+			part.Reset()
+			wasQuoted = false
+		case wasQuoted:
+			return nil, fmt.Errorf("unexpected character after the closing backtick in %s", name)
+		default:
+			part.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated backtick in %s", name)
+	}
+	parts = append(parts, part.String())
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("%s has more than three parts", name)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return nil, fmt.Errorf("%s has an empty part", name)
+		}
+	}
+	return parts, nil
+}
+
+// escapeUcIdentifier escapes backticks in the identifier, so that it can be quoted with backticks
+func escapeUcIdentifier(identifier string) string {
+	return strings.ReplaceAll(identifier, "`", "``")
+}
+
+func NewParseUcNameFunction() function.Function {
+	return &parseUcNameFunction{}
+}
+
+type parseUcNameFunction struct{}
+
+type ucNameModel struct {
+	Catalog types.String `tfsdk:"catalog"`
+	Schema  types.String `tfsdk:"schema"`
+	Name    types.String `tfsdk:"name"`
+}
+
+func (f *parseUcNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_uc_name"
+}
+
+func (f *parseUcNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses the Unity Catalog name into catalog, schema and name",
+		Description: "Parses the full name of a Unity Catalog object, i.e. `main.default.my_table`, into an object with " +
+			"`catalog`, `schema` and `name` attributes. Names of schemas and catalogs have fewer parts, so the " +
+			"missing attributes are null. Parts can be quoted with backticks.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "full_name",
+				Description: "Full name of the Unity Catalog object.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"catalog": types.StringType,
+				"schema":  types.StringType,
+				"name":    types.StringType,
+			},
+		},
+	}
+}
+
+func (f *parseUcNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fullName string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fullName))
+	if resp.Error != nil {
+		return
+	}
+	parts, err := splitUcName(fullName)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	values := make([]types.String, 3)
+	for i := range values {
+		if i < len(parts) {
+			values[i] = types.StringValue(parts[i])
+		} else {
+			values[i] = types.StringNull()
+		}
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, ucNameModel{
+		Catalog: values[0],
+		Schema:  values[1],
+		Name:    values[2],
+	}))
+}
+
+func NewQuoteUcIdentifierFunction() function.Function {
+	return &quoteUcIdentifierFunction{}
+}
+
+type quoteUcIdentifierFunction struct{}
+
+func (f *quoteUcIdentifierFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "quote_uc_identifier"
+}
+
+func (f *quoteUcIdentifierFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Quotes the Unity Catalog name for use in SQL statements",
+		Description: "Quotes every part of the Unity Catalog name with backticks, i.e. `main.default.my-table` becomes " +
+			"`` `main`.`default`.`my-table` ``. Backticks inside of the parts are escaped.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "full_name",
+				Description: "Name of the catalog, or full name of the schema or the object in the schema.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *quoteUcIdentifierFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fullName string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fullName))
+	if resp.Error != nil {
+		return
+	}
+	parts, err := splitUcName(fullName)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	var quoted string
+	if len(parts) == 3 {
+		ti := catalog.SqlTableInfo{
+			CatalogName: escapeUcIdentifier(parts[0]),
+			SchemaName:  escapeUcIdentifier(parts[1]),
+			Name:        escapeUcIdentifier(parts[2]),
+		}
+		quoted = ti.SQLFullName()
+	} else {
+		for i, part := range parts {
+			parts[i] = "`" + escapeUcIdentifier(part) + "`"
+		}
+		quoted = strings.Join(parts, ".")
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, quoted))
+}
+
+var _ function.Function = &parseUcNameFunction{}
+var _ function.Function = &quoteUcIdentifierFunction{}
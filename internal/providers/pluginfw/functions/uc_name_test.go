@@ -0,0 +1,76 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ucNameType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"catalog": types.StringType,
+	"schema":  types.StringType,
+	"name":    types.StringType,
+}}
+
+func TestSplitUcName(t *testing.T) {
+	for name, expected := range map[string][]string{
+		"main":                   {"main"},
+		"main.default":           {"main", "default"},
+		"main.default.my_table":  {"main", "default", "my_table"},
+		"`main`.`default`.`a.b`": {"main", "default", "a.b"},
+		"main.`we``ird`.t":       {"main", "we`ird", "t"},
+	} {
+		parts, err := splitUcName(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, parts, name)
+	}
+}
+
+func TestSplitUcName_Errors(t *testing.T) {
+	for name, expected := range map[string]string{
+		"a.b.c.d":  "a.b.c.d has more than three parts",
+		"a..c":     "a..c has an empty part",
+		"":         " has an empty part",
+		"`a.b":     "unterminated backtick in `a.b",
+		"`a`b.c":   "unexpected character after the closing backtick in `a`b.c",
+		"main.``":  "main.`` has an empty part",
+		"main.sc.": "main.sc. has an empty part",
+	} {
+		_, err := splitUcName(name)
+		assert.EqualError(t, err, expected, name)
+	}
+}
+
+func TestParseUcNameFunction(t *testing.T) {
+	result, err := runFunction(t, NewParseUcNameFunction(), types.ObjectUnknown(ucNameType.AttrTypes),
+		types.StringValue("main.default"))
+	require.Nil(t, err)
+	assert.Equal(t, types.ObjectValueMust(ucNameType.AttrTypes, map[string]attr.Value{
+		"catalog": types.StringValue("main"),
+		"schema":  types.StringValue("default"),
+		"name":    types.StringNull(),
+	}), result)
+}
+
+func TestParseUcNameFunction_Error(t *testing.T) {
+	_, err := runFunction(t, NewParseUcNameFunction(), types.ObjectUnknown(ucNameType.AttrTypes),
+		types.StringValue("a.b.c.d"))
+	require.NotNil(t, err)
+	assert.Equal(t, "a.b.c.d has more than three parts", err.Text)
+}
+
+func TestQuoteUcIdentifierFunction(t *testing.T) {
+	for name, expected := range map[string]string{
+		"main":                  "`main`",
+		"main.default":          "`main`.`default`",
+		"main.default.my-table": "`main`.`default`.`my-table`",
+		"main.`we``ird`.`a.b`":  "`main`.`we``ird`.`a.b`",
+	} {
+		result, err := runFunction(t, NewQuoteUcIdentifierFunction(), types.StringUnknown(), types.StringValue(name))
+		require.Nil(t, err, name)
+		assert.Equal(t, types.StringValue(expected), result, name)
+	}
+}
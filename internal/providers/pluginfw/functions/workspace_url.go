@@ -0,0 +1,58 @@
+package functions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/mws"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+func NewWorkspaceUrlFunction() function.Function {
+	return &workspaceUrlFunction{}
+}
+
+type workspaceUrlFunction struct{}
+
+func (f *workspaceUrlFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "workspace_url"
+}
+
+func (f *workspaceUrlFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Computes the workspace URL from the deployment name",
+		Description: "Computes the URL of the workspace with the given deployment name in the same way as " +
+			"`databricks_mws_workspaces` does, i.e. `https://accounts.cloud.databricks.com` and `my-workspace` " +
+			"give `https://my-workspace.cloud.databricks.com`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "accounts_host",
+				Description: "Host of the account console.",
+			},
+			function.StringParameter{
+				Name:        "deployment_name",
+				Description: "Deployment name of the workspace.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *workspaceUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var accountsHost, deploymentName string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &accountsHost, &deploymentName))
+	if resp.Error != nil {
+		return
+	}
+	if deploymentName == "" {
+		resp.Error = function.NewArgumentFuncError(1, "deployment name cannot be empty")
+		return
+	}
+	if !strings.Contains(accountsHost, "://") {
+		accountsHost = "https://" + accountsHost
+	}
+	url := "https://" + mws.WorkspaceHostname(accountsHost, deploymentName)
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, url))
+}
+
+var _ function.Function = &workspaceUrlFunction{}
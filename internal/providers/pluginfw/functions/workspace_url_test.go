@@ -0,0 +1,30 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceUrlFunction(t *testing.T) {
+	for host, expected := range map[string]string{
+		"https://accounts.cloud.databricks.com":   "https://my-workspace.cloud.databricks.com",
+		"accounts.cloud.databricks.com":           "https://my-workspace.cloud.databricks.com",
+		"https://accounts.gcp.databricks.com/":    "https://my-workspace.gcp.databricks.com",
+		"https://accounts.staging.databricks.com": "https://my-workspace.staging.databricks.com",
+	} {
+		result, err := runFunction(t, NewWorkspaceUrlFunction(), types.StringUnknown(),
+			types.StringValue(host), types.StringValue("my-workspace"))
+		require.Nil(t, err, host)
+		assert.Equal(t, types.StringValue(expected), result, host)
+	}
+}
+
+func TestWorkspaceUrlFunction_EmptyDeploymentName(t *testing.T) {
+	_, err := runFunction(t, NewWorkspaceUrlFunction(), types.StringUnknown(),
+		types.StringValue("https://accounts.cloud.databricks.com"), types.StringValue(""))
+	require.NotNil(t, err)
+	assert.Equal(t, "deployment name cannot be empty", err.Text)
+}
@@ -0,0 +1,208 @@
+// Package listresources contains list resources for `terraform query`. Listed resources are implemented with SDKv2,
+// so list resources reuse their schemas and read functions, and only list IDs of the objects.
+package listresources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	pluginfwcommon "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/common"
+	pluginfwcontext "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkv2diag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdkv2schema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// listedObject is an object returned by the lister
+type listedObject struct {
+	// ID of the resource, i.e. the value to import it with
+	ID string
+	// Name is matched against the filter and displayed to the user
+	Name string
+}
+
+// lister lists objects and passes them to emit, until it returns false. Parents contain values of
+// the required attributes of the list resource, i.e. `catalog_name`.
+type lister func(ctx context.Context, c *common.DatabricksClient, parents map[string]string,
+	emit func(listedObject) bool) error
+
+// listResource lists objects of the SDKv2 resource
+type listResource struct {
+	client *common.DatabricksClient
+	// name of the resource without the `databricks_` prefix
+	name     string
+	resource func() common.Resource
+	// workspaceLevel must match the registration of the resource in the SDKv2 provider,
+	// so that the schema has the `workspace_id` attribute
+	workspaceLevel bool
+	// parents are required attributes of the list resource, i.e. `catalog_name`
+	parents map[string]string
+	list    lister
+}
+
+func (r *listResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = pluginfwcommon.GetDatabricksProductionName(r.name)
+}
+
+func (r *listResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"match": schema.StringAttribute{
+			Optional:    true,
+			Description: "Case-insensitive substring of the name, same as the `-match` flag of the exporter.",
+		},
+		"match_regex": schema.StringAttribute{
+			Optional:    true,
+			Description: "Regular expression to match the name against, same as the `-matchRegex` flag of the exporter.",
+		},
+		"exclude_regex": schema.StringAttribute{
+			Optional:    true,
+			Description: "Regular expression to exclude matching names, same as the `-excludeRegex` flag of the exporter.",
+		},
+	}
+	for parent, description := range r.parents {
+		attributes[parent] = schema.StringAttribute{
+			Required:    true,
+			Description: description,
+		}
+	}
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Lists existing objects, that can be imported as databricks_%s.", r.name),
+		Attributes:  attributes,
+	}
+}
+
+func (r *listResource) RawV6Schemas(ctx context.Context, req list.RawV6SchemaRequest, resp *list.RawV6SchemaResponse) {
+	res := r.sdkv2Resource()
+	resp.ProtoV6Schema = schemaToProto6(res.ProtoSchema(ctx)())
+	resp.ProtoV6IdentitySchema = identitySchemaToProto6(res.ProtoIdentitySchema(ctx)())
+}
+
+// sdkv2Resource returns the listed resource the same way, as it's registered in the SDKv2 provider
+func (r *listResource) sdkv2Resource() *sdkv2schema.Resource {
+	res := r.resource()
+	res.WorkspaceLevel = r.workspaceLevel
+	return res.ToResource()
+}
+
+func (r *listResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if r.client == nil && req.ProviderData != nil {
+		r.client = pluginfwcommon.ConfigureResource(req, resp)
+	}
+}
+
+func (r *listResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx = pluginfwcontext.SetUserAgentInListResourceContext(ctx, r.name)
+	ctx = context.WithValue(ctx, common.ResourceName, r.name)
+	filter, parents, diags := r.readConfig(ctx, req)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	res := r.sdkv2Resource()
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		err := r.list(ctx, r.client, parents, func(obj listedObject) bool {
+			if !filter.Matches(obj.Name) {
+				return true
+			}
+			result, ok := r.newListResult(ctx, req, res, obj)
+			if !ok {
+				// the object was removed after it was listed
+				return true
+			}
+			count++
+			return push(result) && (req.Limit == 0 || count < req.Limit)
+		})
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddError(fmt.Sprintf("failed to list %s", r.name), err.Error())
+			push(list.ListResult{Diagnostics: diags})
+		}
+	}
+}
+
+// readConfig reads the name filter and values of the parent attributes from the configuration
+func (r *listResource) readConfig(ctx context.Context, req list.ListRequest) (common.NameFilter, map[string]string, diag.Diagnostics) {
+	var filter common.NameFilter
+	var diags diag.Diagnostics
+	var match, matchRegex, excludeRegex types.String
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("match"), &match)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("match_regex"), &matchRegex)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("exclude_regex"), &excludeRegex)...)
+	filter.Match = match.ValueString()
+	var err error
+	if matchRegex.ValueString() != "" {
+		filter.MatchRegex, err = regexp.Compile(matchRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("match_regex"), "invalid regular expression", err.Error())
+		}
+	}
+	if excludeRegex.ValueString() != "" {
+		filter.ExcludeRegex, err = regexp.Compile(excludeRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("exclude_regex"), "invalid regular expression", err.Error())
+		}
+	}
+	parents := map[string]string{}
+	for parent := range r.parents {
+		var v types.String
+		diags.Append(req.Config.GetAttribute(ctx, path.Root(parent), &v)...)
+		parents[parent] = v.ValueString()
+	}
+	return filter, parents, diags
+}
+
+// newListResult returns the identity of the object and reads the resource, if Terraform requested it.
+// It returns false, if the object doesn't exist anymore.
+func (r *listResource) newListResult(ctx context.Context, req list.ListRequest, res *sdkv2schema.Resource,
+	obj listedObject) (list.ListResult, bool) {
+	result := req.NewListResult(ctx)
+	result.DisplayName = obj.Name
+	d := res.Data(nil)
+	d.SetId(obj.ID)
+	if req.IncludeResource {
+		// the same as for import, so that all fields returned by the API are kept
+		d.MarkNewResource()
+		diags := res.ReadContext(ctx, d, r.client)
+		for _, v := range diags {
+			if v.Severity == sdkv2diag.Error {
+				result.Diagnostics.AddError(v.Summary, v.Detail)
+			} else {
+				result.Diagnostics.AddWarning(v.Summary, v.Detail)
+			}
+		}
+		if diags.HasError() {
+			return result, true
+		}
+		if d.Id() == "" {
+			return result, false
+		}
+		state, err := d.TfTypeResourceState()
+		if err != nil {
+			result.Diagnostics.AddError("failed to convert resource state", err.Error())
+			return result, true
+		}
+		result.Resource.Raw = *state
+	}
+	if err := common.SetIdentityFromId(d); err != nil {
+		result.Diagnostics.AddError("failed to set resource identity", err.Error())
+		return result, true
+	}
+	identity, err := d.TfTypeIdentityState()
+	if err != nil {
+		result.Diagnostics.AddError("failed to convert resource identity", err.Error())
+		return result, true
+	}
+	result.Identity.Raw = *identity
+	return result, true
+}
+
+var _ list.ListResourceWithConfigure = &listResource{}
+var _ list.ListResourceWithRawV6Schemas = &listResource{}
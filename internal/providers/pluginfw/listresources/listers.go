@@ -0,0 +1,284 @@
+package listresources
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/listing"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/databricks-sdk-go/service/pipelines"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	tf_catalog "github.com/databricks/terraform-provider-databricks/catalog"
+	"github.com/databricks/terraform-provider-databricks/clusters"
+	"github.com/databricks/terraform-provider-databricks/common"
+	tf_jobs "github.com/databricks/terraform-provider-databricks/jobs"
+	tf_pipelines "github.com/databricks/terraform-provider-databricks/pipelines"
+	"github.com/databricks/terraform-provider-databricks/scim"
+	tf_sql "github.com/databricks/terraform-provider-databricks/sql"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+const (
+	catalogNameAttribute = "catalog_name"
+	schemaNameAttribute  = "schema_name"
+)
+
+var (
+	catalogParents = map[string]string{
+		catalogNameAttribute: "Name of the catalog to list schemas in.",
+	}
+	schemaParents = map[string]string{
+		catalogNameAttribute: "Name of the catalog of the schema.",
+		schemaNameAttribute:  "Name of the schema to list objects in.",
+	}
+)
+
+// iterate passes all items of the iterator to emit, until it returns false
+func iterate[T any](ctx context.Context, it listing.Iterator[T], emit func(T) bool) error {
+	for it.HasNext(ctx) {
+		v, err := it.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if !emit(v) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// workspaceLister is a lister, that works only with a workspace-level provider
+func workspaceLister(list func(ctx context.Context, w *databricks.WorkspaceClient, parents map[string]string,
+	emit func(listedObject) bool) error) lister {
+	return func(ctx context.Context, c *common.DatabricksClient, parents map[string]string, emit func(listedObject) bool) error {
+		w, err := c.WorkspaceClient()
+		if err != nil {
+			return err
+		}
+		return list(ctx, w, parents, emit)
+	}
+}
+
+func ListResourceCluster() list.ListResource {
+	return &listResource{
+		name:           "cluster",
+		resource:       clusters.ResourceCluster,
+		workspaceLevel: true,
+		list: workspaceLister(func(ctx context.Context, w *databricks.WorkspaceClient, _ map[string]string,
+			emit func(listedObject) bool) error {
+			// job clusters can't be managed by Terraform
+			it := w.Clusters.List(ctx, compute.ListClustersRequest{
+				FilterBy: &compute.ListClustersFilterBy{
+					ClusterSources: []compute.ClusterSource{compute.ClusterSourceUi, compute.ClusterSourceApi},
+				},
+				PageSize: 100,
+			})
+			return iterate(ctx, it, func(c compute.ClusterDetails) bool {
+				return emit(listedObject{ID: c.ClusterId, Name: c.ClusterName})
+			})
+		}),
+	}
+}
+
+func ListResourceJob() list.ListResource {
+	return &listResource{
+		name:           "job",
+		resource:       tf_jobs.ResourceJob,
+		workspaceLevel: true,
+		list: workspaceLister(func(ctx context.Context, w *databricks.WorkspaceClient, _ map[string]string,
+			emit func(listedObject) bool) error {
+			it := w.Jobs.List(ctx, jobs.ListJobsRequest{Limit: 100})
+			return iterate(ctx, it, func(job jobs.BaseJob) bool {
+				if job.Settings == nil {
+					return true
+				}
+				// jobs deployed by Databricks Asset Bundles are managed by them
+				if job.Settings.Deployment != nil && job.Settings.Deployment.Kind == "BUNDLE" &&
+					job.Settings.EditMode == "UI_LOCKED" {
+					return true
+				}
+				return emit(listedObject{ID: strconv.FormatInt(job.JobId, 10), Name: job.Settings.Name})
+			})
+		}),
+	}
+}
+
+func ListResourcePipeline() list.ListResource {
+	return &listResource{
+		name:           "pipeline",
+		resource:       tf_pipelines.ResourcePipeline,
+		workspaceLevel: true,
+		list: workspaceLister(func(ctx context.Context, w *databricks.WorkspaceClient, _ map[string]string,
+			emit func(listedObject) bool) error {
+			it := w.Pipelines.ListPipelines(ctx, pipelines.ListPipelinesRequest{MaxResults: 100})
+			return iterate(ctx, it, func(p pipelines.PipelineStateInfo) bool {
+				return emit(listedObject{ID: p.PipelineId, Name: p.Name})
+			})
+		}),
+	}
+}
+
+func ListResourceSqlEndpoint() list.ListResource {
+	return &listResource{
+		name:           "sql_endpoint",
+		resource:       tf_sql.ResourceSqlEndpoint,
+		workspaceLevel: true,
+		list: workspaceLister(func(ctx context.Context, w *databricks.WorkspaceClient, _ map[string]string,
+			emit func(listedObject) bool) error {
+			it := w.Warehouses.List(ctx, sql.ListWarehousesRequest{})
+			return iterate(ctx, it, func(wh sql.EndpointInfo) bool {
+				return emit(listedObject{ID: wh.Id, Name: wh.Name})
+			})
+		}),
+	}
+}
+
+func ListResourceCatalog() list.ListResource {
+	return &listResource{
+		name:           "catalog",
+		resource:       tf_catalog.ResourceCatalog,
+		workspaceLevel: true,
+		list: workspaceLister(func(ctx context.Context, w *databricks.WorkspaceClient, _ map[string]string,
+			emit func(listedObject) bool) error {
+			it := w.Catalogs.List(ctx, catalog.ListCatalogsRequest{})
+			return iterate(ctx, it, func(c catalog.CatalogInfo) bool {
+				// system and internal catalogs can't be managed by Terraform
+				switch c.CatalogType {
+				case "MANAGED_CATALOG", "FOREIGN_CATALOG", "DELTASHARING_CATALOG":
+					return emit(listedObject{ID: c.Name, Name: c.Name})
+				}
+				return true
+			})
+		}),
+	}
+}
+
+func ListResourceSchema() list.ListResource {
+	return &listResource{
+		name:           "schema",
+		resource:       tf_catalog.ResourceSchema,
+		workspaceLevel: true,
+		parents:        catalogParents,
+		list: workspaceLister(func(ctx context.Context, w *databricks.WorkspaceClient, parents map[string]string,
+			emit func(listedObject) bool) error {
+			it := w.Schemas.List(ctx, catalog.ListSchemasRequest{
+				CatalogName: parents[catalogNameAttribute],
+			})
+			ignoredSchemas := []string{"information_schema"}
+			return iterate(ctx, it, func(s catalog.SchemaInfo) bool {
+				if slices.Contains(ignoredSchemas, s.Name) {
+					return true
+				}
+				return emit(listedObject{ID: s.FullName, Name: s.Name})
+			})
+		}),
+	}
+}
+
+func ListResourceSqlTable() list.ListResource {
+	return &listResource{
+		name:           "sql_table",
+		resource:       tf_catalog.ResourceSqlTable,
+		workspaceLevel: true,
+		parents:        schemaParents,
+		list: workspaceLister(func(ctx context.Context, w *databricks.WorkspaceClient, parents map[string]string,
+			emit func(listedObject) bool) error {
+			it := w.Tables.List(ctx, catalog.ListTablesRequest{
+				CatalogName: parents[catalogNameAttribute],
+				SchemaName:  parents[schemaNameAttribute],
+			})
+			return iterate(ctx, it, func(t catalog.TableInfo) bool {
+				// other table types are managed by other resources, i.e. databricks_online_table
+				switch t.TableType {
+				case "MANAGED", "EXTERNAL", "VIEW":
+					return emit(listedObject{ID: t.FullName, Name: t.Name})
+				}
+				return true
+			})
+		}),
+	}
+}
+
+func ListResourceVolume() list.ListResource {
+	return &listResource{
+		name:           "volume",
+		resource:       tf_catalog.ResourceVolume,
+		workspaceLevel: true,
+		parents:        schemaParents,
+		list: workspaceLister(func(ctx context.Context, w *databricks.WorkspaceClient, parents map[string]string,
+			emit func(listedObject) bool) error {
+			it := w.Volumes.List(ctx, catalog.ListVolumesRequest{
+				CatalogName: parents[catalogNameAttribute],
+				SchemaName:  parents[schemaNameAttribute],
+			})
+			return iterate(ctx, it, func(v catalog.VolumeInfo) bool {
+				return emit(listedObject{ID: v.FullName, Name: v.Name})
+			})
+		}),
+	}
+}
+
+func ListResourceUser() list.ListResource {
+	return &listResource{
+		name:     "user",
+		resource: scim.ResourceUser,
+		list: func(ctx context.Context, c *common.DatabricksClient, _ map[string]string, emit func(listedObject) bool) error {
+			emitUser := func(u iam.User) bool {
+				return emit(listedObject{ID: u.Id, Name: u.UserName})
+			}
+			return c.AccountOrWorkspaceRequest(func(acc *databricks.AccountClient) error {
+				return iterate(ctx, acc.Users.List(ctx, iam.ListAccountUsersRequest{Attributes: "id,userName"}), emitUser)
+			}, func(w *databricks.WorkspaceClient) error {
+				return iterate(ctx, w.Users.List(ctx, iam.ListUsersRequest{Attributes: "id,userName"}), emitUser)
+			})
+		},
+	}
+}
+
+func ListResourceGroup() list.ListResource {
+	return &listResource{
+		name:     "group",
+		resource: scim.ResourceGroup,
+		list: func(ctx context.Context, c *common.DatabricksClient, _ map[string]string, emit func(listedObject) bool) error {
+			emitGroup := func(g iam.Group) bool {
+				return emit(listedObject{ID: g.Id, Name: g.DisplayName})
+			}
+			return c.AccountOrWorkspaceRequest(func(acc *databricks.AccountClient) error {
+				return iterate(ctx, acc.Groups.List(ctx, iam.ListAccountGroupsRequest{Attributes: "id,displayName"}), emitGroup)
+			}, func(w *databricks.WorkspaceClient) error {
+				return iterate(ctx, w.Groups.List(ctx, iam.ListGroupsRequest{Attributes: "id,displayName"}), emitGroup)
+			})
+		},
+	}
+}
+
+func ListResourceServicePrincipal() list.ListResource {
+	return &listResource{
+		name:     "service_principal",
+		resource: scim.ResourceServicePrincipal,
+		list: func(ctx context.Context, c *common.DatabricksClient, _ map[string]string, emit func(listedObject) bool) error {
+			emitServicePrincipal := func(sp iam.ServicePrincipal) bool {
+				name := sp.DisplayName
+				if name == "" {
+					name = sp.ApplicationId
+				}
+				return emit(listedObject{ID: sp.Id, Name: name})
+			}
+			attributes := "id,applicationId,displayName"
+			return c.AccountOrWorkspaceRequest(func(acc *databricks.AccountClient) error {
+				return iterate(ctx, acc.ServicePrincipals.List(ctx, iam.ListAccountServicePrincipalsRequest{
+					Attributes: attributes,
+				}), emitServicePrincipal)
+			}, func(w *databricks.WorkspaceClient) error {
+				return iterate(ctx, w.ServicePrincipals.List(ctx, iam.ListServicePrincipalsRequest{
+					Attributes: attributes,
+				}), emitServicePrincipal)
+			})
+		},
+	}
+}
@@ -0,0 +1,158 @@
+package listresources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/listing"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func workspaceClient(w *mocks.MockWorkspaceClient) *common.DatabricksClient {
+	c := &common.DatabricksClient{
+		DatabricksClient: &client.DatabricksClient{
+			Config: &config.Config{Host: "https://x.cloud.databricks.com"},
+		},
+	}
+	c.SetWorkspaceClient(w.WorkspaceClient)
+	return c
+}
+
+// listAll returns all objects listed by the list resource
+func listAll(t *testing.T, lr list.ListResource, c *common.DatabricksClient, parents map[string]string) []listedObject {
+	var objects []listedObject
+	err := lr.(*listResource).list(context.Background(), c, parents, func(obj listedObject) bool {
+		objects = append(objects, obj)
+		return true
+	})
+	require.NoError(t, err)
+	return objects
+}
+
+func TestListClusters(t *testing.T) {
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockClustersAPI().EXPECT().List(mock.Anything, compute.ListClustersRequest{
+		FilterBy: &compute.ListClustersFilterBy{
+			ClusterSources: []compute.ClusterSource{compute.ClusterSourceUi, compute.ClusterSourceApi},
+		},
+		PageSize: 100,
+	}).Return(&listing.SliceIterator[compute.ClusterDetails]{
+		{ClusterId: "abc", ClusterName: "Shared"},
+	})
+	assert.Equal(t, []listedObject{{ID: "abc", Name: "Shared"}},
+		listAll(t, ListResourceCluster(), workspaceClient(w), nil))
+}
+
+func TestListJobs(t *testing.T) {
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockJobsAPI().EXPECT().List(mock.Anything, jobs.ListJobsRequest{Limit: 100}).
+		Return(&listing.SliceIterator[jobs.BaseJob]{
+			{JobId: 1},
+			{JobId: 2, Settings: &jobs.JobSettings{Name: "Nightly"}},
+			{JobId: 3, Settings: &jobs.JobSettings{
+				Name:       "Bundle",
+				Deployment: &jobs.JobDeployment{Kind: "BUNDLE"},
+				EditMode:   "UI_LOCKED",
+			}},
+		})
+	assert.Equal(t, []listedObject{{ID: "2", Name: "Nightly"}},
+		listAll(t, ListResourceJob(), workspaceClient(w), nil))
+}
+
+func TestListJobsStopsWhenEmitReturnsFalse(t *testing.T) {
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockJobsAPI().EXPECT().List(mock.Anything, jobs.ListJobsRequest{Limit: 100}).
+		Return(&listing.SliceIterator[jobs.BaseJob]{
+			{JobId: 1, Settings: &jobs.JobSettings{Name: "First"}},
+			{JobId: 2, Settings: &jobs.JobSettings{Name: "Second"}},
+		})
+	var names []string
+	err := ListResourceJob().(*listResource).list(context.Background(), workspaceClient(w), nil,
+		func(obj listedObject) bool {
+			names = append(names, obj.Name)
+			return false
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"First"}, names)
+}
+
+func TestListCatalogs(t *testing.T) {
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockCatalogsAPI().EXPECT().List(mock.Anything, catalog.ListCatalogsRequest{}).
+		Return(&listing.SliceIterator[catalog.CatalogInfo]{
+			{Name: "main", CatalogType: "MANAGED_CATALOG"},
+			{Name: "system", CatalogType: "SYSTEM_CATALOG"},
+			{Name: "shared", CatalogType: "DELTASHARING_CATALOG"},
+		})
+	assert.Equal(t, []listedObject{{ID: "main", Name: "main"}, {ID: "shared", Name: "shared"}},
+		listAll(t, ListResourceCatalog(), workspaceClient(w), nil))
+}
+
+func TestListSchemas(t *testing.T) {
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockSchemasAPI().EXPECT().List(mock.Anything, catalog.ListSchemasRequest{CatalogName: "main"}).
+		Return(&listing.SliceIterator[catalog.SchemaInfo]{
+			{Name: "default", FullName: "main.default"},
+			{Name: "information_schema", FullName: "main.information_schema"},
+		})
+	assert.Equal(t, []listedObject{{ID: "main.default", Name: "default"}},
+		listAll(t, ListResourceSchema(), workspaceClient(w), map[string]string{"catalog_name": "main"}))
+}
+
+func TestListTables(t *testing.T) {
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockTablesAPI().EXPECT().List(mock.Anything, catalog.ListTablesRequest{
+		CatalogName: "main",
+		SchemaName:  "default",
+	}).Return(&listing.SliceIterator[catalog.TableInfo]{
+		{Name: "sales", FullName: "main.default.sales", TableType: "MANAGED"},
+		{Name: "sales_view", FullName: "main.default.sales_view", TableType: "VIEW"},
+		{Name: "sales_online", FullName: "main.default.sales_online", TableType: "FOREIGN"},
+	})
+	assert.Equal(t, []listedObject{
+		{ID: "main.default.sales", Name: "sales"},
+		{ID: "main.default.sales_view", Name: "sales_view"},
+	}, listAll(t, ListResourceSqlTable(), workspaceClient(w), map[string]string{
+		"catalog_name": "main",
+		"schema_name":  "default",
+	}))
+}
+
+func TestListServicePrincipals(t *testing.T) {
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockServicePrincipalsAPI().EXPECT().List(mock.Anything, iam.ListServicePrincipalsRequest{
+		Attributes: "id,applicationId,displayName",
+	}).Return(&listing.SliceIterator[iam.ServicePrincipal]{
+		{Id: "1", ApplicationId: "a", DisplayName: "Automation"},
+		{Id: "2", ApplicationId: "b"},
+	})
+	assert.Equal(t, []listedObject{{ID: "1", Name: "Automation"}, {ID: "2", Name: "b"}},
+		listAll(t, ListResourceServicePrincipal(), workspaceClient(w), nil))
+}
+
+func TestListGroupsWithAccountClient(t *testing.T) {
+	a := mocks.NewMockAccountClient(t)
+	a.GetMockAccountGroupsAPI().EXPECT().List(mock.Anything, iam.ListAccountGroupsRequest{
+		Attributes: "id,displayName",
+	}).Return(&listing.SliceIterator[iam.Group]{
+		{Id: "1", DisplayName: "admins"},
+	})
+	c := &common.DatabricksClient{
+		DatabricksClient: &client.DatabricksClient{
+			Config: &config.Config{Host: "https://accounts.cloud.databricks.com", AccountID: "abc"},
+		},
+	}
+	c.SetAccountClient(a.AccountClient)
+	assert.Equal(t, []listedObject{{ID: "1", Name: "admins"}}, listAll(t, ListResourceGroup(), c, nil))
+}
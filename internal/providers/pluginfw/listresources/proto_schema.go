@@ -0,0 +1,74 @@
+package listresources
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// SDKv2 resources only have protocol version 5 schemas, that are upgraded in the same way
+// as tf5to6server does it for the whole SDKv2 provider.
+
+func schemaToProto6(s *tfprotov5.Schema) *tfprotov6.Schema {
+	if s == nil {
+		return nil
+	}
+	return &tfprotov6.Schema{
+		Version: s.Version,
+		Block:   schemaBlockToProto6(s.Block),
+	}
+}
+
+func schemaBlockToProto6(b *tfprotov5.SchemaBlock) *tfprotov6.SchemaBlock {
+	if b == nil {
+		return nil
+	}
+	block := &tfprotov6.SchemaBlock{
+		Version:         b.Version,
+		Description:     b.Description,
+		DescriptionKind: tfprotov6.StringKind(b.DescriptionKind),
+		Deprecated:      b.Deprecated,
+	}
+	for _, a := range b.Attributes {
+		block.Attributes = append(block.Attributes, &tfprotov6.SchemaAttribute{
+			Name:            a.Name,
+			Type:            a.Type,
+			Description:     a.Description,
+			Required:        a.Required,
+			Optional:        a.Optional,
+			Computed:        a.Computed,
+			Sensitive:       a.Sensitive,
+			DescriptionKind: tfprotov6.StringKind(a.DescriptionKind),
+			Deprecated:      a.Deprecated,
+			WriteOnly:       a.WriteOnly,
+		})
+	}
+	for _, nb := range b.BlockTypes {
+		block.BlockTypes = append(block.BlockTypes, &tfprotov6.SchemaNestedBlock{
+			TypeName: nb.TypeName,
+			Block:    schemaBlockToProto6(nb.Block),
+			Nesting:  tfprotov6.SchemaNestedBlockNestingMode(nb.Nesting),
+			MinItems: nb.MinItems,
+			MaxItems: nb.MaxItems,
+		})
+	}
+	return block
+}
+
+func identitySchemaToProto6(s *tfprotov5.ResourceIdentitySchema) *tfprotov6.ResourceIdentitySchema {
+	if s == nil {
+		return nil
+	}
+	identity := &tfprotov6.ResourceIdentitySchema{
+		Version: s.Version,
+	}
+	for _, a := range s.IdentityAttributes {
+		identity.IdentityAttributes = append(identity.IdentityAttributes, &tfprotov6.ResourceIdentitySchemaAttribute{
+			Name:              a.Name,
+			Type:              a.Type,
+			RequiredForImport: a.RequiredForImport,
+			OptionalForImport: a.OptionalForImport,
+			Description:       a.Description,
+		})
+	}
+	return identity
+}
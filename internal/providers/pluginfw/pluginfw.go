@@ -6,6 +6,7 @@ package pluginfw
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
 	"sort"
 	"strings"
@@ -14,12 +15,17 @@ import (
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/internal/providers/client"
 	providercommon "github.com/databricks/terraform-provider-databricks/internal/providers/common"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -45,6 +51,9 @@ type DatabricksProviderPluginFramework struct {
 }
 
 var _ provider.Provider = (*DatabricksProviderPluginFramework)(nil)
+var _ provider.ProviderWithEphemeralResources = (*DatabricksProviderPluginFramework)(nil)
+var _ provider.ProviderWithFunctions = (*DatabricksProviderPluginFramework)(nil)
+var _ provider.ProviderWithListResources = (*DatabricksProviderPluginFramework)(nil)
 
 func (p *DatabricksProviderPluginFramework) Resources(ctx context.Context) []func() resource.Resource {
 	return getPluginFrameworkResourcesToRegister(p.sdkV2ResourceFallbacks)
@@ -54,6 +63,18 @@ func (p *DatabricksProviderPluginFramework) DataSources(ctx context.Context) []f
 	return getPluginFrameworkDataSourcesToRegister(p.sdkV2DataSourceFallbacks)
 }
 
+func (p *DatabricksProviderPluginFramework) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return pluginFwOnlyEphemeralResources
+}
+
+func (p *DatabricksProviderPluginFramework) Functions(ctx context.Context) []func() function.Function {
+	return pluginFwFunctions
+}
+
+func (p *DatabricksProviderPluginFramework) ListResources(ctx context.Context) []func() list.ListResource {
+	return pluginFwOnlyListResources
+}
+
 func (p *DatabricksProviderPluginFramework) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = providerSchemaPluginFramework()
 }
@@ -67,6 +88,8 @@ func (p *DatabricksProviderPluginFramework) Configure(ctx context.Context, req p
 	client := p.configureDatabricksClient(ctx, req, resp)
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ListResourceData = client
 }
 
 // Function returns a schema.Schema based on config attributes where each attribute is mapped to the appropriate
@@ -92,9 +115,95 @@ func providerSchemaPluginFramework() schema.Schema {
 			}
 		}
 	}
+	ps[common.ReadOnlyField] = schema.BoolAttribute{
+		Optional: true,
+	}
+	retryClasses := map[string]schema.Block{}
+	for _, class := range common.RetryClasses {
+		retryClasses[string(class)] = schema.ListNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: map[string]schema.Attribute{
+					common.RetryMaxAttemptsField:       schema.Int64Attribute{Optional: true},
+					common.RetryMinBackoffSecondsField: schema.Int64Attribute{Optional: true},
+					common.RetryMaxBackoffSecondsField: schema.Int64Attribute{Optional: true},
+					common.RetryJitterField:            schema.BoolAttribute{Optional: true},
+				},
+			},
+			Validators: []validator.List{
+				listvalidator.SizeAtMost(1),
+			},
+		}
+	}
 	return schema.Schema{
 		Attributes: ps,
+		Blocks: map[string]schema.Block{
+			common.DeletionProtectionField: schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						common.DeletionProtectionResourceTypesField: schema.SetAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+			},
+			common.DefaultTagsField: schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						common.DefaultTagsTagsField: schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+			},
+			common.RetryField: schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Blocks: retryClasses,
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+			},
+		},
+	}
+}
+
+// retryPolicy is a class of errors in the `retry` block
+type retryPolicy struct {
+	MaxAttempts       types.Int64 `tfsdk:"max_attempts"`
+	MinBackoffSeconds types.Int64 `tfsdk:"min_backoff_seconds"`
+	MaxBackoffSeconds types.Int64 `tfsdk:"max_backoff_seconds"`
+	Jitter            types.Bool  `tfsdk:"jitter"`
+}
+
+// retryPolicies reads classes of errors configured in the `retry` block
+func retryPolicies(ctx context.Context, cfg tfsdk.Config) (common.RetryPolicies, diag.Diagnostics) {
+	policies := common.RetryPolicies{}
+	var diags diag.Diagnostics
+	for _, class := range common.RetryClasses {
+		var blocks []retryPolicy
+		diags.Append(cfg.GetAttribute(ctx, path.Root(common.RetryField).AtListIndex(0).AtName(string(class)), &blocks)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		for _, v := range blocks {
+			policy, err := common.NewRetryPolicy(class, v.MaxAttempts.ValueInt64Pointer(),
+				v.MinBackoffSeconds.ValueInt64Pointer(), v.MaxBackoffSeconds.ValueInt64Pointer(), v.Jitter.ValueBoolPointer())
+			if err != nil {
+				diags.AddError("Failed to configure Databricks client", err.Error())
+				return nil, diags
+			}
+			policies[class] = policy
+		}
 	}
+	return policies, diags
 }
 
 // setAttribute sets the attribute value in the SDK config corresponding to the attribute name in the provider configuration.
@@ -169,10 +278,57 @@ func (p *DatabricksProviderPluginFramework) configureDatabricksClient(ctx contex
 	} else {
 		tflog.Info(ctx, "(plugin framework) No attributes specified in provider configuration")
 	}
+	policies, diags := retryPolicies(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+	var readOnlyValue types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(common.ReadOnlyField), &readOnlyValue)...)
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+	readOnly, err := client.ResolveReadOnly(readOnlyValue.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to configure Databricks client", err.Error())
+		return nil
+	}
+	if readOnly {
+		tflog.Info(ctx, "(plugin framework) Provider is in read-only mode")
+	}
+	common.EnableTransports(cfg, common.TransportOptions{RetryPolicies: policies, ReadOnly: readOnly})
 	databricksClient, err := client.PrepareDatabricksClient(ctx, cfg, p.configCustomizer)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to configure Databricks client", err.Error())
 		return nil
 	}
+	var deletionProtection []struct {
+		ResourceTypes []string `tfsdk:"resource_types"`
+	}
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(common.DeletionProtectionField), &deletionProtection)...)
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+	resourceTypes := []string{}
+	for _, v := range deletionProtection {
+		resourceTypes = append(resourceTypes, v.ResourceTypes...)
+	}
+	if err := databricksClient.SetDeletionProtectedResourceTypes(resourceTypes); err != nil {
+		resp.Diagnostics.AddError("Failed to configure Databricks client", err.Error())
+		return nil
+	}
+	var defaultTags []struct {
+		Tags map[string]string `tfsdk:"tags"`
+	}
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(common.DefaultTagsField), &defaultTags)...)
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+	tags := map[string]string{}
+	for _, v := range defaultTags {
+		maps.Copy(tags, v.Tags)
+	}
+	databricksClient.SetDefaultTags(tags)
+	databricksClient.SetRetryPolicies(policies)
 	return databricksClient
 }
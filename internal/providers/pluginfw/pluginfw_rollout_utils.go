@@ -13,6 +13,8 @@ import (
 	"strings"
 
 	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/functions"
+	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/listresources"
 	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/app"
 	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/catalog"
 	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/cluster"
@@ -21,10 +23,15 @@ import (
 	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/notificationdestinations"
 	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/qualitymonitor"
 	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/registered_model"
+	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/secrets"
 	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/serving"
 	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/sharing"
+	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/tokens"
 	"github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/products/volume"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
@@ -71,6 +78,96 @@ var pluginFwOnlyDataSources = append(
 	autoGeneratedDataSources...,
 )
 
+// List of ephemeral resources, that exist only in the plugin framework.
+// Keep this list sorted.
+var pluginFwOnlyEphemeralResources = []func() ephemeral.EphemeralResource{
+	secrets.EphemeralResourceSecret,
+	tokens.EphemeralResourceOboToken,
+	tokens.EphemeralResourceServicePrincipalSecret,
+	tokens.EphemeralResourceToken,
+}
+
+// List of provider-defined functions. They are available only in the plugin framework.
+// Keep this list sorted.
+var pluginFwFunctions = []func() function.Function{
+	functions.NewParsePairIdFunction,
+	functions.NewParseUcNameFunction,
+	functions.NewQuoteUcIdentifierFunction,
+	functions.NewSparkVersionCompareFunction,
+	functions.NewWorkspaceUrlFunction,
+}
+
+// List of list resources for `terraform query`. They list objects of SDKv2 resources.
+// Keep this list sorted.
+var pluginFwOnlyListResources = []func() list.ListResource{
+	listresources.ListResourceCatalog,
+	listresources.ListResourceCluster,
+	listresources.ListResourceGroup,
+	listresources.ListResourceJob,
+	listresources.ListResourcePipeline,
+	listresources.ListResourceSchema,
+	listresources.ListResourceServicePrincipal,
+	listresources.ListResourceSqlEndpoint,
+	listresources.ListResourceSqlTable,
+	listresources.ListResourceUser,
+	listresources.ListResourceVolume,
+}
+
+// List of resources and data sources that are managed only through the workspace API, so they
+// get the `workspace_id` attribute and could be managed from an account-level provider.
+// Keep this list sorted.
+var workspaceLevelResourcesAndDataSources = []string{
+	"databricks_alert_v2",
+	"databricks_alerts_v2",
+	"databricks_app",
+	"databricks_apps",
+	"databricks_apps_settings_custom_template",
+	"databricks_apps_settings_custom_templates",
+	"databricks_clean_room_asset",
+	"databricks_clean_room_asset_revisions_clean_room_asset",
+	"databricks_clean_room_asset_revisions_clean_room_assets",
+	"databricks_clean_room_assets",
+	"databricks_clean_room_auto_approval_rule",
+	"databricks_clean_room_auto_approval_rules",
+	"databricks_clean_rooms_clean_room",
+	"databricks_clean_rooms_clean_rooms",
+	"databricks_cluster_pluginframework",
+	"databricks_dashboards",
+	"databricks_database_database_catalog",
+	"databricks_database_database_catalogs",
+	"databricks_database_instance",
+	"databricks_database_instances",
+	"databricks_database_synced_database_table",
+	"databricks_database_synced_database_tables",
+	"databricks_entity_tag_assignment",
+	"databricks_entity_tag_assignments",
+	"databricks_external_metadata",
+	"databricks_external_metadatas",
+	"databricks_functions",
+	"databricks_library",
+	"databricks_materialized_features_feature_tag",
+	"databricks_materialized_features_feature_tags",
+	"databricks_notification_destinations",
+	"databricks_online_store",
+	"databricks_online_stores",
+	"databricks_policy_info",
+	"databricks_policy_infos",
+	"databricks_quality_monitor",
+	"databricks_quality_monitor_v2",
+	"databricks_quality_monitors_v2",
+	"databricks_recipient_federation_policies",
+	"databricks_recipient_federation_policy",
+	"databricks_registered_model",
+	"databricks_registered_model_versions",
+	"databricks_serving_endpoints",
+	"databricks_share_pluginframework",
+	"databricks_shares_pluginframework",
+	"databricks_tag_policies",
+	"databricks_tag_policy",
+	"databricks_volumes",
+	"databricks_workspace_setting_v2",
+}
+
 type pluginFrameworkOptions struct {
 	resourceFallbacks   []string
 	dataSourceFallbacks []string
@@ -163,7 +260,15 @@ func getPluginFrameworkResourcesToRegister(resourceFallbacks []string) []func()
 		}
 	}
 
-	return append(resources, pluginFwOnlyResources...)
+	resources = append(resources, pluginFwOnlyResources...)
+	for i, resourceFunc := range resources {
+		name := getResourceName(resourceFunc)
+		resources[i] = withProviderChecksResource(resourceFunc)
+		if slices.Contains(workspaceLevelResourcesAndDataSources, name) {
+			resources[i] = withWorkspaceIdResource(resources[i])
+		}
+	}
+	return resources
 }
 
 // getPluginFrameworkDataSourcesToRegister is a helper function to get the list of data sources that are migrated away from sdkv2 to plugin framework
@@ -178,7 +283,13 @@ func getPluginFrameworkDataSourcesToRegister(dataSourceFallbacks []string) []fun
 		}
 	}
 
-	return append(dataSources, pluginFwOnlyDataSources...)
+	dataSources = append(dataSources, pluginFwOnlyDataSources...)
+	for i, dataSourceFunc := range dataSources {
+		if slices.Contains(workspaceLevelResourcesAndDataSources, getDataSourceName(dataSourceFunc)) {
+			dataSources[i] = withWorkspaceIdDataSource(dataSourceFunc)
+		}
+	}
+	return dataSources
 }
 
 func getResourceName(resourceFunc func() resource.Resource) string {
@@ -193,6 +304,16 @@ func getDataSourceName(dataSourceFunc func() datasource.DataSource) string {
 	return resp.TypeName
 }
 
+// GetPluginFrameworkResourceNames returns names of all resources, that are implemented with the plugin framework,
+// including migrated resources, that are currently served by SDK V2
+func GetPluginFrameworkResourceNames() []string {
+	names := []string{}
+	for _, resourceFunc := range append(slices.Clone(migratedResources), pluginFwOnlyResources...) {
+		names = append(names, getResourceName(resourceFunc))
+	}
+	return names
+}
+
 // GetSdkV2ResourcesToRemove is a helper function to get the list of resources that are migrated away from sdkv2 to plugin framework
 func GetSdkV2ResourcesToRemove(resourceFallbacks []string) []string {
 	resourcesToRemove := []string{}
@@ -0,0 +1,109 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/common"
+	pluginfwcommon "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/common"
+	pluginfwcontext "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/context"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const secretName = "secret"
+
+func EphemeralResourceSecret() ephemeral.EphemeralResource {
+	return &ephemeralSecret{}
+}
+
+type ephemeralSecret struct {
+	client *common.DatabricksClient
+}
+
+type ephemeralSecretModel struct {
+	Scope           types.String `tfsdk:"scope"`
+	Key             types.String `tfsdk:"key"`
+	Value           types.String `tfsdk:"value"`
+	ConfigReference types.String `tfsdk:"config_reference"`
+}
+
+func (r *ephemeralSecret) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = pluginfwcommon.GetDatabricksProductionName(secretName)
+}
+
+func (r *ephemeralSecret) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Value of a secret, that is not persisted in the state.",
+		Attributes: map[string]schema.Attribute{
+			"scope": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the secret scope.",
+			},
+			"key": schema.StringAttribute{
+				Required:    true,
+				Description: "Key of the secret in the scope.",
+			},
+			"value": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Value of the secret.",
+			},
+			"config_reference": schema.StringAttribute{
+				Computed:    true,
+				Description: "Value to use as a secret reference in Spark configuration and environment variables: `{{secrets/scope/key}}`.",
+			},
+		},
+	}
+}
+
+func (r *ephemeralSecret) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if r.client == nil && req.ProviderData != nil {
+		r.client = pluginfwcommon.ConfigureEphemeralResource(req, resp)
+	}
+}
+
+func (r *ephemeralSecret) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx = pluginfwcontext.SetUserAgentInEphemeralResourceContext(ctx, secretName)
+	w, diags := r.client.GetWorkspaceClient()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var secret ephemeralSecretModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &secret)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := readSecret(ctx, w, &secret)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to read secret", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, secret)...)
+}
+
+func readSecret(ctx context.Context, w *databricks.WorkspaceClient, secret *ephemeralSecretModel) error {
+	scope := secret.Scope.ValueString()
+	key := secret.Key.ValueString()
+	res, err := w.Secrets.GetSecret(ctx, workspace.GetSecretRequest{
+		Scope: scope,
+		Key:   key,
+	})
+	if err != nil {
+		return err
+	}
+	value, err := base64.StdEncoding.DecodeString(res.Value)
+	if err != nil {
+		return fmt.Errorf("failed to decode secret value: %w", err)
+	}
+	secret.Value = types.StringValue(string(value))
+	secret.ConfigReference = types.StringValue(fmt.Sprintf("{{secrets/%s/%s}}", scope, key))
+	return nil
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralSecret{}
@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSecret(t *testing.T) {
+	ctx := context.Background()
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockSecretsAPI().EXPECT().GetSecret(ctx, workspace.GetSecretRequest{
+		Scope: "foo",
+		Key:   "bar",
+	}).Return(&workspace.GetSecretResponse{
+		Key:   "bar",
+		Value: "c2VjcmV0",
+	}, nil)
+	secret := ephemeralSecretModel{
+		Scope: types.StringValue("foo"),
+		Key:   types.StringValue("bar"),
+	}
+	require.NoError(t, readSecret(ctx, w.WorkspaceClient, &secret))
+	assert.Equal(t, "secret", secret.Value.ValueString())
+	assert.Equal(t, "{{secrets/foo/bar}}", secret.ConfigReference.ValueString())
+}
+
+func TestReadSecretInvalidValue(t *testing.T) {
+	ctx := context.Background()
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockSecretsAPI().EXPECT().GetSecret(ctx, workspace.GetSecretRequest{
+		Scope: "foo",
+		Key:   "bar",
+	}).Return(&workspace.GetSecretResponse{
+		Value: "!!!",
+	}, nil)
+	secret := ephemeralSecretModel{
+		Scope: types.StringValue("foo"),
+		Key:   types.StringValue("bar"),
+	}
+	assert.ErrorContains(t, readSecret(ctx, w.WorkspaceClient, &secret), "failed to decode secret value")
+}
@@ -0,0 +1,150 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/settings"
+	"github.com/databricks/terraform-provider-databricks/common"
+	pluginfwcommon "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/common"
+	pluginfwcontext "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/context"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const oboTokenName = "obo_token"
+
+func EphemeralResourceOboToken() ephemeral.EphemeralResource {
+	return &ephemeralOboToken{}
+}
+
+type ephemeralOboToken struct {
+	client *common.DatabricksClient
+}
+
+type ephemeralOboTokenModel struct {
+	ApplicationId   types.String `tfsdk:"application_id"`
+	Comment         types.String `tfsdk:"comment"`
+	LifetimeSeconds types.Int64  `tfsdk:"lifetime_seconds"`
+	TokenId         types.String `tfsdk:"token_id"`
+	TokenValue      types.String `tfsdk:"token_value"`
+	ExpiryTime      types.Int64  `tfsdk:"expiry_time"`
+}
+
+func (r *ephemeralOboToken) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = pluginfwcommon.GetDatabricksProductionName(oboTokenName)
+}
+
+func (r *ephemeralOboToken) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "On-behalf-of token for a service principal, that is not persisted in the state. " +
+			"The token is revoked when Terraform no longer needs it.",
+		Attributes: map[string]schema.Attribute{
+			"application_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Application ID of the service principal to create the token for.",
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comment of the token.",
+			},
+			"lifetime_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Lifetime of the token in seconds. Defaults to 3600 seconds.",
+			},
+			"token_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the token.",
+			},
+			"token_value": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Value of the token.",
+			},
+			"expiry_time": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Expiry time of the token in milliseconds since epoch.",
+			},
+		},
+	}
+}
+
+func (r *ephemeralOboToken) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if r.client == nil && req.ProviderData != nil {
+		r.client = pluginfwcommon.ConfigureEphemeralResource(req, resp)
+	}
+}
+
+func (r *ephemeralOboToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx = pluginfwcontext.SetUserAgentInEphemeralResourceContext(ctx, oboTokenName)
+	w, diags := r.client.GetWorkspaceClient()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var token ephemeralOboTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := openOboToken(ctx, w, &token)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to create obo token", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setPrivateToken(ctx, resp.Private, privateToken{TokenId: token.TokenId.ValueString()})...)
+}
+
+func (r *ephemeralOboToken) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	ctx = pluginfwcontext.SetUserAgentInEphemeralResourceContext(ctx, oboTokenName)
+	token, diags := getPrivateToken(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || token == nil {
+		return
+	}
+	w, diags := r.client.GetWorkspaceClient()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := closeOboToken(ctx, w, *token)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to revoke obo token", err.Error())
+	}
+}
+
+func openOboToken(ctx context.Context, w *databricks.WorkspaceClient, token *ephemeralOboTokenModel) error {
+	res, err := w.TokenManagement.CreateOboToken(ctx, settings.CreateOboTokenRequest{
+		ApplicationId:   token.ApplicationId.ValueString(),
+		Comment:         token.Comment.ValueString(),
+		LifetimeSeconds: lifetimeSeconds(token.LifetimeSeconds),
+	})
+	if err != nil {
+		return err
+	}
+	if res.TokenInfo == nil {
+		return fmt.Errorf("token info is missing in the response")
+	}
+	token.TokenId = types.StringValue(res.TokenInfo.TokenId)
+	token.TokenValue = types.StringValue(res.TokenValue)
+	token.ExpiryTime = types.Int64Value(res.TokenInfo.ExpiryTime)
+	return nil
+}
+
+func closeOboToken(ctx context.Context, w *databricks.WorkspaceClient, token privateToken) error {
+	err := w.TokenManagement.DeleteByTokenId(ctx, token.TokenId)
+	if apierr.IsMissing(err) {
+		return nil
+	}
+	return err
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralOboToken{}
+var _ ephemeral.EphemeralResourceWithClose = &ephemeralOboToken{}
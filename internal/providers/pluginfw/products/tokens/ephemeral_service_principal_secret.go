@@ -0,0 +1,159 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/oauth2"
+	"github.com/databricks/terraform-provider-databricks/common"
+	pluginfwcommon "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/common"
+	pluginfwcontext "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/context"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const servicePrincipalSecretName = "service_principal_secret"
+
+func EphemeralResourceServicePrincipalSecret() ephemeral.EphemeralResource {
+	return &ephemeralServicePrincipalSecret{}
+}
+
+type ephemeralServicePrincipalSecret struct {
+	client *common.DatabricksClient
+}
+
+type ephemeralServicePrincipalSecretModel struct {
+	ServicePrincipalId types.String `tfsdk:"service_principal_id"`
+	Lifetime           types.String `tfsdk:"lifetime"`
+	Id                 types.String `tfsdk:"id"`
+	Secret             types.String `tfsdk:"secret"`
+	SecretHash         types.String `tfsdk:"secret_hash"`
+	ExpireTime         types.String `tfsdk:"expire_time"`
+}
+
+func (r *ephemeralServicePrincipalSecret) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = pluginfwcommon.GetDatabricksProductionName(servicePrincipalSecretName)
+}
+
+func (r *ephemeralServicePrincipalSecret) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "OAuth secret of a service principal, that is not persisted in the state. " +
+			"The secret is deleted when Terraform no longer needs it.",
+		Attributes: map[string]schema.Attribute{
+			"service_principal_id": schema.StringAttribute{
+				Required:    true,
+				Description: "SCIM ID of the service principal to create the secret for.",
+			},
+			"lifetime": schema.StringAttribute{
+				Optional:    true,
+				Description: "Lifetime of the secret in seconds, i.e. `3600s`. Defaults to `3600s`.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the secret.",
+			},
+			"secret": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Value of the secret.",
+			},
+			"secret_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "Secret hash.",
+			},
+			"expire_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "Expiry time of the secret in RFC3339 format.",
+			},
+		},
+	}
+}
+
+func (r *ephemeralServicePrincipalSecret) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if r.client == nil && req.ProviderData != nil {
+		r.client = pluginfwcommon.ConfigureEphemeralResource(req, resp)
+	}
+}
+
+func (r *ephemeralServicePrincipalSecret) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx = pluginfwcontext.SetUserAgentInEphemeralResourceContext(ctx, servicePrincipalSecretName)
+	var secret ephemeralServicePrincipalSecretModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &secret)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := openServicePrincipalSecret(ctx, r.client, &secret)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to create service principal secret", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, secret)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setPrivateToken(ctx, resp.Private, privateToken{
+		TokenId:            secret.Id.ValueString(),
+		ServicePrincipalId: secret.ServicePrincipalId.ValueString(),
+	})...)
+}
+
+func (r *ephemeralServicePrincipalSecret) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	ctx = pluginfwcontext.SetUserAgentInEphemeralResourceContext(ctx, servicePrincipalSecretName)
+	token, diags := getPrivateToken(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || token == nil {
+		return
+	}
+	err := closeServicePrincipalSecret(ctx, r.client, *token)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to delete service principal secret", err.Error())
+	}
+}
+
+// openServicePrincipalSecret creates the secret with the account-level or the workspace-level API
+func openServicePrincipalSecret(ctx context.Context, c *common.DatabricksClient, secret *ephemeralServicePrincipalSecretModel) error {
+	createRequest := oauth2.CreateServicePrincipalSecretRequest{
+		ServicePrincipalId: secret.ServicePrincipalId.ValueString(),
+		Lifetime:           secret.Lifetime.ValueString(),
+	}
+	if createRequest.Lifetime == "" {
+		createRequest.Lifetime = fmt.Sprintf("%ds", defaultLifetimeSeconds)
+	}
+	var res *oauth2.CreateServicePrincipalSecretResponse
+	err := c.AccountOrWorkspaceRequest(func(acc *databricks.AccountClient) error {
+		var err error
+		res, err = acc.ServicePrincipalSecrets.Create(ctx, createRequest)
+		return err
+	}, func(w *databricks.WorkspaceClient) error {
+		var err error
+		res, err = w.ServicePrincipalSecretsProxy.Create(ctx, createRequest)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	secret.Id = types.StringValue(res.Id)
+	secret.Secret = types.StringValue(res.Secret)
+	secret.SecretHash = types.StringValue(res.SecretHash)
+	secret.ExpireTime = types.StringValue(res.ExpireTime)
+	return nil
+}
+
+// closeServicePrincipalSecret deletes the secret, that was created in openServicePrincipalSecret
+func closeServicePrincipalSecret(ctx context.Context, c *common.DatabricksClient, token privateToken) error {
+	deleteRequest := oauth2.DeleteServicePrincipalSecretRequest{
+		ServicePrincipalId: token.ServicePrincipalId,
+		SecretId:           token.TokenId,
+	}
+	err := c.AccountOrWorkspaceRequest(func(acc *databricks.AccountClient) error {
+		return acc.ServicePrincipalSecrets.Delete(ctx, deleteRequest)
+	}, func(w *databricks.WorkspaceClient) error {
+		return w.ServicePrincipalSecretsProxy.Delete(ctx, deleteRequest)
+	})
+	return common.IgnoreNotFoundError(err)
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralServicePrincipalSecret{}
+var _ ephemeral.EphemeralResourceWithClose = &ephemeralServicePrincipalSecret{}
@@ -0,0 +1,81 @@
+package tokens
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/oauth2"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAndCloseServicePrincipalSecret_Workspace(t *testing.T) {
+	qa.MockWorkspaceApply(t, func(w *mocks.MockWorkspaceClient) {
+		e := w.GetMockServicePrincipalSecretsProxyAPI().EXPECT()
+		e.Create(context.Background(), oauth2.CreateServicePrincipalSecretRequest{
+			ServicePrincipalId: "123",
+			Lifetime:           "3600s",
+		}).Return(&oauth2.CreateServicePrincipalSecretResponse{
+			Id:         "abc",
+			Secret:     "dose123",
+			SecretHash: "hash",
+			ExpireTime: "2026-10-19T15:00:00Z",
+		}, nil)
+		e.Delete(context.Background(), oauth2.DeleteServicePrincipalSecretRequest{
+			ServicePrincipalId: "123",
+			SecretId:           "abc",
+		}).Return(nil)
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		secret := ephemeralServicePrincipalSecretModel{
+			ServicePrincipalId: types.StringValue("123"),
+		}
+		require.NoError(t, openServicePrincipalSecret(ctx, client, &secret))
+		assert.Equal(t, "abc", secret.Id.ValueString())
+		assert.Equal(t, "dose123", secret.Secret.ValueString())
+		assert.Equal(t, "hash", secret.SecretHash.ValueString())
+		assert.Equal(t, "2026-10-19T15:00:00Z", secret.ExpireTime.ValueString())
+		require.NoError(t, closeServicePrincipalSecret(ctx, client, privateToken{
+			TokenId:            "abc",
+			ServicePrincipalId: "123",
+		}))
+	})
+}
+
+func TestOpenAndCloseServicePrincipalSecret_Account(t *testing.T) {
+	qa.MockAccountsApply(t, func(a *mocks.MockAccountClient) {
+		e := a.GetMockServicePrincipalSecretsAPI().EXPECT()
+		e.Create(context.Background(), oauth2.CreateServicePrincipalSecretRequest{
+			ServicePrincipalId: "123",
+			Lifetime:           "60s",
+		}).Return(&oauth2.CreateServicePrincipalSecretResponse{
+			Id:     "abc",
+			Secret: "dose123",
+		}, nil)
+		e.Delete(context.Background(), oauth2.DeleteServicePrincipalSecretRequest{
+			ServicePrincipalId: "123",
+			SecretId:           "abc",
+		}).Return(&apierr.APIError{
+			StatusCode: 404,
+			ErrorCode:  "NOT_FOUND",
+			Message:    "Secret abc does not exist",
+		})
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.Config.WithTesting().AccountID = "00000000-0000-0000-0000-000000000001"
+		secret := ephemeralServicePrincipalSecretModel{
+			ServicePrincipalId: types.StringValue("123"),
+			Lifetime:           types.StringValue("60s"),
+		}
+		require.NoError(t, openServicePrincipalSecret(ctx, client, &secret))
+		assert.Equal(t, "abc", secret.Id.ValueString())
+		// already deleted secret isn't an error
+		require.NoError(t, closeServicePrincipalSecret(ctx, client, privateToken{
+			TokenId:            "abc",
+			ServicePrincipalId: "123",
+		}))
+	})
+}
@@ -0,0 +1,224 @@
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/settings"
+	"github.com/databricks/terraform-provider-databricks/common"
+	pluginfwcommon "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/common"
+	pluginfwcontext "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const tokenName = "token"
+
+const (
+	tokenTypePat   = "pat"
+	tokenTypeOAuth = "oauth"
+)
+
+// defaultLifetimeSeconds bounds the lifetime of tokens and secrets, if it isn't configured, so that they
+// expire even if Terraform can't revoke them in Close
+const defaultLifetimeSeconds = 3600
+
+// lifetimeSeconds returns the configured lifetime, or the default one
+func lifetimeSeconds(v types.Int64) int64 {
+	if v.IsNull() || v.IsUnknown() {
+		return defaultLifetimeSeconds
+	}
+	return v.ValueInt64()
+}
+
+// privateTokenKey is the key of the private state, where the ID of the token to revoke in Close is stored
+const privateTokenKey = "token"
+
+func EphemeralResourceToken() ephemeral.EphemeralResource {
+	return &ephemeralToken{}
+}
+
+type ephemeralToken struct {
+	client *common.DatabricksClient
+}
+
+type ephemeralTokenModel struct {
+	Type            types.String `tfsdk:"type"`
+	Comment         types.String `tfsdk:"comment"`
+	LifetimeSeconds types.Int64  `tfsdk:"lifetime_seconds"`
+	TokenId         types.String `tfsdk:"token_id"`
+	TokenValue      types.String `tfsdk:"token_value"`
+	ExpiryTime      types.Int64  `tfsdk:"expiry_time"`
+}
+
+// privateStateSetter is implemented by the private state of OpenResponse
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// privateStateGetter is implemented by the private state of CloseRequest
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateToken is stored in the private state of ephemeral tokens and credentials, that have to be revoked in Close
+type privateToken struct {
+	TokenId            string `json:"token_id,omitempty"`
+	ServicePrincipalId string `json:"service_principal_id,omitempty"`
+}
+
+func (r *ephemeralToken) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = pluginfwcommon.GetDatabricksProductionName(tokenName)
+}
+
+func (r *ephemeralToken) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Short-lived personal access token or OAuth token, that is not persisted in the state. " +
+			"Personal access tokens are revoked when Terraform no longer needs them.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Type of the token: `pat` (default) creates a new personal access token, `oauth` returns an OAuth access token of the current identity.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tokenTypePat, tokenTypeOAuth),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comment of the personal access token.",
+			},
+			"lifetime_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Lifetime of the personal access token in seconds. Defaults to 3600 seconds.",
+			},
+			"token_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the personal access token.",
+			},
+			"token_value": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Value of the token.",
+			},
+			"expiry_time": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Expiry time of the token in milliseconds since epoch.",
+			},
+		},
+	}
+}
+
+func (r *ephemeralToken) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if r.client == nil && req.ProviderData != nil {
+		r.client = pluginfwcommon.ConfigureEphemeralResource(req, resp)
+	}
+}
+
+func (r *ephemeralToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx = pluginfwcontext.SetUserAgentInEphemeralResourceContext(ctx, tokenName)
+	w, diags := r.client.GetWorkspaceClient()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var token ephemeralTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := openToken(ctx, w, &token)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to create token", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, token)...)
+	if resp.Diagnostics.HasError() || token.Type.ValueString() == tokenTypeOAuth {
+		return
+	}
+	resp.Diagnostics.Append(setPrivateToken(ctx, resp.Private, privateToken{TokenId: token.TokenId.ValueString()})...)
+}
+
+func (r *ephemeralToken) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	ctx = pluginfwcontext.SetUserAgentInEphemeralResourceContext(ctx, tokenName)
+	token, diags := getPrivateToken(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || token == nil {
+		return
+	}
+	w, diags := r.client.GetWorkspaceClient()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := closeToken(ctx, w, *token)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to revoke token", err.Error())
+	}
+}
+
+// openToken creates a personal access token or gets an OAuth token, depending on the type
+func openToken(ctx context.Context, w *databricks.WorkspaceClient, token *ephemeralTokenModel) error {
+	if token.Type.ValueString() == tokenTypeOAuth {
+		oauthToken, err := w.Config.GetTokenSource().Token(ctx)
+		if err != nil {
+			return err
+		}
+		token.TokenId = types.StringNull()
+		token.TokenValue = types.StringValue(oauthToken.AccessToken)
+		token.ExpiryTime = types.Int64Value(oauthToken.Expiry.UnixMilli())
+		return nil
+	}
+	res, err := w.Tokens.Create(ctx, settings.CreateTokenRequest{
+		Comment:         token.Comment.ValueString(),
+		LifetimeSeconds: lifetimeSeconds(token.LifetimeSeconds),
+	})
+	if err != nil {
+		return err
+	}
+	if res.TokenInfo == nil {
+		return fmt.Errorf("token info is missing in the response")
+	}
+	token.TokenId = types.StringValue(res.TokenInfo.TokenId)
+	token.TokenValue = types.StringValue(res.TokenValue)
+	token.ExpiryTime = types.Int64Value(res.TokenInfo.ExpiryTime)
+	return nil
+}
+
+// closeToken revokes the personal access token, that was created in openToken
+func closeToken(ctx context.Context, w *databricks.WorkspaceClient, token privateToken) error {
+	err := w.Tokens.DeleteByTokenId(ctx, token.TokenId)
+	if apierr.IsMissing(err) {
+		return nil
+	}
+	return err
+}
+
+func setPrivateToken(ctx context.Context, private privateStateSetter, token privateToken) diag.Diagnostics {
+	value, err := json.Marshal(token)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("failed to save private state", err.Error())}
+	}
+	return private.SetKey(ctx, privateTokenKey, value)
+}
+
+func getPrivateToken(ctx context.Context, private privateStateGetter) (*privateToken, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, privateTokenKey)
+	if diags.HasError() || value == nil {
+		return nil, diags
+	}
+	var token privateToken
+	if err := json.Unmarshal(value, &token); err != nil {
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("failed to read private state", err.Error())}
+	}
+	return &token, nil
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralToken{}
+var _ ephemeral.EphemeralResourceWithClose = &ephemeralToken{}
@@ -0,0 +1,126 @@
+package tokens
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/settings"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func (p fakePrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func TestOpenAndCloseToken(t *testing.T) {
+	ctx := context.Background()
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockTokensAPI().EXPECT().Create(ctx, settings.CreateTokenRequest{
+		Comment:         "ephemeral",
+		LifetimeSeconds: 3600,
+	}).Return(&settings.CreateTokenResponse{
+		TokenInfo: &settings.PublicTokenInfo{
+			TokenId:    "abc",
+			ExpiryTime: 1234,
+		},
+		TokenValue: "dapi123",
+	}, nil)
+	w.GetMockTokensAPI().EXPECT().DeleteByTokenId(ctx, "abc").Return(nil)
+
+	token := ephemeralTokenModel{
+		Comment:         types.StringValue("ephemeral"),
+		LifetimeSeconds: types.Int64Value(3600),
+	}
+	require.NoError(t, openToken(ctx, w.WorkspaceClient, &token))
+	assert.Equal(t, "abc", token.TokenId.ValueString())
+	assert.Equal(t, "dapi123", token.TokenValue.ValueString())
+	assert.Equal(t, int64(1234), token.ExpiryTime.ValueInt64())
+
+	private := fakePrivateState{}
+	diags := setPrivateToken(ctx, private, privateToken{TokenId: token.TokenId.ValueString()})
+	require.False(t, diags.HasError())
+	saved, diags := getPrivateToken(ctx, private)
+	require.False(t, diags.HasError())
+	require.NotNil(t, saved)
+	require.NoError(t, closeToken(ctx, w.WorkspaceClient, *saved))
+}
+
+func TestCloseTokenAlreadyDeleted(t *testing.T) {
+	ctx := context.Background()
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockTokensAPI().EXPECT().DeleteByTokenId(ctx, "abc").Return(apierr.ErrNotFound)
+	assert.NoError(t, closeToken(ctx, w.WorkspaceClient, privateToken{TokenId: "abc"}))
+}
+
+func TestGetPrivateTokenMissing(t *testing.T) {
+	token, diags := getPrivateToken(context.Background(), fakePrivateState{})
+	assert.False(t, diags.HasError())
+	assert.Nil(t, token)
+}
+
+func TestOpenAndCloseOboToken(t *testing.T) {
+	ctx := context.Background()
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockTokenManagementAPI().EXPECT().CreateOboToken(ctx, settings.CreateOboTokenRequest{
+		ApplicationId:   "app-id",
+		LifetimeSeconds: 60,
+	}).Return(&settings.CreateOboTokenResponse{
+		TokenInfo: &settings.TokenInfo{
+			TokenId:    "abc",
+			ExpiryTime: 1234,
+		},
+		TokenValue: "dapi123",
+	}, nil)
+	w.GetMockTokenManagementAPI().EXPECT().DeleteByTokenId(ctx, "abc").Return(nil)
+
+	token := ephemeralOboTokenModel{
+		ApplicationId:   types.StringValue("app-id"),
+		LifetimeSeconds: types.Int64Value(60),
+	}
+	require.NoError(t, openOboToken(ctx, w.WorkspaceClient, &token))
+	assert.Equal(t, "abc", token.TokenId.ValueString())
+	assert.Equal(t, "dapi123", token.TokenValue.ValueString())
+	require.NoError(t, closeOboToken(ctx, w.WorkspaceClient, privateToken{TokenId: "abc"}))
+}
+
+func TestOpenTokenDefaultLifetime(t *testing.T) {
+	ctx := context.Background()
+	w := mocks.NewMockWorkspaceClient(t)
+	w.GetMockTokensAPI().EXPECT().Create(ctx, settings.CreateTokenRequest{
+		LifetimeSeconds: 3600,
+	}).Return(&settings.CreateTokenResponse{
+		TokenInfo: &settings.PublicTokenInfo{
+			TokenId: "abc",
+		},
+	}, nil)
+	w.GetMockTokenManagementAPI().EXPECT().CreateOboToken(ctx, settings.CreateOboTokenRequest{
+		ApplicationId:   "app-id",
+		LifetimeSeconds: 3600,
+	}).Return(&settings.CreateOboTokenResponse{
+		TokenInfo: &settings.TokenInfo{
+			TokenId: "def",
+		},
+	}, nil)
+
+	token := ephemeralTokenModel{
+		LifetimeSeconds: types.Int64Null(),
+	}
+	require.NoError(t, openToken(ctx, w.WorkspaceClient, &token))
+	oboToken := ephemeralOboTokenModel{
+		ApplicationId:   types.StringValue("app-id"),
+		LifetimeSeconds: types.Int64Null(),
+	}
+	require.NoError(t, openOboToken(ctx, w.WorkspaceClient, &oboToken))
+}
@@ -0,0 +1,169 @@
+package pluginfw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	pluginfwcommon "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/common"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// resourceWithProviderChecks wraps a resource and fails create, update and delete before any request is made,
+// if the provider is configured with `read_only = true`. Delete also fails, if the resource type is listed in
+// `deletion_protection.resource_types` of the provider configuration. All operations are recorded as tracing spans.
+type resourceWithProviderChecks struct {
+	resource.Resource
+	client *common.DatabricksClient
+}
+
+var _ resource.ResourceWithConfigure = &resourceWithProviderChecks{}
+var _ resource.ResourceWithImportState = &resourceWithProviderChecks{}
+var _ resource.ResourceWithModifyPlan = &resourceWithProviderChecks{}
+var _ resource.ResourceWithValidateConfig = &resourceWithProviderChecks{}
+var _ resource.ResourceWithConfigValidators = &resourceWithProviderChecks{}
+var _ resource.ResourceWithUpgradeState = &resourceWithProviderChecks{}
+var _ resource.ResourceWithMoveState = &resourceWithProviderChecks{}
+
+func withProviderChecksResource(resourceFunc func() resource.Resource) func() resource.Resource {
+	return func() resource.Resource {
+		return &resourceWithProviderChecks{Resource: resourceFunc()}
+	}
+}
+
+func (r *resourceWithProviderChecks) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = pluginfwcommon.ConfigureResource(req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if inner, ok := r.Resource.(resource.ResourceWithConfigure); ok {
+		inner.Configure(ctx, req, resp)
+	}
+}
+
+func (r *resourceWithProviderChecks) readOnlyDiagnostics(action string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if r.client.IsReadOnly() {
+		diags.AddError("Cannot "+action+" the resource in read-only mode", common.ErrReadOnly.Error())
+	}
+	return diags
+}
+
+func (r *resourceWithProviderChecks) typeName(ctx context.Context) string {
+	metadata := resource.MetadataResponse{}
+	r.Resource.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "databricks"}, &metadata)
+	return metadata.TypeName
+}
+
+// startSpan starts a span for the operation, that has to be ended with endSpan
+func (r *resourceWithProviderChecks) startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return common.StartResourceSpan(ctx, r.typeName(ctx), operation)
+}
+
+func endSpan(span trace.Span, diags diag.Diagnostics) {
+	var err error
+	if errs := diags.Errors(); len(errs) > 0 {
+		err = errors.New(errs[0].Summary())
+	}
+	common.EndSpan(span, err)
+}
+
+func (r *resourceWithProviderChecks) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := r.startSpan(ctx, "create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+	resp.Diagnostics.Append(r.readOnlyDiagnostics("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.Resource.Create(ctx, req, resp)
+}
+
+func (r *resourceWithProviderChecks) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := r.startSpan(ctx, "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+	r.Resource.Read(ctx, req, resp)
+}
+
+func (r *resourceWithProviderChecks) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := r.startSpan(ctx, "update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+	resp.Diagnostics.Append(r.readOnlyDiagnostics("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.Resource.Update(ctx, req, resp)
+}
+
+// deletionProtectionDiagnostics returns an error, if the resource type is protected from deletion. Resources of
+// the plugin framework don't have the `deletion_protection` attribute, so the protection can only be removed
+// from the provider configuration.
+func (r *resourceWithProviderChecks) deletionProtectionDiagnostics(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+	typeName := r.typeName(ctx)
+	if r.client.IsDeletionProtected(typeName) {
+		diags.AddError("Cannot delete the protected resource",
+			fmt.Sprintf("%s is protected from deletion. Remove it from %s.%s of the provider configuration "+
+				"to delete it", typeName,
+				common.DeletionProtectionField, common.DeletionProtectionResourceTypesField))
+	}
+	return diags
+}
+
+func (r *resourceWithProviderChecks) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := r.startSpan(ctx, "delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+	resp.Diagnostics.Append(r.readOnlyDiagnostics("delete")...)
+	resp.Diagnostics.Append(r.deletionProtectionDiagnostics(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.Resource.Delete(ctx, req, resp)
+}
+
+func (r *resourceWithProviderChecks) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importer, ok := r.Resource.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError("Resource Import Not Implemented",
+			"This resource does not support import. Please contact the provider developer for additional information.")
+		return
+	}
+	importer.ImportState(ctx, req, resp)
+}
+
+// Optional interfaces of the wrapped resource are hidden by the embedding, so they have to be forwarded explicitly.
+
+func (r *resourceWithProviderChecks) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if inner, ok := r.Resource.(resource.ResourceWithModifyPlan); ok {
+		inner.ModifyPlan(ctx, req, resp)
+	}
+}
+
+func (r *resourceWithProviderChecks) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if inner, ok := r.Resource.(resource.ResourceWithValidateConfig); ok {
+		inner.ValidateConfig(ctx, req, resp)
+	}
+}
+
+func (r *resourceWithProviderChecks) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	if inner, ok := r.Resource.(resource.ResourceWithConfigValidators); ok {
+		return inner.ConfigValidators(ctx)
+	}
+	return nil
+}
+
+func (r *resourceWithProviderChecks) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	if inner, ok := r.Resource.(resource.ResourceWithUpgradeState); ok {
+		return inner.UpgradeState(ctx)
+	}
+	return nil
+}
+
+func (r *resourceWithProviderChecks) MoveState(ctx context.Context) []resource.StateMover {
+	if inner, ok := r.Resource.(resource.ResourceWithMoveState); ok {
+		return inner.MoveState(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package pluginfw
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func readOnlyClient() *common.DatabricksClient {
+	cfg := &config.Config{}
+	common.EnableTransports(cfg, common.TransportOptions{ReadOnly: true})
+	return &common.DatabricksClient{
+		DatabricksClient: &client.DatabricksClient{Config: cfg},
+	}
+}
+
+func createFakeResource(t *testing.T, c *common.DatabricksClient) resource.CreateResponse {
+	ctx := context.Background()
+	r := withProviderChecksResource(func() resource.Resource { return &fakeResource{} })()
+	configureResp := resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: c}, &configureResp)
+	require.False(t, configureResp.Diagnostics.HasError(), "%v", configureResp.Diagnostics)
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+	plan := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "foo"),
+		"value": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	resp := resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(ctx, resource.CreateRequest{
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan},
+	}, &resp)
+	return resp
+}
+
+func TestResourceWithProviderChecks_Create(t *testing.T) {
+	resp := createFakeResource(t, &common.DatabricksClient{
+		DatabricksClient: &client.DatabricksClient{Config: &config.Config{}},
+	})
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	var value types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("value"), &value)...)
+	assert.Equal(t, "created foo", value.ValueString())
+}
+
+func TestResourceWithProviderChecks_CreateInReadOnlyMode(t *testing.T) {
+	resp := createFakeResource(t, readOnlyClient())
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Cannot create the resource in read-only mode", resp.Diagnostics[0].Summary())
+	assert.True(t, resp.State.Raw.IsNull())
+}
+
+func TestResourceWithProviderChecks_DeleteInReadOnlyMode(t *testing.T) {
+	r := &resourceWithProviderChecks{Resource: &fakeResource{}, client: readOnlyClient()}
+	resp := resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{}, &resp)
+	assert.True(t, resp.Diagnostics.HasError())
+}
+
+func TestResourceWithProviderChecks_DeleteProtected(t *testing.T) {
+	c := &common.DatabricksClient{
+		DatabricksClient: &client.DatabricksClient{Config: &config.Config{}},
+	}
+	require.NoError(t, c.SetDeletionProtectedResourceTypes([]string{"databricks_fake"}))
+	r := &resourceWithProviderChecks{Resource: &fakeResource{}, client: c}
+	resp := resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{}, &resp)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Cannot delete the protected resource", resp.Diagnostics[0].Summary())
+
+	require.NoError(t, c.SetDeletionProtectedResourceTypes([]string{"databricks_app"}))
+	resp = resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+}
+
+func TestResourceWithProviderChecks_Spans(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	createFakeResource(t, readOnlyClient())
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "create databricks_fake", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "Cannot create the resource in read-only mode", spans[0].Status().Description)
+}
+
+type fakeResourceWithModifyPlan struct {
+	fakeResource
+}
+
+func (r *fakeResourceWithModifyPlan) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.AddWarning("plan modified", "")
+}
+
+func TestResourceWithProviderChecks_ModifyPlan(t *testing.T) {
+	r := withProviderChecksResource(func() resource.Resource { return &fakeResourceWithModifyPlan{} })()
+	resp := resource.ModifyPlanResponse{}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(context.Background(), resource.ModifyPlanRequest{}, &resp)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "plan modified", resp.Diagnostics[0].Summary())
+}
+
+// optionalResourceInterfaces are the optional interfaces of the plugin framework, that the framework
+// detects with type assertions on the registered resource.
+var optionalResourceInterfaces = []reflect.Type{
+	reflect.TypeOf((*resource.ResourceWithConfigure)(nil)).Elem(),
+	reflect.TypeOf((*resource.ResourceWithConfigValidators)(nil)).Elem(),
+	reflect.TypeOf((*resource.ResourceWithImportState)(nil)).Elem(),
+	reflect.TypeOf((*resource.ResourceWithModifyPlan)(nil)).Elem(),
+	reflect.TypeOf((*resource.ResourceWithMoveState)(nil)).Elem(),
+	reflect.TypeOf((*resource.ResourceWithUpgradeState)(nil)).Elem(),
+	reflect.TypeOf((*resource.ResourceWithValidateConfig)(nil)).Elem(),
+	reflect.TypeOf((*resource.ResourceWithIdentity)(nil)).Elem(),
+	reflect.TypeOf((*resource.ResourceWithUpgradeIdentity)(nil)).Elem(),
+}
+
+// wrappedResources returns the resource with all resources wrapped by it
+func wrappedResources(r resource.Resource) []resource.Resource {
+	resources := []resource.Resource{r}
+	for {
+		switch w := r.(type) {
+		case *resourceWithProviderChecks:
+			r = w.Resource
+		case *resourceWithWorkspaceId:
+			r = w.Resource
+		default:
+			return resources
+		}
+		resources = append(resources, r)
+	}
+}
+
+// TestWrappedResourcesForwardOptionalInterfaces fails, if a registered resource implements an optional
+// interface of the plugin framework, that isn't forwarded by one of the wrappers around it.
+func TestWrappedResourcesForwardOptionalInterfaces(t *testing.T) {
+	for _, resourceFunc := range getPluginFrameworkResourcesToRegister(nil) {
+		resources := wrappedResources(resourceFunc())
+		for _, iface := range optionalResourceInterfaces {
+			inner := resources[len(resources)-1]
+			if !reflect.TypeOf(inner).Implements(iface) {
+				continue
+			}
+			for _, wrapper := range resources[:len(resources)-1] {
+				assert.True(t, reflect.TypeOf(wrapper).Implements(iface),
+					"%T wraps %s, but doesn't forward %s", wrapper, getResourceName(resourceFunc), iface.Name())
+			}
+		}
+	}
+}
@@ -23,6 +23,10 @@ type AttributeBuilder interface {
 	// SetSensitive sets the attribute as sensitive in the schema. It fails if the attribute is already sensitive.
 	SetSensitive() AttributeBuilder
 
+	// SetWriteOnly sets the attribute as write-only in the resource schema, so that its value is never persisted
+	// in the plan or state. It fails if the attribute is already write-only. Data source attributes can't be write-only.
+	SetWriteOnly() AttributeBuilder
+
 	// SetComputed sets the attribute as computed in the schema. It fails if the attribute is already computed.
 	SetComputed() AttributeBuilder
 
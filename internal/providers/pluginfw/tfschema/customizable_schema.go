@@ -161,6 +161,20 @@ func (s *CustomizableSchema) SetSensitive(path ...string) *CustomizableSchema {
 	return s
 }
 
+func (s *CustomizableSchema) SetWriteOnly(path ...string) *CustomizableSchema {
+	cb := func(attr BaseSchemaBuilder) BaseSchemaBuilder {
+		switch a := attr.(type) {
+		case AttributeBuilder:
+			return a.SetWriteOnly()
+		default:
+			panic(fmt.Errorf("SetWriteOnly called on invalid attribute type: %s. %s", reflect.TypeOf(attr).String(), common.TerraformBugErrorMessage))
+		}
+	}
+
+	navigateSchemaWithCallback(&s.attr, cb, path...)
+	return s
+}
+
 func (s *CustomizableSchema) SetDeprecated(msg string, path ...string) *CustomizableSchema {
 	cb := func(attr BaseSchemaBuilder) BaseSchemaBuilder {
 		return attr.SetDeprecated(msg)
@@ -103,6 +103,15 @@ func TestCustomizeSchemaSetSensitive(t *testing.T) {
 	assert.True(t, scm.Attributes["nested"].(schema.ListNestedAttribute).NestedObject.Attributes["name"].IsSensitive())
 }
 
+func TestCustomizeSchemaSetWriteOnly(t *testing.T) {
+	scm := ResourceStructToSchema(context.Background(), TestTfSdk{}, func(c CustomizableSchema) CustomizableSchema {
+		c.SetWriteOnly("nested", "name")
+		return c
+	})
+
+	assert.True(t, scm.Attributes["nested"].(schema.ListNestedAttribute).NestedObject.Attributes["name"].IsWriteOnly())
+}
+
 func TestCustomizeSchemaSetDeprecated(t *testing.T) {
 	scm := ResourceStructToSchema(context.Background(), TestTfSdk{}, func(c CustomizableSchema) CustomizableSchema {
 		c.SetDeprecated("deprecated", "map")
@@ -200,6 +209,16 @@ func TestCustomizeSchema_SetSensitive_PanicOnBlock(t *testing.T) {
 	})
 }
 
+func TestCustomizeSchema_SetWriteOnly_PanicOnBlock(t *testing.T) {
+	assert.Panics(t, func() {
+		_ = ResourceStructToSchema(context.Background(), TestTfSdk{}, func(c CustomizableSchema) CustomizableSchema {
+			c.ConfigureAsSdkV2Compatible()
+			c.SetWriteOnly("nested")
+			return c
+		})
+	})
+}
+
 func TestCustomizeSchema_SetReadOnly_PanicOnBlock(t *testing.T) {
 	assert.Panics(t, func() {
 		_ = ResourceStructToSchema(context.Background(), TestTfSdk{}, func(c CustomizableSchema) CustomizableSchema {
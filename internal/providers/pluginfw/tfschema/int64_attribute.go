@@ -12,6 +12,7 @@ type Int64AttributeBuilder struct {
 	Optional           bool
 	Required           bool
 	Sensitive          bool
+	WriteOnly          bool
 	Computed           bool
 	DeprecationMessage string
 	Validators         []validator.Int64
@@ -34,6 +35,7 @@ func (a Int64AttributeBuilder) BuildResourceAttribute() schema.Attribute {
 		Optional:           a.Optional,
 		Required:           a.Required,
 		Sensitive:          a.Sensitive,
+		WriteOnly:          a.WriteOnly,
 		DeprecationMessage: a.DeprecationMessage,
 		Computed:           a.Computed,
 		Validators:         a.Validators,
@@ -67,6 +69,14 @@ func (a Int64AttributeBuilder) SetSensitive() AttributeBuilder {
 	return a
 }
 
+func (a Int64AttributeBuilder) SetWriteOnly() AttributeBuilder {
+	if a.WriteOnly {
+		panic("attribute is already write-only")
+	}
+	a.WriteOnly = true
+	return a
+}
+
 func (a Int64AttributeBuilder) SetComputed() AttributeBuilder {
 	if a.Computed {
 		panic("attribute is already computed")
@@ -15,6 +15,7 @@ type ListAttributeBuilder struct {
 	Optional           bool
 	Required           bool
 	Sensitive          bool
+	WriteOnly          bool
 	Computed           bool
 	DeprecationMessage string
 	Validators         []validator.List
@@ -39,6 +40,7 @@ func (a ListAttributeBuilder) BuildResourceAttribute() schema.Attribute {
 		Optional:           a.Optional,
 		Required:           a.Required,
 		Sensitive:          a.Sensitive,
+		WriteOnly:          a.WriteOnly,
 		DeprecationMessage: a.DeprecationMessage,
 		Computed:           a.Computed,
 		Validators:         a.Validators,
@@ -72,6 +74,14 @@ func (a ListAttributeBuilder) SetSensitive() AttributeBuilder {
 	return a
 }
 
+func (a ListAttributeBuilder) SetWriteOnly() AttributeBuilder {
+	if a.WriteOnly {
+		panic("attribute is already write-only")
+	}
+	a.WriteOnly = true
+	return a
+}
+
 func (a ListAttributeBuilder) SetComputed() AttributeBuilder {
 	if a.Computed {
 		panic("attribute is already computed")
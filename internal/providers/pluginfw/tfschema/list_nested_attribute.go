@@ -14,6 +14,7 @@ type ListNestedAttributeBuilder struct {
 	Optional           bool
 	Required           bool
 	Sensitive          bool
+	WriteOnly          bool
 	Computed           bool
 	DeprecationMessage string
 	Validators         []validator.List
@@ -38,6 +39,7 @@ func (a ListNestedAttributeBuilder) BuildResourceAttribute() schema.Attribute {
 		Optional:           a.Optional,
 		Required:           a.Required,
 		Sensitive:          a.Sensitive,
+		WriteOnly:          a.WriteOnly,
 		DeprecationMessage: a.DeprecationMessage,
 		Computed:           a.Computed,
 		Validators:         a.Validators,
@@ -71,6 +73,14 @@ func (a ListNestedAttributeBuilder) SetSensitive() AttributeBuilder {
 	return a
 }
 
+func (a ListNestedAttributeBuilder) SetWriteOnly() AttributeBuilder {
+	if a.WriteOnly {
+		panic("attribute is already write-only")
+	}
+	a.WriteOnly = true
+	return a
+}
+
 func (a ListNestedAttributeBuilder) SetComputed() AttributeBuilder {
 	if a.Computed {
 		panic("attribute is already computed")
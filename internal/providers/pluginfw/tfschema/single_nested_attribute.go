@@ -17,6 +17,7 @@ type SingleNestedAttributeBuilder struct {
 	Optional           bool
 	Required           bool
 	Sensitive          bool
+	WriteOnly          bool
 	Computed           bool
 	DeprecationMessage string
 	Validators         []validator.Object
@@ -41,6 +42,7 @@ func (a SingleNestedAttributeBuilder) BuildResourceAttribute() schema.Attribute
 		Optional:           a.Optional,
 		Required:           a.Required,
 		Sensitive:          a.Sensitive,
+		WriteOnly:          a.WriteOnly,
 		DeprecationMessage: a.DeprecationMessage,
 		Computed:           a.Computed,
 		Validators:         a.Validators,
@@ -74,6 +76,14 @@ func (a SingleNestedAttributeBuilder) SetSensitive() AttributeBuilder {
 	return a
 }
 
+func (a SingleNestedAttributeBuilder) SetWriteOnly() AttributeBuilder {
+	if a.WriteOnly {
+		panic("attribute is already write-only")
+	}
+	a.WriteOnly = true
+	return a
+}
+
 func (a SingleNestedAttributeBuilder) SetComputed() AttributeBuilder {
 	if a.Computed {
 		panic("attribute is already computed")
@@ -0,0 +1,468 @@
+package pluginfw
+
+// This file contains the wrappers that add the `workspace_id` attribute to workspace-level resources and data sources,
+// so that they could be managed from an account-level provider. When `workspace_id` is set, the wrapped resource
+// is configured with a client for that workspace, derived from the account-level client.
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	pluginfwcommon "github.com/databricks/terraform-provider-databricks/internal/providers/pluginfw/common"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const workspaceIdDescription = "ID of the workspace to manage this object in. Can only be used with an account-level provider."
+
+// workspaceIdState holds the information required to strip the injected `workspace_id` attribute from the
+// state, plan and config before passing them to the wrapped resource, and to add it back afterwards.
+type workspaceIdState struct {
+	// injected is false, if the wrapped resource declares `workspace_id` attribute itself
+	injected bool
+	// idType is the type of `workspace_id` attribute: a number, if it's injected, or the declared type
+	idType tftypes.Type
+	// innerType is the type of the wrapped resource schema
+	innerType tftypes.Type
+	// outerType is the type of the schema with `workspace_id` attribute
+	outerType tftypes.Type
+}
+
+// strip removes the `workspace_id` attribute from the given value and returns it separately
+func (s workspaceIdState) strip(raw tftypes.Value) (tftypes.Value, tftypes.Value, error) {
+	workspaceId := tftypes.NewValue(s.idType, nil)
+	if raw.IsNull() || !raw.IsKnown() {
+		if !s.injected {
+			return raw, workspaceId, nil
+		}
+		if raw.IsNull() {
+			return tftypes.NewValue(s.innerType, nil), workspaceId, nil
+		}
+		return tftypes.NewValue(s.innerType, tftypes.UnknownValue), workspaceId, nil
+	}
+	attrs := map[string]tftypes.Value{}
+	if err := raw.As(&attrs); err != nil {
+		return raw, workspaceId, err
+	}
+	if v, ok := attrs[common.WorkspaceIdField]; ok {
+		workspaceId = v
+	}
+	if !s.injected {
+		return raw, workspaceId, nil
+	}
+	delete(attrs, common.WorkspaceIdField)
+	return tftypes.NewValue(s.innerType, attrs), workspaceId, nil
+}
+
+// restore adds the `workspace_id` attribute back to the value returned by the wrapped resource
+func (s workspaceIdState) restore(raw, workspaceId tftypes.Value) (tftypes.Value, error) {
+	if !s.injected {
+		return raw, nil
+	}
+	if raw.IsNull() {
+		return tftypes.NewValue(s.outerType, nil), nil
+	}
+	attrs := map[string]tftypes.Value{}
+	if err := raw.As(&attrs); err != nil {
+		return raw, err
+	}
+	attrs[common.WorkspaceIdField] = workspaceId
+	return tftypes.NewValue(s.outerType, attrs), nil
+}
+
+// parseWorkspaceId returns the workspace ID from the `workspace_id` attribute value, or 0 if it's not set.
+// Resources, that declare `workspace_id` themselves, have it as a string.
+func parseWorkspaceId(v tftypes.Value) (int64, diag.Diagnostics) {
+	if v.IsNull() || !v.IsKnown() {
+		return 0, nil
+	}
+	if v.Type().Is(tftypes.Number) {
+		var n big.Float
+		if err := v.As(&n); err != nil {
+			return 0, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get workspace_id", err.Error())}
+		}
+		workspaceId, accuracy := n.Int64()
+		if accuracy != big.Exact {
+			return 0, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root(common.WorkspaceIdField),
+				"Invalid workspace_id", fmt.Sprintf("workspace_id must be an integer, got %s", n.String()))}
+		}
+		return workspaceId, nil
+	}
+	var s string
+	if err := v.As(&s); err != nil {
+		return 0, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get workspace_id", err.Error())}
+	}
+	if s == "" {
+		return 0, nil
+	}
+	workspaceId, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root(common.WorkspaceIdField),
+			"Invalid workspace_id", fmt.Sprintf("workspace_id must be a number, got %q", s))}
+	}
+	return workspaceId, nil
+}
+
+// clientInWorkspace returns the client for the given workspace, or the provider client if workspace isn't set
+func clientInWorkspace(ctx context.Context, client *common.DatabricksClient, workspaceId int64) (*common.DatabricksClient, diag.Diagnostics) {
+	if workspaceId == 0 || client == nil {
+		return client, nil
+	}
+	wc, err := client.InWorkspace(ctx, workspaceId)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get workspace client", err.Error())}
+	}
+	return wc, nil
+}
+
+// workspaceIdResourceAttribute returns the `workspace_id` attribute, that has the same type as in SDKv2 resources
+func workspaceIdResourceAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:    true,
+		Description: workspaceIdDescription,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// resourceWithWorkspaceId wraps a workspace-level resource and adds the `workspace_id` attribute to it. Optional
+// interfaces working on plans, configs or states, like ModifyPlan, aren't forwarded, as they have to strip the
+// attribute first; TestWrappedResourcesForwardOptionalInterfaces fails, once a wrapped resource needs them.
+type resourceWithWorkspaceId struct {
+	resource.Resource
+	client *common.DatabricksClient
+}
+
+var _ resource.ResourceWithConfigure = &resourceWithWorkspaceId{}
+var _ resource.ResourceWithImportState = &resourceWithWorkspaceId{}
+
+func withWorkspaceIdResource(resourceFunc func() resource.Resource) func() resource.Resource {
+	return func() resource.Resource {
+		return &resourceWithWorkspaceId{Resource: resourceFunc()}
+	}
+}
+
+func (r *resourceWithWorkspaceId) innerSchema(ctx context.Context) schema.Schema {
+	resp := resource.SchemaResponse{}
+	r.Resource.Schema(ctx, resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+func (r *resourceWithWorkspaceId) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	r.Resource.Schema(ctx, req, resp)
+	attrs := make(map[string]schema.Attribute, len(resp.Schema.Attributes)+1)
+	for k, v := range resp.Schema.Attributes {
+		attrs[k] = v
+	}
+	if _, declared := attrs[common.WorkspaceIdField]; !declared {
+		attrs[common.WorkspaceIdField] = workspaceIdResourceAttribute()
+	}
+	resp.Schema.Attributes = attrs
+}
+
+func (r *resourceWithWorkspaceId) state(ctx context.Context) (schema.Schema, workspaceIdState) {
+	inner := r.innerSchema(ctx)
+	_, declared := inner.Attributes[common.WorkspaceIdField]
+	outer := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &outer)
+	return inner, workspaceIdState{
+		injected:  !declared,
+		idType:    outer.Schema.Attributes[common.WorkspaceIdField].GetType().TerraformType(ctx),
+		innerType: inner.Type().TerraformType(ctx),
+		outerType: outer.Schema.Type().TerraformType(ctx),
+	}
+}
+
+func (r *resourceWithWorkspaceId) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = pluginfwcommon.ConfigureResource(req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if inner, ok := r.Resource.(resource.ResourceWithConfigure); ok {
+		inner.Configure(ctx, req, resp)
+	}
+}
+
+// configureInWorkspace configures the wrapped resource with the client for the given workspace
+func (r *resourceWithWorkspaceId) configureInWorkspace(ctx context.Context, workspaceId tftypes.Value) diag.Diagnostics {
+	id, diags := parseWorkspaceId(workspaceId)
+	if diags.HasError() || id == 0 {
+		return diags
+	}
+	inner, ok := r.Resource.(resource.ResourceWithConfigure)
+	if !ok {
+		return diags
+	}
+	client, diags := clientInWorkspace(ctx, r.client, id)
+	if diags.HasError() {
+		return diags
+	}
+	resp := resource.ConfigureResponse{}
+	inner.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resp)
+	return resp.Diagnostics
+}
+
+func (r *resourceWithWorkspaceId) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	inner, s := r.state(ctx)
+	plan, workspaceId, err := s.strip(req.Plan.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read plan", err.Error())
+		return
+	}
+	config, _, err := s.strip(req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read config", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(r.configureInWorkspace(ctx, workspaceId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.Plan.Schema, req.Plan.Raw = inner, plan
+	req.Config.Schema, req.Config.Raw = inner, config
+	current, _, err := s.strip(resp.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read state", err.Error())
+		return
+	}
+	state := resp.State
+	resp.State.Schema, resp.State.Raw = inner, current
+	r.Resource.Create(ctx, req, resp)
+	raw, err := s.restore(resp.State.Raw, workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to set state", err.Error())
+	}
+	state.Raw = raw
+	resp.State = state
+}
+
+func (r *resourceWithWorkspaceId) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	inner, s := r.state(ctx)
+	raw, workspaceId, err := s.strip(req.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read state", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(r.configureInWorkspace(ctx, workspaceId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.State.Schema, req.State.Raw = inner, raw
+	state := resp.State
+	resp.State.Schema, resp.State.Raw = inner, raw
+	r.Resource.Read(ctx, req, resp)
+	raw, err = s.restore(resp.State.Raw, workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to set state", err.Error())
+	}
+	state.Raw = raw
+	resp.State = state
+}
+
+func (r *resourceWithWorkspaceId) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	inner, s := r.state(ctx)
+	plan, workspaceId, err := s.strip(req.Plan.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read plan", err.Error())
+		return
+	}
+	config, _, err := s.strip(req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read config", err.Error())
+		return
+	}
+	prior, _, err := s.strip(req.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read state", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(r.configureInWorkspace(ctx, workspaceId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.Plan.Schema, req.Plan.Raw = inner, plan
+	req.Config.Schema, req.Config.Raw = inner, config
+	req.State.Schema, req.State.Raw = inner, prior
+	current, _, err := s.strip(resp.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read state", err.Error())
+		return
+	}
+	state := resp.State
+	resp.State.Schema, resp.State.Raw = inner, current
+	r.Resource.Update(ctx, req, resp)
+	raw, err := s.restore(resp.State.Raw, workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to set state", err.Error())
+	}
+	state.Raw = raw
+	resp.State = state
+}
+
+func (r *resourceWithWorkspaceId) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	inner, s := r.state(ctx)
+	raw, workspaceId, err := s.strip(req.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read state", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(r.configureInWorkspace(ctx, workspaceId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.State.Schema, req.State.Raw = inner, raw
+	current, _, err := s.strip(resp.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read state", err.Error())
+		return
+	}
+	state := resp.State
+	resp.State.Schema, resp.State.Raw = inner, current
+	r.Resource.Delete(ctx, req, resp)
+	raw, err = s.restore(resp.State.Raw, workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to set state", err.Error())
+	}
+	state.Raw = raw
+	resp.State = state
+}
+
+// ImportState accepts import IDs in the form of `<workspace_id>:<id>`, where `<id>` is the import ID
+// of the wrapped resource.
+func (r *resourceWithWorkspaceId) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importer, ok := r.Resource.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError("Resource Import Not Implemented",
+			"This resource does not support import. Please contact the provider developer for additional information.")
+		return
+	}
+	workspaceId, id, ok := common.ParseResourceIdWithWorkspaceId(req.ID)
+	if !ok {
+		importer.ImportState(ctx, req, resp)
+		return
+	}
+	inner, s := r.state(ctx)
+	req.ID = id
+	state := resp.State
+	if s.injected {
+		resp.State.Schema, resp.State.Raw = inner, tftypes.NewValue(s.innerType, nil)
+	}
+	importer.ImportState(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !s.injected {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(common.WorkspaceIdField),
+			strconv.FormatInt(workspaceId, 10))...)
+		return
+	}
+	raw, err := s.restore(resp.State.Raw, tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(workspaceId)))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to set state", err.Error())
+	}
+	state.Raw = raw
+	resp.State = state
+}
+
+// dataSourceWithWorkspaceId wraps a workspace-level data source and adds the `workspace_id` attribute to it
+type dataSourceWithWorkspaceId struct {
+	datasource.DataSource
+	client *common.DatabricksClient
+}
+
+var _ datasource.DataSourceWithConfigure = &dataSourceWithWorkspaceId{}
+
+func withWorkspaceIdDataSource(dataSourceFunc func() datasource.DataSource) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &dataSourceWithWorkspaceId{DataSource: dataSourceFunc()}
+	}
+}
+
+func (d *dataSourceWithWorkspaceId) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	d.DataSource.Schema(ctx, req, resp)
+	attrs := make(map[string]dataschema.Attribute, len(resp.Schema.Attributes)+1)
+	for k, v := range resp.Schema.Attributes {
+		attrs[k] = v
+	}
+	if _, declared := attrs[common.WorkspaceIdField]; !declared {
+		attrs[common.WorkspaceIdField] = dataschema.Int64Attribute{
+			Optional:    true,
+			Description: workspaceIdDescription,
+		}
+	}
+	resp.Schema.Attributes = attrs
+}
+
+func (d *dataSourceWithWorkspaceId) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = pluginfwcommon.ConfigureDataSource(req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if inner, ok := d.DataSource.(datasource.DataSourceWithConfigure); ok {
+		inner.Configure(ctx, req, resp)
+	}
+}
+
+func (d *dataSourceWithWorkspaceId) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	innerResp := datasource.SchemaResponse{}
+	d.DataSource.Schema(ctx, datasource.SchemaRequest{}, &innerResp)
+	inner := innerResp.Schema
+	_, declared := inner.Attributes[common.WorkspaceIdField]
+	outer := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &outer)
+	s := workspaceIdState{
+		injected:  !declared,
+		idType:    outer.Schema.Attributes[common.WorkspaceIdField].GetType().TerraformType(ctx),
+		innerType: inner.Type().TerraformType(ctx),
+		outerType: outer.Schema.Type().TerraformType(ctx),
+	}
+	config, workspaceId, err := s.strip(req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read config", err.Error())
+		return
+	}
+	id, diags := parseWorkspaceId(workspaceId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if configurable, ok := d.DataSource.(datasource.DataSourceWithConfigure); ok && id != 0 {
+		client, diags := clientInWorkspace(ctx, d.client, id)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		configureResp := datasource.ConfigureResponse{}
+		configurable.Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &configureResp)
+		resp.Diagnostics.Append(configureResp.Diagnostics...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	req.Config.Schema, req.Config.Raw = inner, config
+	state := resp.State
+	current, _, err := s.strip(resp.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read state", err.Error())
+		return
+	}
+	resp.State.Schema, resp.State.Raw = inner, current
+	d.DataSource.Read(ctx, req, resp)
+	raw, err := s.restore(resp.State.Raw, workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to set state", err.Error())
+	}
+	state.Raw = raw
+	resp.State = state
+}
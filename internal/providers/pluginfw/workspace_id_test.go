@@ -0,0 +1,163 @@
+package pluginfw
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResource struct{}
+
+type fakeResourceModel struct {
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (r *fakeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "databricks_fake"
+}
+
+func (r *fakeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":  schema.StringAttribute{Required: true},
+			"value": schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+func (r *fakeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan fakeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	plan.Value = types.StringValue("created " + plan.Name.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *fakeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (r *fakeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+func (r *fakeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *fakeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+func TestResourceWithWorkspaceId_Schema(t *testing.T) {
+	r := withWorkspaceIdResource(func() resource.Resource { return &fakeResource{} })()
+	resp := resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	require.Contains(t, resp.Schema.Attributes, common.WorkspaceIdField)
+	assert.Equal(t, types.Int64Type, resp.Schema.Attributes[common.WorkspaceIdField].GetType())
+	assert.Contains(t, resp.Schema.Attributes, "name")
+}
+
+type fakeResourceWithWorkspaceId struct {
+	fakeResource
+}
+
+func (r *fakeResourceWithWorkspaceId) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	r.fakeResource.Schema(ctx, req, resp)
+	resp.Schema.Attributes[common.WorkspaceIdField] = schema.StringAttribute{Optional: true}
+}
+
+func TestResourceWithWorkspaceId_SchemaKeepsDeclaredAttribute(t *testing.T) {
+	r := withWorkspaceIdResource(func() resource.Resource { return &fakeResourceWithWorkspaceId{} })()
+	resp := resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	require.Contains(t, resp.Schema.Attributes, common.WorkspaceIdField)
+	assert.Equal(t, types.StringType, resp.Schema.Attributes[common.WorkspaceIdField].GetType())
+}
+
+func TestParseWorkspaceId(t *testing.T) {
+	id, diags := parseWorkspaceId(tftypes.NewValue(tftypes.Number, 123))
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, int64(123), id)
+
+	id, diags = parseWorkspaceId(tftypes.NewValue(tftypes.String, "456"))
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, int64(456), id)
+
+	id, diags = parseWorkspaceId(tftypes.NewValue(tftypes.Number, nil))
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, int64(0), id)
+
+	_, diags = parseWorkspaceId(tftypes.NewValue(tftypes.Number, 1.5))
+	assert.True(t, diags.HasError())
+}
+
+func TestResourceWithWorkspaceId_Create(t *testing.T) {
+	ctx := context.Background()
+	r := withWorkspaceIdResource(func() resource.Resource { return &fakeResource{} })()
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+	plan := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":                  tftypes.NewValue(tftypes.String, "foo"),
+		"value":                 tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		common.WorkspaceIdField: tftypes.NewValue(tftypes.Number, nil),
+	})
+	resp := resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(ctx, resource.CreateRequest{
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan},
+	}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	var value types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("value"), &value)...)
+	assert.Equal(t, "created foo", value.ValueString())
+	var workspaceId types.Int64
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root(common.WorkspaceIdField), &workspaceId)...)
+	assert.True(t, workspaceId.IsNull())
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+}
+
+func TestResourceWithWorkspaceId_ImportState(t *testing.T) {
+	ctx := context.Background()
+	r := withWorkspaceIdResource(func() resource.Resource { return &fakeResource{} })()
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	resp := resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "123:foo"}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	var name types.String
+	var workspaceId types.Int64
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root(common.WorkspaceIdField), &workspaceId)...)
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.Equal(t, "foo", name.ValueString())
+	assert.Equal(t, int64(123), workspaceId.ValueInt64())
+}
+
+func TestOnlyWorkspaceLevelResourcesHaveWorkspaceId(t *testing.T) {
+	for _, resourceFunc := range getPluginFrameworkResourcesToRegister(nil) {
+		name := getResourceName(resourceFunc)
+		_, wrapped := resourceFunc().(*resourceWithWorkspaceId)
+		assert.Equal(t, wrapped, slices.Contains(workspaceLevelResourcesAndDataSources, name), name)
+	}
+	for _, dataSourceFunc := range getPluginFrameworkDataSourcesToRegister(nil) {
+		name := getDataSourceName(dataSourceFunc)
+		_, wrapped := dataSourceFunc().(*dataSourceWithWorkspaceId)
+		assert.Equal(t, wrapped, slices.Contains(workspaceLevelResourcesAndDataSources, name), name)
+	}
+}
@@ -58,6 +58,125 @@ func TestConfig_HostTokenEnv(t *testing.T) {
 	}.apply(t)
 }
 
+func TestConfig_ReadOnlyEnv(t *testing.T) {
+	providerFixture{
+		env: map[string]string{
+			"DATABRICKS_HOST":      "x",
+			"DATABRICKS_TOKEN":     "x",
+			"DATABRICKS_READ_ONLY": "true",
+		},
+		assertAuth:     "pat",
+		assertHost:     "https://x",
+		assertReadOnly: true,
+	}.apply(t)
+}
+
+func TestConfig_ReadOnlyEnvInvalid(t *testing.T) {
+	providerFixture{
+		env: map[string]string{
+			"DATABRICKS_HOST":      "x",
+			"DATABRICKS_TOKEN":     "x",
+			"DATABRICKS_READ_ONLY": "maybe",
+		},
+		assertError: "invalid value of DATABRICKS_READ_ONLY environment variable",
+	}.apply(t)
+}
+
+func TestConfig_DeletionProtection(t *testing.T) {
+	providerFixture{
+		host:                    "https://x",
+		token:                   "x",
+		deletionProtection:      []string{"databricks_catalog", "databricks_metastore"},
+		assertAuth:              "pat",
+		assertHost:              "https://x",
+		assertDeletionProtected: []string{"databricks_catalog", "databricks_metastore"},
+	}.apply(t)
+}
+
+func TestConfig_DeletionProtectionInvalidResourceType(t *testing.T) {
+	providerFixture{
+		host:               "https://x",
+		token:              "x",
+		deletionProtection: []string{"catalog"},
+		assertError:        "deletion_protection.resource_types must contain full resource types",
+	}.apply(t)
+}
+
+func TestConfig_DeletionProtectionUnknownResourceType(t *testing.T) {
+	for _, resourceType := range []string{"databricks_catalogs", "databricks_mws_workspace"} {
+		// resource types of both providers are validated by the SDKv2 provider
+		_ = providerFixture{
+			host:               "https://x",
+			token:              "x",
+			deletionProtection: []string{resourceType},
+			assertError:        "deletion_protection.resource_types contains unknown resource type " + resourceType,
+		}.applyWithSDKv2(t)
+	}
+	// resources of the plugin framework are known as well
+	_ = providerFixture{
+		host:                    "https://x",
+		token:                   "x",
+		deletionProtection:      []string{"databricks_app", "databricks_mws_workspaces"},
+		assertAuth:              "pat",
+		assertHost:              "https://x",
+		assertDeletionProtected: []string{"databricks_app", "databricks_mws_workspaces"},
+	}.applyWithSDKv2(t)
+}
+
+func TestConfig_DefaultTags(t *testing.T) {
+	providerFixture{
+		host:              "https://x",
+		token:             "x",
+		defaultTags:       map[string]string{"cost_center": "engineering"},
+		assertAuth:        "pat",
+		assertHost:        "https://x",
+		assertDefaultTags: map[string]string{"cost_center": "engineering"},
+	}.apply(t)
+}
+
+func TestConfig_Retry(t *testing.T) {
+	providerFixture{
+		host:  "https://x",
+		token: "x",
+		retry: map[common.RetryClass]map[string]any{
+			common.RetryThrottling: {
+				common.RetryMaxAttemptsField:       int64(10),
+				common.RetryMinBackoffSecondsField: int64(2),
+				common.RetryMaxBackoffSecondsField: int64(60),
+			},
+			common.RetryServerError: {
+				common.RetryMaxAttemptsField: int64(0),
+				common.RetryJitterField:      false,
+			},
+			common.RetryNotFound: {
+				common.RetryMinBackoffSecondsField: int64(30),
+			},
+		},
+		assertAuth: "pat",
+		assertHost: "https://x",
+		assertRetryPolicies: common.RetryPolicies{
+			common.RetryThrottling:  {MaxAttempts: 10, MinBackoff: 2 * time.Second, MaxBackoff: time.Minute, Jitter: true},
+			common.RetryServerError: {MinBackoff: time.Second, MaxBackoff: 10 * time.Second},
+			common.RetryTimeout:     common.DefaultRetryPolicy(common.RetryTimeout),
+			common.RetryNotFound:    {MinBackoff: 30 * time.Second, MaxBackoff: 30 * time.Second, Jitter: true},
+		},
+	}.apply(t)
+}
+
+func TestConfig_RetryInvalidBackoff(t *testing.T) {
+	providerFixture{
+		host:  "https://x",
+		token: "x",
+		retry: map[common.RetryClass]map[string]any{
+			common.RetryConflict: {
+				common.RetryMinBackoffSecondsField: int64(5),
+				common.RetryMaxBackoffSecondsField: int64(1),
+			},
+		},
+		assertError: "retry.conflict.max_backoff_seconds must not be less than min_backoff_seconds",
+	}.apply(t)
+}
+
 func TestConfig_HostParamTokenEnv(t *testing.T) {
 	providerFixture{
 		host: "https://x",
@@ -35,6 +35,16 @@ type providerFixture struct {
 	assertAuth        string
 	assertHost        string
 	assertAzure       bool
+	assertReadOnly    bool
+	// resource types in the `deletion_protection` block
+	deletionProtection      []string
+	assertDeletionProtected []string
+	// tags in the `default_tags` block
+	defaultTags       map[string]string
+	assertDefaultTags map[string]string
+	// classes of errors in the `retry` block with int64 or bool values of their fields
+	retry               map[common.RetryClass]map[string]any
+	assertRetryPolicies common.RetryPolicies
 }
 
 const testDataPath = "../../common/testdata"
@@ -83,6 +93,38 @@ func (pf providerFixture) rawConfigSDKv2() map[string]any {
 	for k, v := range rawConfig {
 		rawConfigSDKv2[k] = v
 	}
+	if pf.deletionProtection != nil {
+		resourceTypes := []any{}
+		for _, v := range pf.deletionProtection {
+			resourceTypes = append(resourceTypes, v)
+		}
+		rawConfigSDKv2[common.DeletionProtectionField] = []any{
+			map[string]any{common.DeletionProtectionResourceTypesField: resourceTypes},
+		}
+	}
+	if pf.defaultTags != nil {
+		tags := map[string]any{}
+		for k, v := range pf.defaultTags {
+			tags[k] = v
+		}
+		rawConfigSDKv2[common.DefaultTagsField] = []any{
+			map[string]any{common.DefaultTagsTagsField: tags},
+		}
+	}
+	if pf.retry != nil {
+		classes := map[string]any{}
+		for class, fields := range pf.retry {
+			block := map[string]any{}
+			for k, v := range fields {
+				if i, ok := v.(int64); ok {
+					v = int(i)
+				}
+				block[k] = v
+			}
+			classes[string(class)] = []any{block}
+		}
+		rawConfigSDKv2[common.RetryField] = []any{classes}
+	}
 	return rawConfigSDKv2
 }
 
@@ -101,6 +143,63 @@ func (pf providerFixture) rawConfigPluginFramework() tftypes.Value {
 	for k, v := range rawConfig {
 		rawConfigValueMap[k] = tftypes.NewValue(tftypes.String, v)
 	}
+	if pf.deletionProtection != nil {
+		resourceTypesType := tftypes.Set{ElementType: tftypes.String}
+		blockType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			common.DeletionProtectionResourceTypesField: resourceTypesType,
+		}}
+		resourceTypes := []tftypes.Value{}
+		for _, v := range pf.deletionProtection {
+			resourceTypes = append(resourceTypes, tftypes.NewValue(tftypes.String, v))
+		}
+		rawConfigTypeMap[common.DeletionProtectionField] = tftypes.List{ElementType: blockType}
+		rawConfigValueMap[common.DeletionProtectionField] = tftypes.NewValue(tftypes.List{ElementType: blockType},
+			[]tftypes.Value{tftypes.NewValue(blockType, map[string]tftypes.Value{
+				common.DeletionProtectionResourceTypesField: tftypes.NewValue(resourceTypesType, resourceTypes),
+			})})
+	}
+	if pf.defaultTags != nil {
+		tagsType := tftypes.Map{ElementType: tftypes.String}
+		blockType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			common.DefaultTagsTagsField: tagsType,
+		}}
+		tags := map[string]tftypes.Value{}
+		for k, v := range pf.defaultTags {
+			tags[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		rawConfigTypeMap[common.DefaultTagsField] = tftypes.List{ElementType: blockType}
+		rawConfigValueMap[common.DefaultTagsField] = tftypes.NewValue(tftypes.List{ElementType: blockType},
+			[]tftypes.Value{tftypes.NewValue(blockType, map[string]tftypes.Value{
+				common.DefaultTagsTagsField: tftypes.NewValue(tagsType, tags),
+			})})
+	}
+	if pf.retry != nil {
+		policyType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			common.RetryMaxAttemptsField:       tftypes.Number,
+			common.RetryMinBackoffSecondsField: tftypes.Number,
+			common.RetryMaxBackoffSecondsField: tftypes.Number,
+			common.RetryJitterField:            tftypes.Bool,
+		}}
+		classesType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}
+		classes := map[string]tftypes.Value{}
+		for _, class := range common.RetryClasses {
+			classesType.AttributeTypes[string(class)] = tftypes.List{ElementType: policyType}
+			fields, ok := pf.retry[class]
+			if !ok {
+				classes[string(class)] = tftypes.NewValue(tftypes.List{ElementType: policyType}, nil)
+				continue
+			}
+			policy := map[string]tftypes.Value{}
+			for k, t := range policyType.AttributeTypes {
+				policy[k] = tftypes.NewValue(t, fields[k])
+			}
+			classes[string(class)] = tftypes.NewValue(tftypes.List{ElementType: policyType},
+				[]tftypes.Value{tftypes.NewValue(policyType, policy)})
+		}
+		rawConfigTypeMap[common.RetryField] = tftypes.List{ElementType: classesType}
+		rawConfigValueMap[common.RetryField] = tftypes.NewValue(tftypes.List{ElementType: classesType},
+			[]tftypes.Value{tftypes.NewValue(classesType, classes)})
+	}
 	rawConfigValue := tftypes.NewValue(rawConfigType, rawConfigValueMap)
 	return rawConfigValue
 }
@@ -129,6 +228,16 @@ func (pf providerFixture) applyAssertions(c *common.DatabricksClient, t *testing
 	assert.Equal(t, pf.assertAzure, c.IsAzure())
 	assert.Equal(t, pf.assertAuth, c.Config.AuthType)
 	assert.Equal(t, pf.assertHost, c.Config.Host)
+	assert.Equal(t, pf.assertReadOnly, c.IsReadOnly())
+	if pf.assertDefaultTags != nil {
+		assert.Equal(t, pf.assertDefaultTags, c.MergeDefaultTags(nil))
+	}
+	for class, policy := range pf.assertRetryPolicies {
+		assert.Equal(t, policy, c.RetryPolicy(class), class)
+	}
+	for _, resourceType := range pf.assertDeletionProtected {
+		assert.True(t, c.IsDeletionProtected(resourceType), resourceType)
+	}
 	return c
 }
 
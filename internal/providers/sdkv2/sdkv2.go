@@ -113,27 +113,29 @@ func DatabricksProvider(opts ...SdkV2ProviderOption) *schema.Provider {
 		"databricks_aws_bucket_policy":                    aws.DataAwsBucketPolicy().ToResource(),
 		"databricks_aws_unity_catalog_assume_role_policy": aws.DataAwsUnityCatalogAssumeRolePolicy().ToResource(),
 		"databricks_aws_unity_catalog_policy":             aws.DataAwsUnityCatalogPolicy().ToResource(),
+		"databricks_bundle_job":                           jobs.DataSourceBundleJob().ToResource(),
 		"databricks_cluster":                              clusters.DataSourceCluster().ToResource(),
 		"databricks_clusters":                             clusters.DataSourceClusters().ToResource(),
 		"databricks_cluster_policy":                       policies.DataSourceClusterPolicy().ToResource(),
 		"databricks_catalog":                              catalog.DataSourceCatalog().ToResource(),
 		"databricks_catalogs":                             catalog.DataSourceCatalogs().ToResource(),
 		"databricks_current_config":                       mws.DataSourceCurrentConfiguration().ToResource(),
-		"databricks_current_metastore":                    catalog.DataSourceCurrentMetastore().ToResource(),
+		"databricks_current_metastore":                    workspaceLevel(catalog.DataSourceCurrentMetastore()),
 		"databricks_current_user":                         scim.DataSourceCurrentUser().ToResource(),
-		"databricks_dbfs_file":                            storage.DataSourceDbfsFile().ToResource(),
-		"databricks_dbfs_file_paths":                      storage.DataSourceDbfsFilePaths().ToResource(),
-		"databricks_directory":                            workspace.DataSourceDirectory().ToResource(),
-		"databricks_external_location":                    catalog.DataSourceExternalLocation().ToResource(),
-		"databricks_external_locations":                   catalog.DataSourceExternalLocations().ToResource(),
+		"databricks_dbfs_file":                            workspaceLevel(storage.DataSourceDbfsFile()),
+		"databricks_dbfs_file_paths":                      workspaceLevel(storage.DataSourceDbfsFilePaths()),
+		"databricks_directory":                            workspaceLevel(workspace.DataSourceDirectory()),
+		"databricks_effective_grants":                     workspaceLevel(catalog.DataSourceEffectiveGrants()),
+		"databricks_external_location":                    workspaceLevel(catalog.DataSourceExternalLocation()),
+		"databricks_external_locations":                   workspaceLevel(catalog.DataSourceExternalLocations()),
 		"databricks_group":                                scim.DataSourceGroup().ToResource(),
-		"databricks_instance_pool":                        pools.DataSourceInstancePool().ToResource(),
-		"databricks_instance_profiles":                    aws.DataSourceInstanceProfiles().ToResource(),
+		"databricks_instance_pool":                        workspaceLevel(pools.DataSourceInstancePool()),
+		"databricks_instance_profiles":                    workspaceLevel(aws.DataSourceInstanceProfiles()),
 		"databricks_jobs":                                 jobs.DataSourceJobs().ToResource(),
-		"databricks_job":                                  jobs.DataSourceJob().ToResource(),
+		"databricks_job":                                  workspaceLevel(jobs.DataSourceJob()),
 		"databricks_metastore":                            catalog.DataSourceMetastore().ToResource(),
 		"databricks_metastores":                           catalog.DataSourceMetastores().ToResource(),
-		"databricks_mlflow_experiment":                    mlflow.DataSourceExperiment().ToResource(),
+		"databricks_mlflow_experiment":                    workspaceLevel(mlflow.DataSourceExperiment()),
 		"databricks_mlflow_model":                         mlflow.DataSourceModel().ToResource(),
 		"databricks_mlflow_models":                        mlflow.DataSourceModels().ToResource(),
 		"databricks_mws_credentials":                      mws.DataSourceMwsCredentials().ToResource(),
@@ -141,9 +143,11 @@ func DatabricksProvider(opts ...SdkV2ProviderOption) *schema.Provider {
 		"databricks_mws_network_connectivity_configs":     mws.DataSourceMwsNetworkConnectivityConfigs().ToResource(),
 		"databricks_mws_workspaces":                       mws.DataSourceMwsWorkspaces().ToResource(),
 		"databricks_node_type":                            clusters.DataSourceNodeType().ToResource(),
-		"databricks_notebook":                             workspace.DataSourceNotebook().ToResource(),
-		"databricks_notebook_paths":                       workspace.DataSourceNotebookPaths().ToResource(),
-		"databricks_pipelines":                            pipelines.DataSourcePipelines().ToResource(),
+		"databricks_notebook":                             workspaceLevel(workspace.DataSourceNotebook()),
+		"databricks_notebook_paths":                       workspaceLevel(workspace.DataSourceNotebookPaths()),
+		"databricks_permissions_audit":                    workspaceLevel(permissions.DataSourcePermissionsAudit()),
+		"databricks_pipelines":                            workspaceLevel(pipelines.DataSourcePipelines()),
+		"databricks_principals":                           scim.DataSourcePrincipals().ToResource(),
 		"databricks_schema":                               catalog.DataSourceSchema().ToResource(),
 		"databricks_schemas":                              catalog.DataSourceSchemas().ToResource(),
 		"databricks_service_principal":                    scim.DataSourceServicePrincipal().ToResource(),
@@ -152,7 +156,7 @@ func DatabricksProvider(opts ...SdkV2ProviderOption) *schema.Provider {
 		"databricks_shares":                               sharing.DataSourceShares().ToResource(),
 		"databricks_spark_version":                        clusters.DataSourceSparkVersion().ToResource(),
 		"databricks_sql_warehouse":                        sql.DataSourceWarehouse().ToResource(),
-		"databricks_sql_warehouses":                       sql.DataSourceWarehouses().ToResource(),
+		"databricks_sql_warehouses":                       workspaceLevel(sql.DataSourceWarehouses()),
 		"databricks_storage_credential":                   catalog.DataSourceStorageCredential().ToResource(),
 		"databricks_storage_credentials":                  catalog.DataSourceStorageCredentials().ToResource(),
 		"databricks_table":                                catalog.DataSourceTable().ToResource(),
@@ -165,50 +169,54 @@ func DatabricksProvider(opts ...SdkV2ProviderOption) *schema.Provider {
 	}
 
 	resourceMap := map[string]*schema.Resource{ // must be in alphabetical order
+		"databricks_access_control_rule":                  permissions.ResourceAccessControlRule().ToResource(),
 		"databricks_access_control_rule_set":              permissions.ResourceAccessControlRuleSet().ToResource(),
-		"databricks_alert":                                sql.ResourceAlert().ToResource(),
-		"databricks_artifact_allowlist":                   catalog.ResourceArtifactAllowlist().ToResource(),
-		"databricks_aws_s3_mount":                         storage.ResourceAWSS3Mount().ToResource(),
-		"databricks_azure_adls_gen1_mount":                storage.ResourceAzureAdlsGen1Mount().ToResource(),
-		"databricks_azure_adls_gen2_mount":                storage.ResourceAzureAdlsGen2Mount().ToResource(),
-		"databricks_azure_blob_mount":                     storage.ResourceAzureBlobMount().ToResource(),
+		"databricks_alert":                                workspaceLevel(sql.ResourceAlert()),
+		"databricks_artifact_allowlist":                   workspaceLevel(catalog.ResourceArtifactAllowlist()),
+		"databricks_aws_s3_mount":                         workspaceLevel(storage.ResourceAWSS3Mount()),
+		"databricks_azure_adls_gen1_mount":                workspaceLevel(storage.ResourceAzureAdlsGen1Mount()),
+		"databricks_azure_adls_gen2_mount":                workspaceLevel(storage.ResourceAzureAdlsGen2Mount()),
+		"databricks_azure_blob_mount":                     workspaceLevel(storage.ResourceAzureBlobMount()),
 		"databricks_budget":                               finops.ResourceBudget().ToResource(),
-		"databricks_catalog":                              catalog.ResourceCatalog().ToResource(),
-		"databricks_catalog_workspace_binding":            catalog.ResourceCatalogWorkspaceBinding().ToResource(),
-		"databricks_credential":                           catalog.ResourceCredential().ToResource(),
+		"databricks_catalog":                              workspaceLevel(catalog.ResourceCatalog()),
+		"databricks_catalog_workspace_binding":            workspaceLevel(catalog.ResourceCatalogWorkspaceBinding()),
+		"databricks_credential":                           workspaceLevel(catalog.ResourceCredential()),
 		"databricks_custom_app_integration":               apps.ResourceCustomAppIntegration().ToResource(),
-		"databricks_connection":                           catalog.ResourceConnection().ToResource(),
-		"databricks_cluster":                              clusters.ResourceCluster().ToResource(),
-		"databricks_cluster_policy":                       policies.ResourceClusterPolicy().ToResource(),
-		"databricks_dashboard":                            dashboards.ResourceDashboard().ToResource(),
-		"databricks_dbfs_file":                            storage.ResourceDbfsFile().ToResource(),
-		"databricks_directory":                            workspace.ResourceDirectory().ToResource(),
+		"databricks_connection":                           workspaceLevel(catalog.ResourceConnection()),
+		"databricks_cluster":                              workspaceLevel(clusters.ResourceCluster()),
+		"databricks_cluster_libraries":                    workspaceLevel(clusters.ResourceClusterLibraries()),
+		"databricks_cluster_policy":                       workspaceLevel(policies.ResourceClusterPolicy()),
+		"databricks_dashboard":                            workspaceLevel(dashboards.ResourceDashboard()),
+		"databricks_dbfs_file":                            workspaceLevel(storage.ResourceDbfsFile()),
+		"databricks_directory":                            workspaceLevel(workspace.ResourceDirectory()),
 		"databricks_entitlements":                         scim.ResourceEntitlements().ToResource(),
-		"databricks_external_location":                    catalog.ResourceExternalLocation().ToResource(),
-		"databricks_file":                                 storage.ResourceFile().ToResource(),
-		"databricks_git_credential":                       repos.ResourceGitCredential().ToResource(),
-		"databricks_global_init_script":                   workspace.ResourceGlobalInitScript().ToResource(),
-		"databricks_grant":                                catalog.ResourceGrant().ToResource(),
-		"databricks_grants":                               catalog.ResourceGrants().ToResource(),
+		"databricks_external_location":                    workspaceLevel(catalog.ResourceExternalLocation()),
+		"databricks_file":                                 workspaceLevel(storage.ResourceFile()),
+		"databricks_git_credential":                       workspaceLevel(repos.ResourceGitCredential()),
+		"databricks_global_init_script":                   workspaceLevel(workspace.ResourceGlobalInitScript()),
+		"databricks_grant":                                workspaceLevel(catalog.ResourceGrant()),
+		"databricks_grant_policy":                         workspaceLevel(catalog.ResourceGrantPolicy()),
+		"databricks_grants":                               workspaceLevel(catalog.ResourceGrants()),
 		"databricks_group":                                scim.ResourceGroup().ToResource(),
 		"databricks_group_instance_profile":               aws.ResourceGroupInstanceProfile().ToResource(),
 		"databricks_group_member":                         scim.ResourceGroupMember().ToResource(),
+		"databricks_group_members":                        scim.ResourceGroupMembers().ToResource(),
 		"databricks_group_role":                           scim.ResourceGroupRole().ToResource(),
-		"databricks_instance_pool":                        pools.ResourceInstancePool().ToResource(),
+		"databricks_instance_pool":                        workspaceLevel(pools.ResourceInstancePool()),
 		"databricks_instance_profile":                     aws.ResourceInstanceProfile().ToResource(),
-		"databricks_ip_access_list":                       access.ResourceIPAccessList().ToResource(),
-		"databricks_job":                                  jobs.ResourceJob().ToResource(),
-		"databricks_lakehouse_monitor":                    catalog.ResourceLakehouseMonitor().ToResource(),
-		"databricks_library":                              clusters.ResourceLibrary().ToResource(),
+		"databricks_ip_access_list":                       workspaceLevel(access.ResourceIPAccessList()),
+		"databricks_job":                                  workspaceLevel(jobs.ResourceJob()),
+		"databricks_lakehouse_monitor":                    workspaceLevel(catalog.ResourceLakehouseMonitor()),
+		"databricks_library":                              workspaceLevel(clusters.ResourceLibrary()),
 		"databricks_metastore":                            catalog.ResourceMetastore().ToResource(),
 		"databricks_metastore_assignment":                 catalog.ResourceMetastoreAssignment().ToResource(),
 		"databricks_metastore_data_access":                catalog.ResourceMetastoreDataAccess().ToResource(),
-		"databricks_mlflow_experiment":                    mlflow.ResourceMlflowExperiment().ToResource(),
-		"databricks_mlflow_model":                         mlflow.ResourceMlflowModel().ToResource(),
-		"databricks_mlflow_webhook":                       mlflow.ResourceMlflowWebhook().ToResource(),
-		"databricks_model_serving":                        serving.ResourceModelServing().ToResource(),
-		"databricks_model_serving_provisioned_throughput": serving.ResourceModelServingProvisionedThroughput().ToResource(),
-		"databricks_mount":                                storage.ResourceMount().ToResource(),
+		"databricks_mlflow_experiment":                    workspaceLevel(mlflow.ResourceMlflowExperiment()),
+		"databricks_mlflow_model":                         workspaceLevel(mlflow.ResourceMlflowModel()),
+		"databricks_mlflow_webhook":                       workspaceLevel(mlflow.ResourceMlflowWebhook()),
+		"databricks_model_serving":                        workspaceLevel(serving.ResourceModelServing()),
+		"databricks_model_serving_provisioned_throughput": workspaceLevel(serving.ResourceModelServingProvisionedThroughput()),
+		"databricks_mount":                                workspaceLevel(storage.ResourceMount()),
 		"databricks_mws_customer_managed_keys":            mws.ResourceMwsCustomerManagedKeys().ToResource(),
 		"databricks_mws_credentials":                      mws.ResourceMwsCredentials().ToResource(),
 		"databricks_mws_log_delivery":                     mws.ResourceMwsLogDelivery().ToResource(),
@@ -221,49 +229,50 @@ func DatabricksProvider(opts ...SdkV2ProviderOption) *schema.Provider {
 		"databricks_mws_storage_configurations":           mws.ResourceMwsStorageConfigurations().ToResource(),
 		"databricks_mws_vpc_endpoint":                     mws.ResourceMwsVpcEndpoint().ToResource(),
 		"databricks_mws_workspaces":                       mws.ResourceMwsWorkspaces().ToResource(),
-		"databricks_notebook":                             workspace.ResourceNotebook().ToResource(),
-		"databricks_notification_destination":             settings.ResourceNotificationDestination().ToResource(),
-		"databricks_obo_token":                            tokens.ResourceOboToken().ToResource(),
-		"databricks_online_table":                         catalog.ResourceOnlineTable().ToResource(),
+		"databricks_notebook":                             workspaceLevel(workspace.ResourceNotebook()),
+		"databricks_notification_destination":             workspaceLevel(settings.ResourceNotificationDestination()),
+		"databricks_obo_token":                            workspaceLevel(tokens.ResourceOboToken()),
+		"databricks_online_table":                         workspaceLevel(catalog.ResourceOnlineTable()),
 		"databricks_permission_assignment":                access.ResourcePermissionAssignment().ToResource(),
-		"databricks_permissions":                          permissions.ResourcePermissions().ToResource(),
-		"databricks_pipeline":                             pipelines.ResourcePipeline().ToResource(),
-		"databricks_provider":                             sharing.ResourceProvider().ToResource(),
-		"databricks_quality_monitor":                      catalog.ResourceQualityMonitor().ToResource(),
-		"databricks_query":                                sql.ResourceQuery().ToResource(),
-		"databricks_recipient":                            sharing.ResourceRecipient().ToResource(),
-		"databricks_registered_model":                     catalog.ResourceRegisteredModel().ToResource(),
-		"databricks_repo":                                 repos.ResourceRepo().ToResource(),
-		"databricks_schema":                               catalog.ResourceSchema().ToResource(),
-		"databricks_secret":                               secrets.ResourceSecret().ToResource(),
-		"databricks_secret_scope":                         secrets.ResourceSecretScope().ToResource(),
-		"databricks_secret_acl":                           secrets.ResourceSecretACL().ToResource(),
+		"databricks_permission":                           workspaceLevel(permissions.ResourcePermission()),
+		"databricks_permissions":                          workspaceLevel(permissions.ResourcePermissions()),
+		"databricks_pipeline":                             workspaceLevel(pipelines.ResourcePipeline()),
+		"databricks_provider":                             workspaceLevel(sharing.ResourceProvider()),
+		"databricks_quality_monitor":                      workspaceLevel(catalog.ResourceQualityMonitor()),
+		"databricks_query":                                workspaceLevel(sql.ResourceQuery()),
+		"databricks_recipient":                            workspaceLevel(sharing.ResourceRecipient()),
+		"databricks_registered_model":                     workspaceLevel(catalog.ResourceRegisteredModel()),
+		"databricks_repo":                                 workspaceLevel(repos.ResourceRepo()),
+		"databricks_schema":                               workspaceLevel(catalog.ResourceSchema()),
+		"databricks_secret":                               workspaceLevel(secrets.ResourceSecret()),
+		"databricks_secret_scope":                         workspaceLevel(secrets.ResourceSecretScope()),
+		"databricks_secret_acl":                           workspaceLevel(secrets.ResourceSecretACL()),
 		"databricks_service_principal":                    scim.ResourceServicePrincipal().ToResource(),
 		"databricks_service_principal_role":               aws.ResourceServicePrincipalRole().ToResource(),
 		"databricks_service_principal_secret":             tokens.ResourceServicePrincipalSecret().ToResource(),
-		"databricks_share":                                sharing.ResourceShare().ToResource(),
-		"databricks_sql_dashboard":                        sql.ResourceSqlDashboard().ToResource(),
-		"databricks_sql_endpoint":                         sql.ResourceSqlEndpoint().ToResource(),
-		"databricks_sql_global_config":                    sql.ResourceSqlGlobalConfig().ToResource(),
-		"databricks_sql_permissions":                      access.ResourceSqlPermissions().ToResource(),
-		"databricks_sql_query":                            sql.ResourceSqlQuery().ToResource(),
-		"databricks_sql_alert":                            sql.ResourceSqlAlert().ToResource(),
-		"databricks_sql_table":                            catalog.ResourceSqlTable().ToResource(),
-		"databricks_sql_visualization":                    sql.ResourceSqlVisualization().ToResource(),
-		"databricks_sql_widget":                           sql.ResourceSqlWidget().ToResource(),
+		"databricks_share":                                workspaceLevel(sharing.ResourceShare()),
+		"databricks_sql_dashboard":                        workspaceLevel(sql.ResourceSqlDashboard()),
+		"databricks_sql_endpoint":                         workspaceLevel(sql.ResourceSqlEndpoint()),
+		"databricks_sql_global_config":                    workspaceLevel(sql.ResourceSqlGlobalConfig()),
+		"databricks_sql_permissions":                      workspaceLevel(access.ResourceSqlPermissions()),
+		"databricks_sql_query":                            workspaceLevel(sql.ResourceSqlQuery()),
+		"databricks_sql_alert":                            workspaceLevel(sql.ResourceSqlAlert()),
+		"databricks_sql_table":                            workspaceLevel(catalog.ResourceSqlTable()),
+		"databricks_sql_visualization":                    workspaceLevel(sql.ResourceSqlVisualization()),
+		"databricks_sql_widget":                           workspaceLevel(sql.ResourceSqlWidget()),
 		"databricks_storage_credential":                   catalog.ResourceStorageCredential().ToResource(),
-		"databricks_system_schema":                        catalog.ResourceSystemSchema().ToResource(),
-		"databricks_table":                                catalog.ResourceTable().ToResource(),
-		"databricks_token":                                tokens.ResourceToken().ToResource(),
+		"databricks_system_schema":                        workspaceLevel(catalog.ResourceSystemSchema()),
+		"databricks_table":                                workspaceLevel(catalog.ResourceTable()),
+		"databricks_token":                                workspaceLevel(tokens.ResourceToken()),
 		"databricks_user":                                 scim.ResourceUser().ToResource(),
 		"databricks_user_instance_profile":                aws.ResourceUserInstanceProfile().ToResource(),
 		"databricks_user_role":                            aws.ResourceUserRole().ToResource(),
-		"databricks_vector_search_endpoint":               vectorsearch.ResourceVectorSearchEndpoint().ToResource(),
-		"databricks_vector_search_index":                  vectorsearch.ResourceVectorSearchIndex().ToResource(),
-		"databricks_volume":                               catalog.ResourceVolume().ToResource(),
-		"databricks_workspace_binding":                    catalog.ResourceWorkspaceBinding().ToResource(),
-		"databricks_workspace_conf":                       workspace.ResourceWorkspaceConf().ToResource(),
-		"databricks_workspace_file":                       workspace.ResourceWorkspaceFile().ToResource(),
+		"databricks_vector_search_endpoint":               workspaceLevel(vectorsearch.ResourceVectorSearchEndpoint()),
+		"databricks_vector_search_index":                  workspaceLevel(vectorsearch.ResourceVectorSearchIndex()),
+		"databricks_volume":                               workspaceLevel(catalog.ResourceVolume()),
+		"databricks_workspace_binding":                    workspaceLevel(catalog.ResourceWorkspaceBinding()),
+		"databricks_workspace_conf":                       workspaceLevel(workspace.ResourceWorkspaceConf()),
+		"databricks_workspace_file":                       workspaceLevel(workspace.ResourceWorkspaceFile()),
 	}
 
 	// Remove the resources and data sources that are being migrated to plugin framework
@@ -289,10 +298,19 @@ func DatabricksProvider(opts ...SdkV2ProviderOption) *schema.Provider {
 	for name, resource := range settings.AllSettingsResources() {
 		p.ResourcesMap[fmt.Sprintf("databricks_%s_setting", name)] = resource.ToResource()
 	}
+	// resource types of both providers are known only here, so the plugin framework provider doesn't validate them
+	knownResourceTypes := pluginfw.GetPluginFrameworkResourceNames()
+	for name := range p.ResourcesMap {
+		knownResourceTypes = append(knownResourceTypes, name)
+	}
 	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
 		if p.TerraformVersion != "" {
 			useragent.WithUserAgentExtra("terraform", p.TerraformVersion)
 		}
+		err := common.ValidateDeletionProtectedResourceTypes(deletionProtectedResourceTypes(d), knownResourceTypes)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 		logger.SetTfLogger(logger.NewTfLogger(ctx))
 		return ConfigureDatabricksClient(ctx, d, providerOptions.configCustomizer)
 	}
@@ -300,6 +318,13 @@ func DatabricksProvider(opts ...SdkV2ProviderOption) *schema.Provider {
 	return p
 }
 
+// workspaceLevel marks resources and data sources that are managed only through the workspace API,
+// so that they get the `workspace_id` attribute.
+func workspaceLevel(r common.Resource) *schema.Resource {
+	r.WorkspaceLevel = true
+	return r.ToResource()
+}
+
 func providerSchema() map[string]*schema.Schema {
 	kindMap := map[reflect.Kind]schema.ValueType{
 		reflect.String: schema.TypeString,
@@ -316,9 +341,108 @@ func providerSchema() map[string]*schema.Schema {
 		}
 		ps[attr.Name] = fieldSchema
 	}
+	ps[common.ReadOnlyField] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+	ps[common.DeletionProtectionField] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				common.DeletionProtectionResourceTypesField: {
+					Type:     schema.TypeSet,
+					Required: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+	ps[common.DefaultTagsField] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				common.DefaultTagsTagsField: {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+	retryClasses := map[string]*schema.Schema{}
+	for _, class := range common.RetryClasses {
+		retryClasses[string(class)] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					common.RetryMaxAttemptsField:       {Type: schema.TypeInt, Optional: true},
+					common.RetryMinBackoffSecondsField: {Type: schema.TypeInt, Optional: true},
+					common.RetryMaxBackoffSecondsField: {Type: schema.TypeInt, Optional: true},
+					common.RetryJitterField:            {Type: schema.TypeBool, Optional: true},
+				},
+			},
+		}
+	}
+	ps[common.RetryField] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: retryClasses,
+		},
+	}
 	return ps
 }
 
+// retryPolicies reads classes of errors configured in the `retry` block
+func retryPolicies(d *schema.ResourceData) (common.RetryPolicies, error) {
+	policies := common.RetryPolicies{}
+	for _, class := range common.RetryClasses {
+		prefix := common.RetryField + ".0." + string(class)
+		if _, ok := d.GetOk(prefix); !ok {
+			continue
+		}
+		prefix += ".0."
+		optionalInt := func(field string) *int64 {
+			// zero is a valid value, so GetOk can't tell it apart from an omitted field
+			if v, ok := d.GetOkExists(prefix + field); ok {
+				i := int64(v.(int))
+				return &i
+			}
+			return nil
+		}
+		var jitter *bool
+		if v, ok := d.GetOkExists(prefix + common.RetryJitterField); ok {
+			b := v.(bool)
+			jitter = &b
+		}
+		policy, err := common.NewRetryPolicy(class, optionalInt(common.RetryMaxAttemptsField),
+			optionalInt(common.RetryMinBackoffSecondsField), optionalInt(common.RetryMaxBackoffSecondsField), jitter)
+		if err != nil {
+			return nil, err
+		}
+		policies[class] = policy
+	}
+	return policies, nil
+}
+
+// deletionProtectedResourceTypes returns resource types from the `deletion_protection` block
+func deletionProtectedResourceTypes(d *schema.ResourceData) []string {
+	resourceTypes := []string{}
+	if v, ok := d.GetOk(common.DeletionProtectionField + ".0." + common.DeletionProtectionResourceTypesField); ok {
+		for _, resourceType := range v.(*schema.Set).List() {
+			resourceTypes = append(resourceTypes, resourceType.(string))
+		}
+	}
+	return resourceTypes
+}
+
 func ConfigureDatabricksClient(ctx context.Context, d *schema.ResourceData, configCustomizer func(*config.Config) error) (any, diag.Diagnostics) {
 	cfg := &config.Config{}
 	attrsUsed := []string{}
@@ -337,10 +461,31 @@ func ConfigureDatabricksClient(ctx context.Context, d *schema.ResourceData, conf
 	} else {
 		tflog.Info(ctx, "(sdkv2) No attributes specified in provider configuration")
 	}
+	policies, err := retryPolicies(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	readOnly, err := client.ResolveReadOnly(d.Get(common.ReadOnlyField).(bool))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if readOnly {
+		tflog.Info(ctx, "(sdkv2) Provider is in read-only mode")
+	}
+	common.EnableTransports(cfg, common.TransportOptions{RetryPolicies: policies, ReadOnly: readOnly})
 	databricksClient, err := client.PrepareDatabricksClient(ctx, cfg, configCustomizer)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+	if err := databricksClient.SetDeletionProtectedResourceTypes(deletionProtectedResourceTypes(d)); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defaultTags := map[string]string{}
+	for k, v := range d.Get(common.DefaultTagsField + ".0." + common.DefaultTagsTagsField).(map[string]any) {
+		defaultTags[k] = v.(string)
+	}
+	databricksClient.SetDefaultTags(defaultTags)
+	databricksClient.SetRetryPolicies(policies)
 	return databricksClient, nil
 }
 
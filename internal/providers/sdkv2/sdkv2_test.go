@@ -54,3 +54,10 @@ func TestConfigureDatabricksClient(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceIdOfDataSources(t *testing.T) {
+	p := DatabricksProvider()
+	assert.Contains(t, p.DataSourcesMap["databricks_permissions_audit"].Schema, common.WorkspaceIdField)
+	// databricks_bundle_job reads only the bundle configuration, so there is no workspace to route to
+	assert.NotContains(t, p.DataSourcesMap["databricks_bundle_job"].Schema, common.WorkspaceIdField)
+}
@@ -0,0 +1,429 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// bundleJobData parses the job definition from the Databricks Asset Bundle configuration
+type bundleJobData struct {
+	Content     string               `json:"content"`
+	JobKey      string               `json:"job_key"`
+	Target      string               `json:"target,omitempty"`
+	Variables   map[string]string    `json:"variables,omitempty"`
+	JSON        string               `json:"json,omitempty" tf:"computed"`
+	JobSettings *JobSettingsResource `json:"job_settings,omitempty" tf:"computed"`
+}
+
+func (bundleJobData) Aliases() map[string]map[string]string {
+	return jobsAliases
+}
+
+func (bundleJobData) CustomizeSchema(s *common.CustomizableSchema) *common.CustomizableSchema {
+	return s
+}
+
+func (bundleJobData) MaxDepthForTypes() map[string]int {
+	return JobSettingsResource{}.MaxDepthForTypes()
+}
+
+// keys used to merge lists of objects when applying target overrides
+var bundleMergeKeys = map[string]string{
+	"tasks":        "task_key",
+	"job_clusters": "job_cluster_key",
+	"environments": "environment_key",
+	"parameters":   "name",
+}
+
+// bundle-specific fields of the job definition that aren't part of job settings
+var bundleOnlyFields = []string{"permissions"}
+
+var bundleReferenceRegex = regexp.MustCompile(`\$\{([a-zA-Z0-9_.\-]+)\}`)
+
+// value that consists of exactly one reference, which is replaced with the typed value
+var bundleWholeReferenceRegex = regexp.MustCompile(`^\$\{([a-zA-Z0-9_.\-]+)\}$`)
+
+type bundleConfig struct {
+	Bundle struct {
+		Name string `yaml:"name"`
+	} `yaml:"bundle"`
+	Variables map[string]bundleVariable `yaml:"variables"`
+	Resources struct {
+		Jobs map[string]any `yaml:"jobs"`
+	} `yaml:"resources"`
+	Targets map[string]bundleTarget `yaml:"targets"`
+}
+
+type bundleVariable struct {
+	Default any `yaml:"default"`
+	Lookup  any `yaml:"lookup"`
+}
+
+type bundleTarget struct {
+	Default   bool           `yaml:"default"`
+	Variables map[string]any `yaml:"variables"`
+	Resources struct {
+		Jobs map[string]any `yaml:"jobs"`
+	} `yaml:"resources"`
+}
+
+// selectTarget returns the name of the target to use: either explicitly specified,
+// or the one marked as default in the bundle
+func (bc *bundleConfig) selectTarget(name string) (string, *bundleTarget, error) {
+	if name != "" {
+		target, ok := bc.Targets[name]
+		if !ok {
+			return "", nil, fmt.Errorf("target %s is not defined in the bundle", name)
+		}
+		return name, &target, nil
+	}
+	defaults := []string{}
+	for name, target := range bc.Targets {
+		if target.Default {
+			defaults = append(defaults, name)
+		}
+	}
+	switch len(defaults) {
+	case 0:
+		return "", nil, nil
+	case 1:
+		target := bc.Targets[defaults[0]]
+		return defaults[0], &target, nil
+	}
+	sort.Strings(defaults)
+	return "", nil, fmt.Errorf("multiple targets are marked as default: %s", strings.Join(defaults, ", "))
+}
+
+// resolveVariables returns values of bundle variables, where explicitly provided values take
+// precedence over target overrides, and target overrides take precedence over defaults.
+// Values defined in the bundle keep their YAML types.
+func (bc *bundleConfig) resolveVariables(target *bundleTarget, overrides map[string]string) map[string]any {
+	values := map[string]any{}
+	for name, v := range bc.Variables {
+		if v.Lookup != nil {
+			continue
+		}
+		if v.Default != nil {
+			values[name] = v.Default
+		}
+	}
+	if target != nil {
+		for name, raw := range target.Variables {
+			if m, ok := raw.(map[string]any); ok {
+				if _, ok := m["lookup"]; ok {
+					continue
+				}
+				raw = m["default"]
+			}
+			if raw != nil {
+				values[name] = raw
+			}
+		}
+	}
+	for name, value := range overrides {
+		values[name] = value
+	}
+	return values
+}
+
+// mergeBundleValues applies target override on top of the base definition. Maps are merged
+// recursively, lists of objects with known keys are merged by that key, other values are replaced.
+func mergeBundleValues(field string, base, override any) (any, error) {
+	switch o := override.(type) {
+	case map[string]any:
+		b, ok := base.(map[string]any)
+		if !ok {
+			return o, nil
+		}
+		merged := map[string]any{}
+		for k, v := range b {
+			merged[k] = v
+		}
+		for k, v := range o {
+			value, err := mergeBundleValues(k, merged[k], v)
+			if err != nil {
+				return nil, err
+			}
+			merged[k] = value
+		}
+		return merged, nil
+	case []any:
+		key, ok := bundleMergeKeys[field]
+		b, isList := base.([]any)
+		if !ok || !isList {
+			return o, nil
+		}
+		merged := append([]any{}, b...)
+		for _, item := range o {
+			m, ok := item.(map[string]any)
+			if !ok {
+				merged = append(merged, item)
+				continue
+			}
+			name, err := bundleMergeKey(field, key, m)
+			if err != nil {
+				return nil, err
+			}
+			found := false
+			for i, existing := range merged {
+				em, ok := existing.(map[string]any)
+				if !ok {
+					continue
+				}
+				existingName, err := bundleMergeKey(field, key, em)
+				if err != nil {
+					return nil, err
+				}
+				if name != "" && existingName == name {
+					merged[i], err = mergeBundleValues("", em, m)
+					if err != nil {
+						return nil, err
+					}
+					found = true
+					break
+				}
+			}
+			if !found {
+				merged = append(merged, m)
+			}
+		}
+		return merged, nil
+	}
+	return override, nil
+}
+
+// bundleMergeKey returns the value of the key, by which items of the list are merged, or an empty
+// string, if the item has no key
+func bundleMergeKey(field, key string, item map[string]any) (string, error) {
+	v, ok := item[key]
+	if !ok || v == nil {
+		return "", nil
+	}
+	name, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s: %s must be a string", field, key)
+	}
+	return name, nil
+}
+
+// interpolateBundleValues replaces `${var.<name>}`, `${bundle.name}` and `${bundle.target}`
+// references in all string values. A value that is exactly one reference gets the typed value
+// of the reference, references embedded in a larger string are substituted as strings.
+func interpolateBundleValues(v any, refs map[string]any) (any, error) {
+	switch x := v.(type) {
+	case string:
+		if m := bundleWholeReferenceRegex.FindStringSubmatch(x); m != nil {
+			if value, ok := refs[m[1]]; ok {
+				return value, nil
+			}
+		}
+		var err error
+		result := bundleReferenceRegex.ReplaceAllStringFunc(x, func(m string) string {
+			ref := bundleReferenceRegex.FindStringSubmatch(m)[1]
+			value, ok := refs[ref]
+			if ok {
+				return fmt.Sprint(value)
+			}
+			if strings.HasPrefix(ref, "var.") && err == nil {
+				err = fmt.Errorf("variable %s is not defined or has no value", strings.TrimPrefix(ref, "var."))
+			}
+			return m
+		})
+		return result, err
+	case map[string]any:
+		for k, item := range x {
+			value, err := interpolateBundleValues(item, refs)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			x[k] = value
+		}
+	case []any:
+		for i, item := range x {
+			value, err := interpolateBundleValues(item, refs)
+			if err != nil {
+				return nil, err
+			}
+			x[i] = value
+		}
+	}
+	return v, nil
+}
+
+// jsonFields returns struct fields by their JSON names, including fields of embedded structs,
+// where fields of the outer struct take precedence
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	embedded := []reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+			}
+			continue
+		}
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		fields[name] = field.Type
+	}
+	for _, et := range embedded {
+		for name, ft := range jsonFields(et) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = ft
+			}
+		}
+	}
+	return fields
+}
+
+// coerceBundleValue converts scalar values to the types of the corresponding fields of t, so that
+// variables passed as strings can be used in numeric or boolean fields and vice versa
+func coerceBundleValue(v any, t reflect.Type) any {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch x := v.(type) {
+	case map[string]any:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for k, item := range x {
+				if ft, ok := fields[k]; ok {
+					x[k] = coerceBundleValue(item, ft)
+				}
+			}
+		case reflect.Map:
+			for k, item := range x {
+				x[k] = coerceBundleValue(item, t.Elem())
+			}
+		}
+		return x
+	case []any:
+		if t.Kind() == reflect.Slice {
+			for i, item := range x {
+				x[i] = coerceBundleValue(item, t.Elem())
+			}
+		}
+		return x
+	case string:
+		switch t.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+			var parsed any
+			if err := yaml.Unmarshal([]byte(x), &parsed); err == nil {
+				switch parsed.(type) {
+				case bool, int, float64:
+					return parsed
+				}
+			}
+		}
+		return x
+	case bool, int, float64:
+		if t.Kind() == reflect.String {
+			return fmt.Sprint(x)
+		}
+	}
+	return v
+}
+
+// parseBundleJob returns job settings for the job defined in `resources.jobs.<jobKey>`
+// section of the bundle configuration
+func parseBundleJob(content, jobKey, targetName string, variables map[string]string) (*JobSettingsResource, error) {
+	var bc bundleConfig
+	err := yaml.Unmarshal([]byte(content), &bc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse bundle: %w", err)
+	}
+	targetName, target, err := bc.selectTarget(targetName)
+	if err != nil {
+		return nil, err
+	}
+	job, ok := bc.Resources.Jobs[jobKey]
+	if target != nil {
+		if override, ok := target.Resources.Jobs[jobKey]; ok {
+			job, err = mergeBundleValues("", job, override)
+			if err != nil {
+				return nil, fmt.Errorf("job %s: %w", jobKey, err)
+			}
+		}
+	}
+	if !ok && (target == nil || target.Resources.Jobs[jobKey] == nil) {
+		available := []string{}
+		for k := range bc.Resources.Jobs {
+			available = append(available, k)
+		}
+		sort.Strings(available)
+		return nil, fmt.Errorf("job %s is not defined in the bundle, available jobs: %s",
+			jobKey, strings.Join(available, ", "))
+	}
+	jobMap, ok := job.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("job %s must be a mapping", jobKey)
+	}
+	values := bc.resolveVariables(target, variables)
+	refs := map[string]any{
+		"bundle.name":   bc.Bundle.Name,
+		"bundle.target": targetName,
+	}
+	for name, value := range values {
+		refs["var."+name] = value
+	}
+	if _, err = interpolateBundleValues(jobMap, refs); err != nil {
+		return nil, fmt.Errorf("job %s: %w", jobKey, err)
+	}
+	for _, field := range bundleOnlyFields {
+		delete(jobMap, field)
+	}
+	coerceBundleValue(jobMap, reflect.TypeOf(JobSettingsResource{}))
+	raw, err := json.Marshal(jobMap)
+	if err != nil {
+		return nil, err
+	}
+	var js JobSettingsResource
+	err = json.Unmarshal(raw, &js)
+	if err != nil {
+		return nil, fmt.Errorf("job %s: %w", jobKey, err)
+	}
+	if js.Name == "" {
+		js.Name = jobKey
+	}
+	return &js, nil
+}
+
+func DataSourceBundleJob() common.Resource {
+	s := common.StructToSchema(bundleJobData{}, nil)
+	return common.Resource{
+		Schema: s,
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var data bundleJobData
+			common.DataToStructPointer(d, s, &data)
+			js, err := parseBundleJob(data.Content, data.JobKey, data.Target, data.Variables)
+			if err != nil {
+				return err
+			}
+			raw, err := json.Marshal(js)
+			if err != nil {
+				return err
+			}
+			data.JobSettings = js
+			data.JSON = string(raw)
+			d.SetId(data.JobKey)
+			return common.StructToData(data, s, d)
+		},
+	}
+}
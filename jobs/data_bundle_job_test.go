@@ -0,0 +1,219 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBundle = `
+bundle:
+  name: sales
+
+variables:
+  catalog:
+    default: dev_catalog
+  warehouse_id:
+    description: SQL warehouse
+
+resources:
+  jobs:
+    etl:
+      name: "[${bundle.target}] ${bundle.name} ETL"
+      max_concurrent_runs: 1
+      permissions:
+        - level: CAN_VIEW
+          group_name: users
+      tasks:
+        - task_key: ingest
+          notebook_task:
+            notebook_path: ./ingest.py
+            base_parameters:
+              catalog: ${var.catalog}
+        - task_key: report
+          depends_on:
+            - task_key: ingest
+          sql_task:
+            warehouse_id: ${var.warehouse_id}
+            query:
+              query_id: abc
+
+targets:
+  dev:
+    default: true
+    variables:
+      warehouse_id: dev_wh
+  prod:
+    variables:
+      catalog: prod_catalog
+      warehouse_id:
+        default: prod_wh
+    resources:
+      jobs:
+        etl:
+          max_concurrent_runs: 2
+          tasks:
+            - task_key: ingest
+              timeout_seconds: 3600
+            - task_key: cleanup
+              notebook_task:
+                notebook_path: ./cleanup.py
+`
+
+func TestParseBundleJobDefaultTarget(t *testing.T) {
+	js, err := parseBundleJob(testBundle, "etl", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "[dev] sales ETL", js.Name)
+	assert.Equal(t, 1, js.MaxConcurrentRuns)
+	require.Len(t, js.Tasks, 2)
+	assert.Equal(t, "dev_catalog", js.Tasks[0].NotebookTask.BaseParameters["catalog"])
+	assert.Equal(t, "dev_wh", js.Tasks[1].SqlTask.WarehouseId)
+}
+
+func TestParseBundleJobTargetOverrides(t *testing.T) {
+	js, err := parseBundleJob(testBundle, "etl", "prod", map[string]string{
+		"warehouse_id": "explicit_wh",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "[prod] sales ETL", js.Name)
+	assert.Equal(t, 2, js.MaxConcurrentRuns)
+	require.Len(t, js.Tasks, 3)
+	assert.Equal(t, "ingest", js.Tasks[0].TaskKey)
+	assert.Equal(t, "prod_catalog", js.Tasks[0].NotebookTask.BaseParameters["catalog"])
+	assert.Equal(t, 3600, js.Tasks[0].TimeoutSeconds)
+	assert.Equal(t, "explicit_wh", js.Tasks[1].SqlTask.WarehouseId)
+	assert.Equal(t, "cleanup", js.Tasks[2].TaskKey)
+}
+
+const testTypedVariablesBundle = `
+variables:
+  runs:
+    default: 3
+  queued:
+    default: true
+  warehouse_id:
+    default: 1234
+
+resources:
+  jobs:
+    etl:
+      name: etl-${var.runs}
+      max_concurrent_runs: ${var.runs}
+      queue:
+        enabled: ${var.queued}
+      tasks:
+        - task_key: report
+          sql_task:
+            warehouse_id: ${var.warehouse_id}
+            query:
+              query_id: abc
+`
+
+func TestParseBundleJobTypedVariables(t *testing.T) {
+	js, err := parseBundleJob(testTypedVariablesBundle, "etl", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "etl-3", js.Name)
+	assert.Equal(t, 3, js.MaxConcurrentRuns)
+	require.NotNil(t, js.Queue)
+	assert.True(t, js.Queue.Enabled)
+	require.Len(t, js.Tasks, 1)
+	assert.Equal(t, "1234", js.Tasks[0].SqlTask.WarehouseId)
+
+	js, err = parseBundleJob(testTypedVariablesBundle, "etl", "", map[string]string{
+		"runs":         "5",
+		"queued":       "false",
+		"warehouse_id": "0042",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "etl-5", js.Name)
+	assert.Equal(t, 5, js.MaxConcurrentRuns)
+	require.NotNil(t, js.Queue)
+	assert.False(t, js.Queue.Enabled)
+	assert.Equal(t, "0042", js.Tasks[0].SqlTask.WarehouseId)
+}
+
+func TestParseBundleJobMultipleDefaultTargets(t *testing.T) {
+	_, err := parseBundleJob(`
+resources:
+  jobs:
+    etl:
+      name: ETL
+targets:
+  dev:
+    default: true
+  staging:
+    default: true
+`, "etl", "", nil)
+	assert.EqualError(t, err, "multiple targets are marked as default: dev, staging")
+}
+
+func TestParseBundleJobErrors(t *testing.T) {
+	_, err := parseBundleJob(testBundle, "unknown", "", nil)
+	assert.EqualError(t, err, "job unknown is not defined in the bundle, available jobs: etl")
+
+	_, err = parseBundleJob(testBundle, "etl", "staging", nil)
+	assert.EqualError(t, err, "target staging is not defined in the bundle")
+
+	_, err = parseBundleJob(`
+resources:
+  jobs:
+    etl:
+      name: ${var.missing}
+`, "etl", "", nil)
+	assert.EqualError(t, err, "job etl: name: variable missing is not defined or has no value")
+
+	_, err = parseBundleJob(`
+resources:
+  jobs:
+    etl:
+      tasks:
+        - task_key: ingest
+targets:
+  dev:
+    default: true
+    resources:
+      jobs:
+        etl:
+          tasks:
+            - task_key:
+                name: ingest
+`, "etl", "", nil)
+	assert.EqualError(t, err, "job etl: tasks: task_key must be a string")
+
+	_, err = parseBundleJob(`resources: [`, "etl", "", nil)
+	assert.ErrorContains(t, err, "cannot parse bundle")
+}
+
+func TestDataSourceBundleJob(t *testing.T) {
+	qa.ResourceFixture{
+		Resource:    DataSourceBundleJob(),
+		Read:        true,
+		New:         true,
+		NonWritable: true,
+		HCL: `
+		content = <<EOT
+resources:
+  jobs:
+    etl:
+      name: ETL
+      tasks:
+        - task_key: ingest
+          notebook_task:
+            notebook_path: /Shared/ingest
+            base_parameters:
+              catalog: ${var.catalog}
+EOT
+		job_key = "etl"
+		variables = {
+			catalog = "main"
+		}`,
+		ID: "etl",
+	}.ApplyAndExpectData(t, map[string]any{
+		"job_settings.0.name":                                           "ETL",
+		"job_settings.0.task.0.task_key":                                "ingest",
+		"job_settings.0.task.0.notebook_task.0.base_parameters.catalog": "main",
+		"json": `{"name":"ETL","tasks":[{"notebook_task":{"base_parameters":{"catalog":"main"},"notebook_path":"/Shared/ingest"},"task_key":"ingest"}]}`,
+	})
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/databricks/terraform-provider-databricks/clusters"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/repos"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // NotebookTask contains the information for notebook jobs
@@ -718,7 +720,12 @@ func (a JobsAPI) RunsCancel(runID int64, timeout time.Duration) error {
 	return a.waitForRunState(runID, "TERMINATED", timeout)
 }
 
-func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.Duration) error {
+func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.Duration) (err error) {
+	var span trace.Span
+	a.context, span = common.StartSpan(a.context, "waitForRunState",
+		attribute.Int64("databricks.run_id", runID),
+		attribute.String("databricks.desired_state", desiredState))
+	defer func() { common.EndSpan(span, err) }()
 	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
 		jobRun, err := a.RunsGet(runID)
 		if err != nil {
@@ -1068,6 +1075,8 @@ func ResourceJob() common.Resource {
 	return common.Resource{
 		Schema:        jobsGoSdkSchema,
 		SchemaVersion: 2,
+		// tags of the job are also added to job clusters
+		TagsPath: []string{"tags"},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(clusters.DefaultProvisionTimeout),
 			Update: schema.DefaultTimeout(clusters.DefaultProvisionTimeout),
@@ -1101,6 +1110,7 @@ func ResourceJob() common.Resource {
 				}
 				var cj JobCreateStruct
 				common.DataToStructPointer(d, jobsGoSdkSchema, &cj)
+				cj.Tags = c.MergeDefaultTags(cj.Tags)
 				err = prepareJobSettingsForCreateGoSdk(d, &cj)
 				if err != nil {
 					return err
@@ -1116,6 +1126,7 @@ func ResourceJob() common.Resource {
 				// TODO: Deprecate and remove this code path
 				var js JobSettings
 				common.DataToStructPointer(d, jobsGoSdkSchema, &js)
+				js.Tags = c.MergeDefaultTags(js.Tags)
 
 				jobsAPI := NewJobsAPI(ctx, c)
 				job, err := jobsAPI.Create(js)
@@ -1165,6 +1176,7 @@ func ResourceJob() common.Resource {
 			common.DataToStructPointer(d, jobsGoSdkSchema, &jsr)
 			if jsr.isMultiTask() {
 				// Api 2.1
+				jsr.Tags = c.MergeDefaultTags(jsr.Tags)
 				err := prepareJobSettingsForUpdateGoSdk(d, &jsr)
 				if err != nil {
 					return err
@@ -1187,6 +1199,7 @@ func ResourceJob() common.Resource {
 				// TODO: Deprecate and remove this code path
 				var js JobSettings
 				common.DataToStructPointer(d, jobsGoSdkSchema, &js)
+				js.Tags = c.MergeDefaultTags(js.Tags)
 
 				prepareJobSettingsForUpdate(d, js)
 
@@ -1210,6 +1223,7 @@ func ResourceJob() common.Resource {
 			}
 			return w.Jobs.DeleteByJobId(ctx, jobID)
 		},
+		WithIdentity: true,
 	}
 }
 
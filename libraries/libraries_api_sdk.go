@@ -11,12 +11,17 @@ import (
 	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Given a compute.Wait struct, returns library statuses based on the input parameter.
 // If wait.IsRunning is set to true, this function will wait until all of the libraries are installed to return. Otherwise, it will directly return the list of libraries.
 func WaitForLibrariesInstalledSdk(ctx context.Context, w *databricks.WorkspaceClient, wait compute.Wait, timeout time.Duration) (result *compute.ClusterLibraryStatuses, err error) {
+	ctx, span := common.StartSpan(ctx, "WaitForLibrariesInstalledSdk",
+		attribute.String("databricks.cluster_id", wait.ClusterID))
+	defer func() { common.EndSpan(span, err) }()
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		libsClusterStatus, err := w.Libraries.ClusterStatusByClusterId(ctx, wait.ClusterID)
 		if err != nil {
@@ -38,6 +38,10 @@ func main() {
 	log.Printf(startMessageFormat, common.Version())
 
 	ctx := context.Background()
+	shutdownTracing, err := common.InitTracing(ctx)
+	if err != nil {
+		log.Printf("[WARN] Tracing is disabled: %s", err)
+	}
 	providerServer, err := providers.GetProviderServer(ctx)
 	if err != nil {
 		log.Fatal(err)
@@ -53,6 +57,10 @@ func main() {
 		func() tfprotov6.ProviderServer { return providerServer },
 		serveOpts...,
 	)
+	// spans of the last operations are exported only on shutdown
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("[WARN] Cannot export traces: %s", shutdownErr)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -23,6 +23,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // DefaultProvisionTimeout is the amount of minutes terraform will wait
@@ -179,11 +181,17 @@ func (a WorkspacesAPI) Create(ws *Workspace, timeout time.Duration) error {
 // generateWorkspaceHostname computes the hostname for the specified workspace,
 // given the account console hostname.
 func generateWorkspaceHostname(client *common.DatabricksClient, ws Workspace) string {
-	u, err := url.Parse(client.Config.Host)
+	return WorkspaceHostname(client.Config.Host, ws.DeploymentName)
+}
+
+// WorkspaceHostname computes the hostname of the workspace with the given deployment name
+// from the account console host.
+func WorkspaceHostname(accountsHost, deploymentName string) string {
+	u, err := url.Parse(accountsHost)
 	if err != nil {
 		// Fallback.
 		log.Printf("[WARN] Unable to parse URL from client host: %v", err)
-		return ws.DeploymentName + ".cloud.databricks.com"
+		return deploymentName + ".cloud.databricks.com"
 	}
 
 	// We expect the account console hostname to be of the form `accounts.foo[.bar]...`
@@ -193,9 +201,9 @@ func generateWorkspaceHostname(client *common.DatabricksClient, ws Workspace) st
 	if len(chunks) == 0 || net.ParseIP(u.Hostname()) != nil {
 		// Fallback.
 		log.Printf("[WARN] Unable to split client host: %v", u.Hostname())
-		return ws.DeploymentName + ".cloud.databricks.com"
+		return deploymentName + ".cloud.databricks.com"
 	}
-	chunks[0] = ws.DeploymentName
+	chunks[0] = deploymentName
 	return strings.Join(chunks, ".")
 }
 
@@ -240,7 +248,11 @@ func (a WorkspacesAPI) explainWorkspaceFailure(ws Workspace) error {
 }
 
 // WaitForRunning will wait until workspace is running, otherwise will try to explain why it failed
-func (a WorkspacesAPI) WaitForRunning(ws Workspace, timeout time.Duration) error {
+func (a WorkspacesAPI) WaitForRunning(ws Workspace, timeout time.Duration) (err error) {
+	var span trace.Span
+	a.context, span = common.StartSpan(a.context, "WaitForRunning",
+		attribute.Int64("databricks.workspace_id", ws.WorkspaceID))
+	defer func() { common.EndSpan(span, err) }()
 	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
 		workspace, err := a.Read(ws.AccountID, fmt.Sprintf("%d", ws.WorkspaceID))
 		if err != nil {
@@ -1514,6 +1514,13 @@ func TestGenerateWorkspaceHostname_CornerCases(t *testing.T) {
 		}))
 }
 
+func TestWorkspaceHostname(t *testing.T) {
+	assert.Equal(t, "stuff.cloud.databricks.com",
+		WorkspaceHostname("https://accounts.cloud.databricks.com", "stuff"))
+	assert.Equal(t, "stuff.cloud.databricks.com",
+		WorkspaceHostname("https://127.0.0.1", "stuff"))
+}
+
 func TestExplainWorkspaceFailureCornerCase(t *testing.T) {
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
 		{
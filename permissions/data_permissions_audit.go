@@ -0,0 +1,200 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/permissions/entity"
+)
+
+// PermissionsAuditEntry is a permission level of a principal on a single object
+type PermissionsAuditEntry struct {
+	ObjectType      string `json:"object_type"`
+	ObjectId        string `json:"object_id"`
+	PrincipalType   string `json:"principal_type"`
+	Principal       string `json:"principal"`
+	PermissionLevel string `json:"permission_level"`
+	Inherited       bool   `json:"inherited"`
+}
+
+type permissionsAuditData struct {
+	ObjectTypes   []string                `json:"object_types" tf:"slice_set"`
+	Principal     string                  `json:"principal,omitempty"`
+	WorkspacePath string                  `json:"workspace_path,omitempty" tf:"default:/"`
+	Permissions   []PermissionsAuditEntry `json:"permissions,omitempty" tf:"computed"`
+}
+
+// workspaceTree lists the workspace tree under the root path once per audit, as notebooks,
+// directories and files are found by the same walk
+type workspaceTree struct {
+	root    string
+	objects []workspace.ObjectInfo
+	listed  bool
+}
+
+func (t *workspaceTree) list(ctx context.Context, w *databricks.WorkspaceClient) ([]workspace.ObjectInfo, error) {
+	if t.listed {
+		return t.objects, nil
+	}
+	root, err := w.Workspace.GetStatusByPath(ctx, t.root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get status of %s: %w", t.root, err)
+	}
+	objects := []workspace.ObjectInfo{*root}
+	queue := []string{}
+	if root.ObjectType == workspace.ObjectTypeDirectory {
+		queue = append(queue, t.root)
+	}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		batch, err := w.Workspace.ListAll(ctx, workspace.ListWorkspaceRequest{Path: path})
+		if apierr.IsMissing(err) {
+			// directory was removed after it was listed
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot list %s: %w", path, err)
+		}
+		for _, object := range batch {
+			objects = append(objects, object)
+			if object.ObjectType == workspace.ObjectTypeDirectory {
+				queue = append(queue, object.Path)
+			}
+		}
+	}
+	t.objects, t.listed = objects, true
+	return objects, nil
+}
+
+// ids returns IDs of workspace objects of the given type
+func (t *workspaceTree) ids(ctx context.Context, w *databricks.WorkspaceClient, objectType workspace.ObjectType) ([]string, error) {
+	objects, err := t.list(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, object := range objects {
+		if object.ObjectType == objectType {
+			ids = append(ids, strconv.FormatInt(object.ObjectId, 10))
+		}
+	}
+	return ids, nil
+}
+
+// auditableObjectTypes returns request object types, which objects could be listed, with their mappings
+func auditableObjectTypes() map[string][]resourcePermissions {
+	types := map[string][]resourcePermissions{}
+	for _, mapping := range allResourcePermissions() {
+		if mapping.listObjectIds == nil && mapping.workspaceObjectType == "" {
+			continue
+		}
+		types[mapping.requestObjectType] = append(types[mapping.requestObjectType], mapping)
+	}
+	return types
+}
+
+func auditAccessControl(mapping resourcePermissions, objectID string, principal string,
+	acl iam.AccessControlResponse) (entries []PermissionsAuditEntry) {
+	principalType, name := "user", acl.UserName
+	if acl.GroupName != "" {
+		principalType, name = "group", acl.GroupName
+	} else if acl.ServicePrincipalName != "" {
+		principalType, name = "service_principal", acl.ServicePrincipalName
+	}
+	if principal != "" && principal != name {
+		return
+	}
+	for _, permission := range acl.AllPermissions {
+		entries = append(entries, PermissionsAuditEntry{
+			ObjectType:      mapping.objectType,
+			ObjectId:        objectID,
+			PrincipalType:   principalType,
+			Principal:       name,
+			PermissionLevel: permission.PermissionLevel.String(),
+			Inherited:       permission.Inherited,
+		})
+	}
+	return
+}
+
+func auditObjectType(ctx context.Context, w *databricks.WorkspaceClient, mapping resourcePermissions,
+	principal string, tree *workspaceTree) (entries []PermissionsAuditEntry, err error) {
+	var ids []string
+	if mapping.workspaceObjectType != "" {
+		ids, err = tree.ids(ctx, w, mapping.workspaceObjectType)
+	} else {
+		ids, err = mapping.listObjectIds(ctx, w)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot list %s: %w", mapping.requestObjectType, err)
+	}
+	for _, id := range ids {
+		objectID := fmt.Sprintf("/%s/%s", mapping.requestObjectType, id)
+		acl, err := readObjectPermissions(ctx, w, objectID, mapping)
+		if apierr.IsMissing(err) {
+			// object was removed after it was listed
+			log.Printf("[WARN] skipping permissions of %s: %s", objectID, err)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read permissions of %s: %w", objectID, err)
+		}
+		// the same customizers as in databricks_permissions, so that levels and object types are reported
+		// as they are read by the resource, that has no permissions in its state yet
+		customized, err := mapping.customizeReadResponse(objectID, acl, entity.PermissionsEntity{})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read permissions of %s: %w", objectID, err)
+		}
+		for _, accessControl := range customized.AccessControlList {
+			entries = append(entries, auditAccessControl(mapping, objectID, principal, accessControl)...)
+		}
+	}
+	return entries, nil
+}
+
+// DataSourcePermissionsAudit lists permissions of all principals, or the given principal,
+// on all objects of the given types in the workspace
+func DataSourcePermissionsAudit() common.Resource {
+	return common.WorkspaceData(func(ctx context.Context, data *permissionsAuditData, w *databricks.WorkspaceClient) error {
+		auditable := auditableObjectTypes()
+		tree := &workspaceTree{root: data.WorkspacePath}
+		data.Permissions = []PermissionsAuditEntry{}
+		for _, objectType := range data.ObjectTypes {
+			mappings, ok := auditable[objectType]
+			if !ok {
+				supported := make([]string, 0, len(auditable))
+				for k := range auditable {
+					supported = append(supported, k)
+				}
+				sort.Strings(supported)
+				return fmt.Errorf("object type %s is not supported, supported types: %s",
+					objectType, strings.Join(supported, ", "))
+			}
+			for _, mapping := range mappings {
+				entries, err := auditObjectType(ctx, w, mapping, data.Principal, tree)
+				if err != nil {
+					return err
+				}
+				data.Permissions = append(data.Permissions, entries...)
+			}
+		}
+		sort.SliceStable(data.Permissions, func(i, j int) bool {
+			a, b := data.Permissions[i], data.Permissions[j]
+			if a.ObjectId != b.ObjectId {
+				return a.ObjectId < b.ObjectId
+			}
+			return a.Principal < b.Principal
+		})
+		return nil
+	})
+}
@@ -0,0 +1,367 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/databricks-sdk-go/service/ml"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/permissions/read"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataSourcePermissionsAudit(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			mwc.GetMockClustersAPI().EXPECT().ListAll(mock.Anything, compute.ListClustersRequest{}).Return([]compute.ClusterDetails{
+				{ClusterId: "abc"},
+				{ClusterId: "removed"},
+			}, nil)
+			mwc.GetMockJobsAPI().EXPECT().ListAll(mock.Anything, jobs.ListJobsRequest{}).Return([]jobs.BaseJob{
+				{JobId: 123},
+			}, nil)
+			e := mwc.GetMockPermissionsAPI().EXPECT()
+			e.Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "abc",
+				RequestObjectType: "clusters",
+			}).Return(&iam.ObjectPermissions{
+				ObjectId:   "/clusters/abc",
+				ObjectType: "cluster",
+				AccessControlList: []iam.AccessControlResponse{
+					{
+						GroupName: "data-engineers",
+						AllPermissions: []iam.Permission{
+							{PermissionLevel: "CAN_RESTART"},
+						},
+					},
+					{
+						GroupName: "admins",
+						AllPermissions: []iam.Permission{
+							{PermissionLevel: "CAN_MANAGE", Inherited: true},
+						},
+					},
+				},
+			}, nil)
+			e.Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "removed",
+				RequestObjectType: "clusters",
+			}).Return(nil, &apierr.APIError{
+				StatusCode: 400,
+				ErrorCode:  "INVALID_STATE",
+				Message:    "Cannot access cluster removed that was terminated or unpinned more than 30 days ago.",
+			})
+			e.Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "123",
+				RequestObjectType: "jobs",
+			}).Return(&iam.ObjectPermissions{
+				ObjectId:   "/jobs/123",
+				ObjectType: "job",
+				AccessControlList: []iam.AccessControlResponse{
+					{
+						ServicePrincipalName: "a9b8c7",
+						AllPermissions: []iam.Permission{
+							{PermissionLevel: "IS_OWNER"},
+						},
+					},
+					{
+						GroupName: "data-engineers",
+						AllPermissions: []iam.Permission{
+							{PermissionLevel: "CAN_VIEW"},
+							{PermissionLevel: "CAN_MANAGE_RUN"},
+						},
+					},
+				},
+			}, nil)
+		},
+		Resource:    DataSourcePermissionsAudit(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		object_types = ["clusters", "jobs"]
+		principal = "data-engineers"
+		`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{
+			"object_type":      "cluster",
+			"object_id":        "/clusters/abc",
+			"principal_type":   "group",
+			"principal":        "data-engineers",
+			"permission_level": "CAN_RESTART",
+			"inherited":        false,
+		},
+		map[string]any{
+			"object_type":      "job",
+			"object_id":        "/jobs/123",
+			"principal_type":   "group",
+			"principal":        "data-engineers",
+			"permission_level": "CAN_VIEW",
+			"inherited":        false,
+		},
+		map[string]any{
+			"object_type":      "job",
+			"object_id":        "/jobs/123",
+			"principal_type":   "group",
+			"principal":        "data-engineers",
+			"permission_level": "CAN_MANAGE_RUN",
+			"inherited":        false,
+		},
+	}, d.Get("permissions"))
+}
+
+func TestDataSourcePermissionsAudit_AllPrincipals(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			mwc.GetMockPermissionsAPI().EXPECT().Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "tokens",
+				RequestObjectType: "authorization",
+			}).Return(&iam.ObjectPermissions{
+				ObjectId:   "/authorization/tokens",
+				ObjectType: "tokens",
+				AccessControlList: []iam.AccessControlResponse{
+					{
+						UserName: "someone@example.com",
+						AllPermissions: []iam.Permission{
+							{PermissionLevel: "CAN_USE"},
+						},
+					},
+					{
+						GroupName: "admins",
+						AllPermissions: []iam.Permission{
+							{PermissionLevel: "CAN_MANAGE"},
+						},
+					},
+				},
+			}, nil)
+			mwc.GetMockPermissionsAPI().EXPECT().Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "passwords",
+				RequestObjectType: "authorization",
+			}).Return(&iam.ObjectPermissions{
+				ObjectId:          "/authorization/passwords",
+				ObjectType:        "passwords",
+				AccessControlList: []iam.AccessControlResponse{},
+			}, nil)
+		},
+		Resource:    DataSourcePermissionsAudit(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `object_types = ["authorization"]`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, d.Get("permissions.#"))
+	assert.Equal(t, "admins", d.Get("permissions.0.principal"))
+	assert.Equal(t, "CAN_MANAGE", d.Get("permissions.0.permission_level"))
+	assert.Equal(t, "user", d.Get("permissions.1.principal_type"))
+	assert.Equal(t, "someone@example.com", d.Get("permissions.1.principal"))
+}
+
+func TestDataSourcePermissionsAudit_WorkspaceObjects(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			we := mwc.GetMockWorkspaceAPI().EXPECT()
+			// the tree is walked once for all workspace object types
+			we.GetStatusByPath(mock.Anything, "/Shared").Return(&workspace.ObjectInfo{
+				ObjectId:   1,
+				ObjectType: workspace.ObjectTypeDirectory,
+				Path:       "/Shared",
+			}, nil).Once()
+			we.ListAll(mock.Anything, workspace.ListWorkspaceRequest{Path: "/Shared"}).Return([]workspace.ObjectInfo{
+				{ObjectId: 2, ObjectType: workspace.ObjectTypeNotebook, Path: "/Shared/etl"},
+				{ObjectId: 3, ObjectType: workspace.ObjectTypeDirectory, Path: "/Shared/team"},
+			}, nil).Once()
+			we.ListAll(mock.Anything, workspace.ListWorkspaceRequest{Path: "/Shared/team"}).Return([]workspace.ObjectInfo{
+				{ObjectId: 4, ObjectType: workspace.ObjectTypeFile, Path: "/Shared/team/data.csv"},
+				{ObjectId: 5, ObjectType: workspace.ObjectTypeNotebook, Path: "/Shared/team/report"},
+			}, nil).Once()
+			e := mwc.GetMockPermissionsAPI().EXPECT()
+			for _, object := range []struct {
+				id, requestType, objectType string
+			}{
+				{"2", "notebooks", "notebook"},
+				{"5", "notebooks", "notebook"},
+				{"1", "directories", "directory"},
+				{"3", "directories", "directory"},
+			} {
+				e.Get(mock.Anything, iam.GetPermissionRequest{
+					RequestObjectId:   object.id,
+					RequestObjectType: object.requestType,
+				}).Return(&iam.ObjectPermissions{
+					ObjectId:   fmt.Sprintf("/%s/%s", object.requestType, object.id),
+					ObjectType: object.objectType,
+					AccessControlList: []iam.AccessControlResponse{
+						{
+							GroupName: "data-engineers",
+							AllPermissions: []iam.Permission{
+								{PermissionLevel: "CAN_RUN", Inherited: object.id != "1"},
+							},
+						},
+					},
+				}, nil)
+			}
+		},
+		Resource:    DataSourcePermissionsAudit(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		object_types = ["notebooks", "directories"]
+		workspace_path = "/Shared"
+		`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, d.Get("permissions.#"))
+	assert.Equal(t, "/directories/1", d.Get("permissions.0.object_id"))
+	assert.Equal(t, false, d.Get("permissions.0.inherited"))
+	assert.Equal(t, "/directories/3", d.Get("permissions.1.object_id"))
+	assert.Equal(t, "/notebooks/2", d.Get("permissions.2.object_id"))
+	assert.Equal(t, "notebook", d.Get("permissions.2.object_type"))
+	assert.Equal(t, "/notebooks/5", d.Get("permissions.3.object_id"))
+}
+
+func TestDataSourcePermissionsAudit_RegisteredModels(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			me := mwc.GetMockModelRegistryAPI().EXPECT()
+			me.ListModelsAll(mock.Anything, ml.ListModelsRequest{}).Return([]ml.Model{
+				{Name: "churn"},
+			}, nil)
+			me.GetModel(mock.Anything, ml.GetModelRequest{Name: "churn"}).Return(&ml.GetModelResponse{
+				RegisteredModelDatabricks: &ml.ModelDatabricks{Id: "m1", Name: "churn"},
+			}, nil)
+			mwc.GetMockPermissionsAPI().EXPECT().Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "m1",
+				RequestObjectType: "registered-models",
+			}).Return(&iam.ObjectPermissions{
+				ObjectId:   "/registered-models/m1",
+				ObjectType: "registered-model",
+				AccessControlList: []iam.AccessControlResponse{
+					{
+						UserName: "someone@example.com",
+						AllPermissions: []iam.Permission{
+							{PermissionLevel: "CAN_MANAGE_STAGING_VERSIONS"},
+						},
+					},
+				},
+			}, nil)
+		},
+		Resource:    DataSourcePermissionsAudit(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `object_types = ["registered-models"]`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, d.Get("permissions.#"))
+	assert.Equal(t, "/registered-models/m1", d.Get("permissions.0.object_id"))
+	assert.Equal(t, "CAN_MANAGE_STAGING_VERSIONS", d.Get("permissions.0.permission_level"))
+}
+
+func TestDataSourcePermissionsAudit_SqlDashboards(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			mwc.GetMockDashboardsAPI().EXPECT().ListAll(mock.Anything, sql.ListDashboardsRequest{}).Return(
+				[]sql.Dashboard{{Id: "d1"}}, nil)
+			mwc.GetMockPermissionsAPI().EXPECT().Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "d1",
+				RequestObjectType: "dbsql-dashboards",
+			}).Return(&iam.ObjectPermissions{
+				ObjectId:   "dashboards/d1",
+				ObjectType: "dbsql-dashboard",
+				AccessControlList: []iam.AccessControlResponse{
+					{
+						GroupName: "analysts",
+						AllPermissions: []iam.Permission{
+							{PermissionLevel: "CAN_READ"},
+						},
+					},
+				},
+			}, nil)
+		},
+		Resource:    DataSourcePermissionsAudit(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `object_types = ["dbsql-dashboards"]`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, d.Get("permissions.#"))
+	assert.Equal(t, "dashboard", d.Get("permissions.0.object_type"))
+	assert.Equal(t, "/dbsql-dashboards/d1", d.Get("permissions.0.object_id"))
+	assert.Equal(t, "CAN_READ", d.Get("permissions.0.permission_level"))
+}
+
+func TestAuditObjectTypeAppliesReadCustomizers(t *testing.T) {
+	mwc := mocks.NewMockWorkspaceClient(t)
+	mwc.GetMockPermissionsAPI().EXPECT().Get(mock.Anything, iam.GetPermissionRequest{
+		RequestObjectId:   "q1",
+		RequestObjectType: "sql/queries",
+	}).Return(&iam.ObjectPermissions{
+		ObjectId:   "queries/q1",
+		ObjectType: "legacy-query",
+		AccessControlList: []iam.AccessControlResponse{
+			{
+				UserName: "abc@example.com",
+				AllPermissions: []iam.Permission{
+					{PermissionLevel: "CAN_VIEW"},
+				},
+			},
+		},
+	}, nil)
+	mapping := resourcePermissions{
+		objectType:        "query",
+		requestObjectType: "sql/queries",
+		readAclCustomizers: []read.ACLCustomizer{
+			func(ctx read.ACLCustomizerContext, acl iam.ObjectPermissions) iam.ObjectPermissions {
+				assert.Equal(t, "/sql/queries/q1", ctx.GetId())
+				assert.Empty(t, ctx.GetExistingPermissionsEntity().AccessControlList)
+				acl.ObjectType = "query"
+				acl.AccessControlList[0].AllPermissions[0].PermissionLevel = "CAN_READ"
+				return acl
+			},
+		},
+		listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+			return []string{"q1"}, nil
+		},
+	}
+	entries, err := auditObjectType(context.Background(), mwc.WorkspaceClient, mapping, "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []PermissionsAuditEntry{
+		{
+			ObjectType:      "query",
+			ObjectId:        "/sql/queries/q1",
+			PrincipalType:   "user",
+			Principal:       "abc@example.com",
+			PermissionLevel: "CAN_READ",
+		},
+	}, entries)
+
+	mapping.readAclCustomizers = nil
+	_, err = auditObjectType(context.Background(), mwc.WorkspaceClient, mapping, "", nil)
+	assert.EqualError(t, err, "cannot read permissions of /sql/queries/q1: expected object type query, got legacy-query")
+}
+
+func TestDataSourcePermissionsAudit_UnsupportedType(t *testing.T) {
+	qa.ResourceFixture{
+		Resource:    DataSourcePermissionsAudit(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `object_types = ["notebook"]`,
+	}.ExpectError(t, "object type notebook is not supported, supported types: alertsv2, apps, authorization, "+
+		"cluster-policies, clusters, dashboards, database-instances, dbsql-dashboards, directories, experiments, "+
+		"files, instance-pools, jobs, notebooks, pipelines, registered-models, repos, serving-endpoints, sql/alerts, "+
+		"sql/queries, sql/warehouses, vector-search-endpoints")
+}
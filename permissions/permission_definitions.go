@@ -8,7 +8,19 @@ import (
 	"strings"
 
 	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/apps"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/dashboards"
+	"github.com/databricks/databricks-sdk-go/service/database"
 	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/databricks-sdk-go/service/ml"
+	"github.com/databricks/databricks-sdk-go/service/pipelines"
+	"github.com/databricks/databricks-sdk-go/service/serving"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	"github.com/databricks/databricks-sdk-go/service/vectorsearch"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/permissions/entity"
 	"github.com/databricks/terraform-provider-databricks/permissions/read"
@@ -74,6 +86,13 @@ type resourcePermissions struct {
 	// Returns the creator of the object. Used when deleting databricks_permissions resources, when the
 	// creator of the object is restored as the owner.
 	fetchObjectCreator func(ctx context.Context, w *databricks.WorkspaceClient, objectID string) (string, error)
+
+	// Returns the IDs of all objects of this type in the workspace. Used by the databricks_permissions_audit
+	// data source. Object types without a lister or workspaceObjectType can't be audited.
+	listObjectIds func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error)
+	// Type of the workspace objects, that are found by walking the workspace tree instead of listObjectIds,
+	// e.g. notebooks. Used by the databricks_permissions_audit data source.
+	workspaceObjectType workspace.ObjectType
 }
 
 // getAllowedPermissionLevels returns the list of permission levels that are allowed for this resource type.
@@ -211,16 +230,9 @@ func (p resourcePermissions) prepareForDelete(objectACL *iam.ObjectPermissions,
 // have CAN_VIEW in their resource configuration, so the read customizer will rewrite the response from CAN_READ to
 // CAN_VIEW to match the user's configuration.
 func (p resourcePermissions) prepareResponse(objectID string, objectACL *iam.ObjectPermissions, existing entity.PermissionsEntity, me string) (entity.PermissionsEntity, error) {
-	ctx := read.ACLCustomizerContext{
-		GetId:                        func() string { return objectID },
-		GetExistingPermissionsEntity: func() entity.PermissionsEntity { return existing },
-	}
-	acl := *objectACL
-	for _, customizer := range p.readAclCustomizers {
-		acl = customizer(ctx, acl)
-	}
-	if acl.ObjectType != p.objectType {
-		return entity.PermissionsEntity{}, fmt.Errorf("expected object type %s, got %s", p.objectType, objectACL.ObjectType)
+	acl, err := p.customizeReadResponse(objectID, objectACL, existing)
+	if err != nil {
+		return entity.PermissionsEntity{}, err
 	}
 	entity := entity.PermissionsEntity{}
 	for _, accessControl := range acl.AccessControlList {
@@ -252,6 +264,24 @@ func (p resourcePermissions) prepareResponse(objectID string, objectACL *iam.Obj
 	return entity, nil
 }
 
+// customizeReadResponse calls all read customizers on the access control list of the object and checks, that
+// the object is of the expected type. Existing permissions are the ones in the state, if there are any.
+func (p resourcePermissions) customizeReadResponse(objectID string, objectACL *iam.ObjectPermissions,
+	existing entity.PermissionsEntity) (iam.ObjectPermissions, error) {
+	ctx := read.ACLCustomizerContext{
+		GetId:                        func() string { return objectID },
+		GetExistingPermissionsEntity: func() entity.PermissionsEntity { return existing },
+	}
+	acl := *objectACL
+	for _, customizer := range p.readAclCustomizers {
+		acl = customizer(ctx, acl)
+	}
+	if acl.ObjectType != p.objectType {
+		return acl, fmt.Errorf("expected object type %s, got %s", p.objectType, objectACL.ObjectType)
+	}
+	return acl, nil
+}
+
 // addOwnerPermissionIfNeeded adds the owner permission to the object ACL if the owner permission is allowed and not already set.
 func (p resourcePermissions) addOwnerPermissionIfNeeded(objectACL []iam.AccessControlRequest, ownerOpt string) []iam.AccessControlRequest {
 	_, ok := p.allowedPermissionLevels["IS_OWNER"]
@@ -351,6 +381,11 @@ func allResourcePermissions() []resourcePermissions {
 			allowedPermissionLevels: map[string]permissionLevelOptions{
 				"CAN_USE": {isManagementPermission: true},
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.ClusterPolicies.ListAll(ctx, compute.ListClusterPoliciesRequest{}))(func(p compute.Policy) string {
+					return p.PolicyId
+				})
+			},
 		},
 		{
 			field:             "instance_pool_id",
@@ -362,6 +397,11 @@ func allResourcePermissions() []resourcePermissions {
 			},
 			updateAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
 			deleteAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.InstancePools.ListAll(ctx))(func(p compute.InstancePoolAndStats) string {
+					return p.InstancePoolId
+				})
+			},
 		},
 		{
 			field:             "cluster_id",
@@ -374,6 +414,11 @@ func allResourcePermissions() []resourcePermissions {
 			},
 			updateAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
 			deleteAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Clusters.ListAll(ctx, compute.ListClustersRequest{}))(func(c compute.ClusterDetails) string {
+					return c.ClusterId
+				})
+			},
 		},
 		{
 			field:             "pipeline_id",
@@ -392,6 +437,11 @@ func allResourcePermissions() []resourcePermissions {
 				}
 				return pipeline.CreatorUserName, nil
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Pipelines.ListPipelinesAll(ctx, pipelines.ListPipelinesRequest{}))(func(p pipelines.PipelineStateInfo) string {
+					return p.PipelineId
+				})
+			},
 		},
 		{
 			field:             "job_id",
@@ -414,6 +464,11 @@ func allResourcePermissions() []resourcePermissions {
 				}
 				return job.CreatorUserName, nil
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Jobs.ListAll(ctx, jobs.ListJobsRequest{}))(func(j jobs.BaseJob) string {
+					return strconv.FormatInt(j.JobId, 10)
+				})
+			},
 		},
 		{
 			field:             "notebook_id",
@@ -425,6 +480,7 @@ func allResourcePermissions() []resourcePermissions {
 				"CAN_EDIT":   {isManagementPermission: false},
 				"CAN_MANAGE": {isManagementPermission: true},
 			},
+			workspaceObjectType: workspace.ObjectTypeNotebook,
 		},
 		{
 			field:             "notebook_path",
@@ -448,6 +504,7 @@ func allResourcePermissions() []resourcePermissions {
 				"CAN_EDIT":   {isManagementPermission: false},
 				"CAN_MANAGE": {isManagementPermission: true},
 			},
+			workspaceObjectType: workspace.ObjectTypeDirectory,
 			updateAclCustomizers: []update.ACLCustomizer{
 				update.If(update.ObjectIdMatches("/directories/0"), update.AddAdmin),
 			},
@@ -483,7 +540,8 @@ func allResourcePermissions() []resourcePermissions {
 				"CAN_EDIT":   {isManagementPermission: false},
 				"CAN_MANAGE": {isManagementPermission: true},
 			},
-			pathVariant: "workspace_file_path",
+			pathVariant:         "workspace_file_path",
+			workspaceObjectType: workspace.ObjectTypeFile,
 		},
 		{
 			field:             "workspace_file_path",
@@ -508,6 +566,11 @@ func allResourcePermissions() []resourcePermissions {
 				"CAN_EDIT":   {isManagementPermission: false},
 				"CAN_MANAGE": {isManagementPermission: true},
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Repos.ListAll(ctx, workspace.ListReposRequest{}))(func(r workspace.RepoInfo) string {
+					return strconv.FormatInt(r.Id, 10)
+				})
+			},
 		},
 		{
 			field:             "repo_path",
@@ -538,6 +601,9 @@ func allResourcePermissions() []resourcePermissions {
 			updateAclCustomizers: []update.ACLCustomizer{
 				update.If(update.ObjectIdMatches("/authorization/tokens"), update.AddAdmin),
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return []string{"tokens"}, nil
+			},
 		},
 		{
 			field:             "authorization",
@@ -553,6 +619,9 @@ func allResourcePermissions() []resourcePermissions {
 				"CAN_USE": {isManagementPermission: true},
 			},
 			allowConfiguringAdmins: true,
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return []string{"passwords"}, nil
+			},
 		},
 		{
 			field:             "sql_endpoint_id",
@@ -579,6 +648,11 @@ func allResourcePermissions() []resourcePermissions {
 				}
 				return warehouse.CreatorName, nil
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Warehouses.ListAll(ctx, sql.ListWarehousesRequest{}))(func(e sql.EndpointInfo) string {
+					return e.Id
+				})
+			},
 		},
 		{
 			field:             "sql_dashboard_id",
@@ -615,6 +689,11 @@ func allResourcePermissions() []resourcePermissions {
 					return objectAcls
 				},
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Dashboards.ListAll(ctx, sql.ListDashboardsRequest{}))(func(d sql.Dashboard) string {
+					return d.Id
+				})
+			},
 		},
 		{
 			field:             "sql_alert_id",
@@ -643,6 +722,11 @@ func allResourcePermissions() []resourcePermissions {
 			readAclCustomizers: []read.ACLCustomizer{
 				rewriteCanReadToCanView,
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Alerts.ListAll(ctx, sql.ListAlertsRequest{}))(func(a sql.ListAlertsResponseAlert) string {
+					return a.Id
+				})
+			},
 		},
 		{
 			field:             "sql_query_id",
@@ -671,6 +755,11 @@ func allResourcePermissions() []resourcePermissions {
 			readAclCustomizers: []read.ACLCustomizer{
 				rewriteCanReadToCanView,
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Queries.ListAll(ctx, sql.ListQueriesRequest{}))(func(q sql.ListQueryObjectsResponseQuery) string {
+					return q.Id
+				})
+			},
 		},
 		{
 			field:             "dashboard_id",
@@ -691,6 +780,11 @@ func allResourcePermissions() []resourcePermissions {
 					return objectAcls
 				},
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Lakeview.ListAll(ctx, dashboards.ListDashboardsRequest{}))(func(d dashboards.Dashboard) string {
+					return d.DashboardId
+				})
+			},
 		},
 		{
 			field:             "experiment_id",
@@ -701,6 +795,11 @@ func allResourcePermissions() []resourcePermissions {
 				"CAN_EDIT":   {isManagementPermission: false},
 				"CAN_MANAGE": {isManagementPermission: true},
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Experiments.ListExperimentsAll(ctx, ml.ListExperimentsRequest{}))(func(e ml.Experiment) string {
+					return e.ExperimentId
+				})
+			},
 		},
 		{
 			field:             "registered_model_id",
@@ -720,6 +819,27 @@ func allResourcePermissions() []resourcePermissions {
 			deleteAclCustomizers: []update.ACLCustomizer{
 				update.If(update.Not(update.ObjectIdMatches("/registered-models/root")), update.AddCurrentUserAsManage),
 			},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				models, err := w.ModelRegistry.ListModelsAll(ctx, ml.ListModelsRequest{})
+				if err != nil {
+					return nil, err
+				}
+				// permissions are managed by the ID of the model, that isn't returned by the list API
+				ids := make([]string, 0, len(models))
+				for _, model := range models {
+					resp, err := w.ModelRegistry.GetModel(ctx, ml.GetModelRequest{Name: model.Name})
+					if apierr.IsMissing(err) {
+						continue
+					}
+					if err != nil {
+						return nil, fmt.Errorf("cannot get model %s: %w", model.Name, err)
+					}
+					if resp.RegisteredModelDatabricks != nil {
+						ids = append(ids, resp.RegisteredModelDatabricks.Id)
+					}
+				}
+				return ids, nil
+			},
 		},
 		{
 			field:             "serving_endpoint_id",
@@ -732,6 +852,11 @@ func allResourcePermissions() []resourcePermissions {
 			},
 			updateAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
 			deleteAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.ServingEndpoints.ListAll(ctx))(func(e serving.ServingEndpoint) string {
+					return e.Id
+				})
+			},
 		},
 		{
 			field:             "vector_search_endpoint_id",
@@ -743,6 +868,11 @@ func allResourcePermissions() []resourcePermissions {
 			},
 			updateAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
 			deleteAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.VectorSearchEndpoints.ListEndpointsAll(ctx, vectorsearch.ListEndpointsRequest{}))(func(e vectorsearch.EndpointInfo) string {
+					return e.Id
+				})
+			},
 		},
 		{
 			field:             "app_name",
@@ -754,6 +884,11 @@ func allResourcePermissions() []resourcePermissions {
 			},
 			updateAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
 			deleteAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Apps.ListAll(ctx, apps.ListAppsRequest{}))(func(a apps.App) string {
+					return a.Name
+				})
+			},
 		},
 		{
 			field:             "database_instance_name",
@@ -765,6 +900,11 @@ func allResourcePermissions() []resourcePermissions {
 			},
 			updateAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
 			deleteAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.Database.ListDatabaseInstancesAll(ctx, database.ListDatabaseInstancesRequest{}))(func(i database.DatabaseInstance) string {
+					return i.Name
+				})
+			},
 		},
 		{
 			field:             "alert_v2_id",
@@ -778,6 +918,25 @@ func allResourcePermissions() []resourcePermissions {
 			},
 			updateAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
 			deleteAclCustomizers: []update.ACLCustomizer{update.AddCurrentUserAsManage},
+			listObjectIds: func(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+				return listIds(w.AlertsV2.ListAlertsAll(ctx, sql.ListAlertsV2Request{}))(func(a sql.AlertV2) string {
+					return a.Id
+				})
+			},
 		},
 	}
 }
+
+// listIds converts the result of a list call into object IDs
+func listIds[T any](objects []T, err error) func(id func(T) string) ([]string, error) {
+	return func(id func(T) string) ([]string, error) {
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(objects))
+		for _, v := range objects {
+			ids = append(ids, id(v))
+		}
+		return ids, nil
+	}
+}
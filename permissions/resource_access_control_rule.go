@@ -0,0 +1,176 @@
+package permissions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// AccessControlRule is a single role of a single principal within a rule set
+type AccessControlRule struct {
+	RuleSetName string `json:"rule_set_name" tf:"force_new"`
+	Role        string `json:"role" tf:"force_new"`
+	Principal   string `json:"principal" tf:"force_new"`
+}
+
+func (r AccessControlRule) id() string {
+	return fmt.Sprintf("%s|%s|%s", r.RuleSetName, r.Role, r.Principal)
+}
+
+func parseAccessControlRuleId(id string) (AccessControlRule, error) {
+	split := strings.SplitN(id, "|", 3)
+	if len(split) != 3 {
+		return AccessControlRule{}, fmt.Errorf("ID must be in form of <rule_set_name>|<role>|<principal>: %s", id)
+	}
+	return AccessControlRule{RuleSetName: split[0], Role: split[1], Principal: split[2]}, nil
+}
+
+// isGranted checks if the principal has the role in the given grant rules
+func (r AccessControlRule) isGranted(grantRules []iam.GrantRule) bool {
+	for _, rule := range grantRules {
+		if rule.Role == r.Role && slices.Contains(rule.Principals, r.Principal) {
+			return true
+		}
+	}
+	return false
+}
+
+// grant returns grant rules with the principal added to the role, keeping other rules intact
+func (r AccessControlRule) grant(grantRules []iam.GrantRule) ([]iam.GrantRule, bool) {
+	if r.isGranted(grantRules) {
+		return grantRules, false
+	}
+	updated := []iam.GrantRule{}
+	found := false
+	for _, rule := range grantRules {
+		if rule.Role == r.Role && !found {
+			rule.Principals = append(slices.Clone(rule.Principals), r.Principal)
+			found = true
+		}
+		updated = append(updated, rule)
+	}
+	if !found {
+		updated = append(updated, iam.GrantRule{
+			Role:       r.Role,
+			Principals: []string{r.Principal},
+		})
+	}
+	return updated, true
+}
+
+// revoke returns grant rules without the principal in the role, keeping other rules intact
+func (r AccessControlRule) revoke(grantRules []iam.GrantRule) ([]iam.GrantRule, bool) {
+	if !r.isGranted(grantRules) {
+		return grantRules, false
+	}
+	updated := []iam.GrantRule{}
+	for _, rule := range grantRules {
+		if rule.Role == r.Role {
+			rule.Principals = slices.DeleteFunc(slices.Clone(rule.Principals), func(p string) bool {
+				return p == r.Principal
+			})
+			if len(rule.Principals) == 0 {
+				continue
+			}
+		}
+		updated = append(updated, rule)
+	}
+	return updated, true
+}
+
+// ruleSetConflictRetryPolicy is used, when the `conflict` class isn't configured in the `retry` provider block.
+// Rules of the same rule set are usually changed by many resources in the same apply, so conflicts are retried
+// more times, than for settings, and with jitter.
+var ruleSetConflictRetryPolicy = common.RetryPolicy{
+	MaxAttempts: 5,
+	MinBackoff:  time.Second,
+	MaxBackoff:  10 * time.Second,
+	Jitter:      true,
+}
+
+// modifyRuleSet reads the latest version of the rule set, modifies its grant rules and writes them back
+// with the etag of the read version. Read-modify-write is repeated according to the `conflict` policy of
+// the `retry` provider block, if the rule set was concurrently modified, so that rules of the same rule set
+// could be managed from different places.
+func modifyRuleSet(ctx context.Context, c *common.DatabricksClient, name string,
+	modify func([]iam.GrantRule) ([]iam.GrantRule, bool)) error {
+	ctx = common.WithDefaultRetryPolicy(ctx, common.RetryConflict, ruleSetConflictRetryPolicy)
+	_, err := common.RetryWithPolicy(ctx, common.RetryConflict, func(err error) bool {
+		return errors.Is(err, apierr.ErrResourceConflict)
+	}, func(ctx context.Context) (*iam.RuleSetResponse, error) {
+		current, err := readFromWsOrAcc(ctx, c, iam.GetRuleSetRequest{
+			Name: name,
+			Etag: "",
+		})
+		if err != nil {
+			return nil, err
+		}
+		grantRules, changed := modify(current.GrantRules)
+		if !changed {
+			return current, nil
+		}
+		return updateThroughWsOrAcc(ctx, c, iam.UpdateRuleSetRequest{
+			Name: name,
+			RuleSet: iam.RuleSetUpdateRequest{
+				Name:       name,
+				Etag:       current.Etag,
+				GrantRules: grantRules,
+			},
+		})
+	})
+	return err
+}
+
+// ResourceAccessControlRule manages a single role of a single principal within a rule set,
+// unlike ResourceAccessControlRuleSet, which is authoritative for all grant rules of the rule set
+func ResourceAccessControlRule() common.Resource {
+	s := common.StructToSchema(AccessControlRule{}, common.NoCustomize)
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var r AccessControlRule
+			common.DataToStructPointer(d, s, &r)
+			err := modifyRuleSet(ctx, c, r.RuleSetName, r.grant)
+			if err != nil {
+				return err
+			}
+			d.SetId(r.id())
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			r, err := parseAccessControlRuleId(d.Id())
+			if err != nil {
+				return err
+			}
+			ruleSet, err := readFromWsOrAcc(ctx, c, iam.GetRuleSetRequest{
+				Name: r.RuleSetName,
+				Etag: "",
+			})
+			if err != nil {
+				return err
+			}
+			if !r.isGranted(ruleSet.GrantRules) {
+				log.Printf("[INFO] %s is no longer granted %s in %s", r.Principal, r.Role, r.RuleSetName)
+				d.SetId("")
+				return nil
+			}
+			return common.StructToData(r, s, d)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			r, err := parseAccessControlRuleId(d.Id())
+			if err != nil {
+				return err
+			}
+			return modifyRuleSet(ctx, c, r.RuleSetName, r.revoke)
+		},
+	}
+}
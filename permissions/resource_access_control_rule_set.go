@@ -12,6 +12,36 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func readFromWsOrAcc(ctx context.Context, c *common.DatabricksClient, getRuleSetReq iam.GetRuleSetRequest) (*iam.RuleSetResponse, error) {
+	if c.Config.AccountID != "" {
+		accountClient, err := c.AccountClient()
+		if err != nil {
+			return nil, err
+		}
+		return accountClient.AccessControl.GetRuleSet(ctx, getRuleSetReq)
+	}
+	workspaceClient, err := c.WorkspaceClient()
+	if err != nil {
+		return nil, err
+	}
+	return workspaceClient.AccountAccessControlProxy.GetRuleSet(ctx, getRuleSetReq)
+}
+
+func updateThroughWsOrAcc(ctx context.Context, c *common.DatabricksClient, updateRuleSetReq iam.UpdateRuleSetRequest) (*iam.RuleSetResponse, error) {
+	if c.Config.AccountID != "" {
+		accountClient, err := c.AccountClient()
+		if err != nil {
+			return nil, err
+		}
+		return accountClient.AccessControl.UpdateRuleSet(ctx, updateRuleSetReq)
+	}
+	workspaceClient, err := c.WorkspaceClient()
+	if err != nil {
+		return nil, err
+	}
+	return workspaceClient.AccountAccessControlProxy.UpdateRuleSet(ctx, updateRuleSetReq)
+}
+
 func ResourceAccessControlRuleSet() common.Resource {
 	s := common.StructToSchema(
 		iam.RuleSetUpdateRequest{},
@@ -24,34 +54,6 @@ func ResourceAccessControlRuleSet() common.Resource {
 
 			return m
 		})
-	readFromWsOrAcc := func(ctx context.Context, c *common.DatabricksClient, getRuleSetReq iam.GetRuleSetRequest) (*iam.RuleSetResponse, error) {
-		if c.Config.AccountID != "" {
-			accountClient, err := c.AccountClient()
-			if err != nil {
-				return nil, err
-			}
-			return accountClient.AccessControl.GetRuleSet(ctx, getRuleSetReq)
-		}
-		workspaceClient, err := c.WorkspaceClient()
-		if err != nil {
-			return nil, err
-		}
-		return workspaceClient.AccountAccessControlProxy.GetRuleSet(ctx, getRuleSetReq)
-	}
-	updateThroughWsOrAcc := func(ctx context.Context, c *common.DatabricksClient, updateRuleSetReq iam.UpdateRuleSetRequest) (*iam.RuleSetResponse, error) {
-		if c.Config.AccountID != "" {
-			accountClient, err := c.AccountClient()
-			if err != nil {
-				return nil, err
-			}
-			return accountClient.AccessControl.UpdateRuleSet(ctx, updateRuleSetReq)
-		}
-		workspaceClient, err := c.WorkspaceClient()
-		if err != nil {
-			return nil, err
-		}
-		return workspaceClient.AccountAccessControlProxy.UpdateRuleSet(ctx, updateRuleSetReq)
-	}
 	fetchLatestEtag := func(ctx context.Context, c *common.DatabricksClient, name string) (string, error) {
 		ruleSetGetRes, err := readFromWsOrAcc(ctx, c, iam.GetRuleSetRequest{
 			Name: name,
@@ -0,0 +1,340 @@
+package permissions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAccessControlRuleId = testServicePrincipalRuleSetName + "|roles/servicePrincipal.user|groups/data-eng"
+
+func TestAccessControlRuleGrantRevoke(t *testing.T) {
+	r := AccessControlRule{Role: "roles/servicePrincipal.user", Principal: "groups/data-eng"}
+	rules := []iam.GrantRule{
+		{Role: "roles/servicePrincipal.manager", Principals: []string{"users/abc@example.com"}},
+		{Role: "roles/servicePrincipal.user", Principals: []string{"groups/analysts"}},
+	}
+	granted, changed := r.grant(rules)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"groups/analysts", "groups/data-eng"}, granted[1].Principals)
+	assert.Equal(t, []string{"groups/analysts"}, rules[1].Principals)
+
+	_, changed = r.grant(granted)
+	assert.False(t, changed)
+
+	revoked, changed := r.revoke(granted)
+	assert.True(t, changed)
+	assert.Equal(t, rules, revoked)
+
+	revoked, changed = AccessControlRule{
+		Role:      "roles/servicePrincipal.manager",
+		Principal: "users/abc@example.com",
+	}.revoke(rules)
+	assert.True(t, changed)
+	assert.Equal(t, rules[1:], revoked)
+}
+
+func TestParseAccessControlRuleId(t *testing.T) {
+	r, err := parseAccessControlRuleId(testAccessControlRuleId)
+	assert.NoError(t, err)
+	assert.Equal(t, AccessControlRule{
+		RuleSetName: testServicePrincipalRuleSetName,
+		Role:        "roles/servicePrincipal.user",
+		Principal:   "groups/data-eng",
+	}, r)
+	_, err = parseAccessControlRuleId("abc")
+	assert.EqualError(t, err, "ID must be in form of <rule_set_name>|<role>|<principal>: abc")
+}
+
+// withFastRuleSetConflictRetries keeps the number of attempts of the default rule set policy, but doesn't wait
+func withFastRuleSetConflictRetries(t *testing.T) {
+	defaultPolicy := ruleSetConflictRetryPolicy
+	ruleSetConflictRetryPolicy.MinBackoff = time.Millisecond
+	ruleSetConflictRetryPolicy.MaxBackoff = time.Millisecond
+	t.Cleanup(func() { ruleSetConflictRetryPolicy = defaultPolicy })
+}
+
+func TestRuleSetConflictRetryPolicy(t *testing.T) {
+	assert.GreaterOrEqual(t, ruleSetConflictRetryPolicy.MaxAttempts, 5)
+	assert.True(t, ruleSetConflictRetryPolicy.Jitter)
+	assert.Positive(t, ruleSetConflictRetryPolicy.MinBackoff)
+	assert.Equal(t, 2, common.DefaultRetryPolicy(common.RetryConflict).MaxAttempts)
+}
+
+func TestResourceAccessControlRuleCreate_RetriesOnConflict(t *testing.T) {
+	withFastRuleSetConflictRetries(t)
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: getResourceName(testServicePrincipalRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx=",
+				},
+			},
+			{
+				Method:   "PUT",
+				Resource: ruleSetApiPath,
+				ExpectedRequest: iam.UpdateRuleSetRequest{
+					Name: testServicePrincipalRuleSetName,
+					RuleSet: iam.RuleSetUpdateRequest{
+						Name: testServicePrincipalRuleSetName,
+						Etag: "etagEx=",
+						GrantRules: []iam.GrantRule{
+							{
+								Principals: []string{"groups/data-eng"},
+								Role:       "roles/servicePrincipal.user",
+							},
+						},
+					},
+				},
+				Response: map[string]string{
+					"error_code": "RESOURCE_CONFLICT",
+					"message":    "Conflict with another RuleSet operation",
+				},
+				Status: 409,
+			},
+			{
+				Method:   "GET",
+				Resource: getResourceName(testServicePrincipalRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx2=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"users/abc@example.com"},
+							Role:       "roles/servicePrincipal.manager",
+						},
+					},
+				},
+			},
+			{
+				Method:   "PUT",
+				Resource: ruleSetApiPath,
+				ExpectedRequest: iam.UpdateRuleSetRequest{
+					Name: testServicePrincipalRuleSetName,
+					RuleSet: iam.RuleSetUpdateRequest{
+						Name: testServicePrincipalRuleSetName,
+						Etag: "etagEx2=",
+						GrantRules: []iam.GrantRule{
+							{
+								Principals: []string{"users/abc@example.com"},
+								Role:       "roles/servicePrincipal.manager",
+							},
+							{
+								Principals: []string{"groups/data-eng"},
+								Role:       "roles/servicePrincipal.user",
+							},
+						},
+					},
+				},
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx3=",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: getResourceName(testServicePrincipalRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx3=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"users/abc@example.com"},
+							Role:       "roles/servicePrincipal.manager",
+						},
+						{
+							Principals: []string{"groups/data-eng"},
+							Role:       "roles/servicePrincipal.user",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceAccessControlRule(),
+		Create:   true,
+		HCL: `
+		rule_set_name = "` + testServicePrincipalRuleSetName + `"
+		role = "roles/servicePrincipal.user"
+		principal = "groups/data-eng"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":            testAccessControlRuleId,
+		"rule_set_name": testServicePrincipalRuleSetName,
+	})
+}
+
+func TestResourceAccessControlRuleCreate_AlreadyGranted(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     getResourceName(testServicePrincipalRuleSetName, ""),
+				ReuseRequest: true,
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"groups/data-eng"},
+							Role:       "roles/servicePrincipal.user",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceAccessControlRule(),
+		Create:   true,
+		HCL: `
+		rule_set_name = "` + testServicePrincipalRuleSetName + `"
+		role = "roles/servicePrincipal.user"
+		principal = "groups/data-eng"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id": testAccessControlRuleId,
+	})
+}
+
+func TestResourceAccessControlRuleCreate_ConflictRetriesExhausted(t *testing.T) {
+	withFastRuleSetConflictRetries(t)
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     getResourceName(testServicePrincipalRuleSetName, ""),
+				ReuseRequest: true,
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx=",
+				},
+			},
+			{
+				Method:       "PUT",
+				Resource:     ruleSetApiPath,
+				ReuseRequest: true,
+				Response: map[string]string{
+					"error_code": "RESOURCE_CONFLICT",
+					"message":    "Conflict with another RuleSet operation",
+				},
+				Status: 409,
+			},
+		},
+		Resource: ResourceAccessControlRule(),
+		Create:   true,
+		HCL: `
+		rule_set_name = "` + testServicePrincipalRuleSetName + `"
+		role = "roles/servicePrincipal.user"
+		principal = "groups/data-eng"
+		`,
+	}.ExpectError(t, "Conflict with another RuleSet operation")
+}
+
+func TestResourceAccessControlRuleRead_Revoked(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: getResourceName(testServicePrincipalRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"groups/data-eng"},
+							Role:       "roles/servicePrincipal.manager",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceAccessControlRule(),
+		Read:     true,
+		Removed:  true,
+		New:      true,
+		ID:       testAccessControlRuleId,
+	}.ApplyNoError(t)
+}
+
+func TestResourceAccessControlRuleRead_Import(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: getResourceName(testServicePrincipalRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"users/abc@example.com", "groups/data-eng"},
+							Role:       "roles/servicePrincipal.user",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceAccessControlRule(),
+		Read:     true,
+		New:      true,
+		ID:       testAccessControlRuleId,
+	}.ApplyAndExpectData(t, map[string]any{
+		"rule_set_name": testServicePrincipalRuleSetName,
+		"role":          "roles/servicePrincipal.user",
+		"principal":     "groups/data-eng",
+	})
+}
+
+func TestResourceAccessControlRuleDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: getResourceName(testServicePrincipalRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"users/abc@example.com"},
+							Role:       "roles/servicePrincipal.manager",
+						},
+						{
+							Principals: []string{"groups/data-eng"},
+							Role:       "roles/servicePrincipal.user",
+						},
+					},
+				},
+			},
+			{
+				Method:   "PUT",
+				Resource: ruleSetApiPath,
+				ExpectedRequest: iam.UpdateRuleSetRequest{
+					Name: testServicePrincipalRuleSetName,
+					RuleSet: iam.RuleSetUpdateRequest{
+						Name: testServicePrincipalRuleSetName,
+						Etag: "etagEx=",
+						GrantRules: []iam.GrantRule{
+							{
+								Principals: []string{"users/abc@example.com"},
+								Role:       "roles/servicePrincipal.manager",
+							},
+						},
+					},
+				},
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx2=",
+				},
+			},
+		},
+		Resource: ResourceAccessControlRule(),
+		Delete:   true,
+		ID:       testAccessControlRuleId,
+	}.ApplyNoError(t)
+}
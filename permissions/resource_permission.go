@@ -0,0 +1,320 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/permissions/entity"
+	"github.com/databricks/terraform-provider-databricks/permissions/update"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// PermissionEntity is a permission level of a single principal on an object
+type PermissionEntity struct {
+	ObjectType           string              `json:"object_type,omitempty" tf:"computed"`
+	UserName             string              `json:"user_name,omitempty" tf:"force_new"`
+	GroupName            string              `json:"group_name,omitempty" tf:"force_new"`
+	ServicePrincipalName string              `json:"service_principal_name,omitempty" tf:"force_new"`
+	PermissionLevel      iam.PermissionLevel `json:"permission_level"`
+}
+
+var principalFields = []string{"user_name", "group_name", "service_principal_name"}
+
+func (p PermissionEntity) toAccessControlRequest() iam.AccessControlRequest {
+	return iam.AccessControlRequest{
+		UserName:             p.UserName,
+		GroupName:            p.GroupName,
+		ServicePrincipalName: p.ServicePrincipalName,
+		PermissionLevel:      p.PermissionLevel,
+	}
+}
+
+// principal returns the name of principal attribute and its value
+func (p PermissionEntity) principal() (string, string) {
+	switch {
+	case p.UserName != "":
+		return "user_name", p.UserName
+	case p.GroupName != "":
+		return "group_name", p.GroupName
+	default:
+		return "service_principal_name", p.ServicePrincipalName
+	}
+}
+
+// isFor checks if access control entry belongs to the principal of this entity
+func (p PermissionEntity) isFor(ac iam.AccessControlResponse) bool {
+	return (p.UserName != "" && ac.UserName == p.UserName) ||
+		(p.GroupName != "" && ac.GroupName == p.GroupName) ||
+		(p.ServicePrincipalName != "" && ac.ServicePrincipalName == p.ServicePrincipalName)
+}
+
+func (p PermissionEntity) id(objectID string) string {
+	field, name := p.principal()
+	return fmt.Sprintf("%s|%s|%s", objectID, field, name)
+}
+
+// parsePermissionId returns object ID and principal from the ID of databricks_permission
+func parsePermissionId(id string) (string, PermissionEntity, error) {
+	split := strings.SplitN(id, "|", 3)
+	if len(split) != 3 {
+		return "", PermissionEntity{}, fmt.Errorf("ID must be in form of <object_id>|<principal_type>|<principal>: %s", id)
+	}
+	var p PermissionEntity
+	switch split[1] {
+	case "user_name":
+		p.UserName = split[2]
+	case "group_name":
+		p.GroupName = split[2]
+	case "service_principal_name":
+		p.ServicePrincipalName = split[2]
+	default:
+		return "", PermissionEntity{}, fmt.Errorf("unknown principal type %s in ID: %s", split[1], id)
+	}
+	return split[0], p, nil
+}
+
+// Patch adds or changes permission level of a single principal, keeping the rest of the object ACL intact
+func (a PermissionsAPI) Patch(objectID string, p PermissionEntity, mapping resourcePermissions) error {
+	currentUser, err := a.getCurrentUser()
+	if err != nil {
+		return err
+	}
+	single := entity.PermissionsEntity{
+		AccessControlList: []iam.AccessControlRequest{p.toAccessControlRequest()},
+	}
+	err = mapping.validate(a.context, single, currentUser)
+	if err != nil {
+		return err
+	}
+	prepared, err := mapping.prepareForUpdate(objectID, single, currentUser)
+	if err != nil {
+		return err
+	}
+	// PATCH doesn't remove permissions of other principals, so the current user can't lose access,
+	// and CAN_MANAGE added by update.AddCurrentUserAsManage would never be removed
+	acl := []iam.AccessControlRequest{}
+	for _, ac := range prepared.AccessControlList {
+		addedCurrentUser := (ac.UserName == currentUser || ac.ServicePrincipalName == currentUser) &&
+			!p.isFor(iam.AccessControlResponse{
+				UserName:             ac.UserName,
+				GroupName:            ac.GroupName,
+				ServicePrincipalName: ac.ServicePrincipalName,
+			})
+		if !addedCurrentUser {
+			acl = append(acl, ac)
+		}
+	}
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return err
+	}
+	_, err = w.Permissions.Update(a.context, iam.UpdateObjectPermissions{
+		RequestObjectId:   path.Base(objectID),
+		RequestObjectType: mapping.requestObjectType,
+		AccessControlList: acl,
+	})
+	return err
+}
+
+// ReadPrincipal returns direct permission level of the principal on the object or an empty level, if there is none
+func (a PermissionsAPI) ReadPrincipal(objectID string, p PermissionEntity, mapping resourcePermissions) (PermissionEntity, error) {
+	objectACL, err := a.readRaw(objectID, mapping)
+	if err != nil {
+		return PermissionEntity{}, err
+	}
+	acl, err := mapping.customizeReadResponse(objectID, objectACL, entity.PermissionsEntity{
+		AccessControlList: []iam.AccessControlRequest{p.toAccessControlRequest()},
+	})
+	if err != nil {
+		return PermissionEntity{}, err
+	}
+	p.ObjectType = mapping.objectType
+	p.PermissionLevel = ""
+	for _, accessControl := range acl.AccessControlList {
+		if !p.isFor(accessControl) {
+			continue
+		}
+		for _, permission := range accessControl.AllPermissions {
+			if permission.Inherited {
+				continue
+			}
+			p.PermissionLevel = permission.PermissionLevel
+		}
+	}
+	return p, nil
+}
+
+// RemovePrincipal removes direct permissions of the principal, keeping direct permissions of other principals.
+// Permissions API can't remove a single principal, so the rest of the ACL is set again in the same way as
+// in Delete, including the owner of the object.
+func (a PermissionsAPI) RemovePrincipal(objectID string, p PermissionEntity, mapping resourcePermissions) error {
+	objectACL, err := a.readRaw(objectID, mapping)
+	if err != nil {
+		return err
+	}
+	remaining := []iam.AccessControlRequest{}
+	found := false
+	for _, accessControl := range objectACL.AccessControlList {
+		for _, permission := range accessControl.AllPermissions {
+			if permission.Inherited {
+				continue
+			}
+			if p.isFor(accessControl) {
+				found = true
+				continue
+			}
+			remaining = append(remaining, iam.AccessControlRequest{
+				UserName:             accessControl.UserName,
+				GroupName:            accessControl.GroupName,
+				ServicePrincipalName: accessControl.ServicePrincipalName,
+				PermissionLevel:      permission.PermissionLevel,
+			})
+		}
+	}
+	if !found {
+		return nil
+	}
+	ctx := update.ACLCustomizerContext{
+		GetCurrentUser: a.getCurrentUser,
+		GetId:          func() string { return objectID },
+	}
+	for _, customizer := range mapping.deleteAclCustomizers {
+		remaining, err = customizer(ctx, remaining)
+		if err != nil {
+			return err
+		}
+	}
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return err
+	}
+	resourceStatus, err := mapping.getObjectStatus(a.context, w, objectID)
+	if err != nil {
+		return err
+	}
+	if !resourceStatus.exists {
+		return nil
+	}
+	return a.safePutWithOwner(objectID, remaining, mapping, resourceStatus.creator)
+}
+
+// ResourcePermission manages permission level of a single principal on an object,
+// unlike ResourcePermissions, which is authoritative for the entire ACL of the object
+func ResourcePermission() common.Resource {
+	s := common.StructToSchema(PermissionEntity{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		fields := []string{}
+		for _, mapping := range allResourcePermissions() {
+			if _, ok := s[mapping.field]; ok {
+				continue
+			}
+			s[mapping.field] = &schema.Schema{
+				ForceNew: true,
+				Type:     schema.TypeString,
+				Optional: true,
+			}
+			fields = append(fields, mapping.field)
+		}
+		for _, field := range fields {
+			s[field].ExactlyOneOf = fields
+		}
+		for _, field := range principalFields {
+			s[field].ExactlyOneOf = principalFields
+		}
+		return s
+	})
+	return common.Resource{
+		Schema: s,
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff) error {
+			mapping, _, err := getResourcePermissionsFromState(diff)
+			if err != nil {
+				return nil
+			}
+			level := diff.Get("permission_level").(string)
+			if level == "" {
+				return nil
+			}
+			if _, ok := mapping.allowedPermissionLevels[level]; !ok {
+				return fmt.Errorf(`permission_level %s is not supported with %s objects; allowed levels: %s`,
+					level, mapping.field, strings.Join(mapping.getAllowedPermissionLevels(true), ", "))
+			}
+			return nil
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var p PermissionEntity
+			common.DataToStructPointer(d, s, &p)
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			mapping, configuredValue, err := getResourcePermissionsFromState(d)
+			if err != nil {
+				return err
+			}
+			objectID, err := mapping.getID(ctx, w, configuredValue)
+			if err != nil {
+				return err
+			}
+			err = NewPermissionsAPI(ctx, c).Patch(objectID, p, mapping)
+			if err != nil {
+				return err
+			}
+			d.SetId(p.id(objectID))
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			objectID, principal, err := parsePermissionId(d.Id())
+			if err != nil {
+				return err
+			}
+			mapping, err := getResourcePermissionsFromId(objectID)
+			if err != nil {
+				return err
+			}
+			principal.PermissionLevel = iam.PermissionLevel(d.Get("permission_level").(string))
+			p, err := NewPermissionsAPI(ctx, c).ReadPrincipal(objectID, principal, mapping)
+			if err != nil {
+				return err
+			}
+			if p.PermissionLevel == "" {
+				// principal has no direct permissions on the object
+				d.SetId("")
+				return nil
+			}
+			pathVariant := d.Get(mapping.getPathVariant())
+			if pathVariant == nil || pathVariant.(string) == "" {
+				if err = d.Set(mapping.field, path.Base(objectID)); err != nil {
+					return err
+				}
+			}
+			return common.StructToData(p, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var p PermissionEntity
+			common.DataToStructPointer(d, s, &p)
+			objectID, _, err := parsePermissionId(d.Id())
+			if err != nil {
+				return err
+			}
+			mapping, err := getResourcePermissionsFromId(objectID)
+			if err != nil {
+				return err
+			}
+			return NewPermissionsAPI(ctx, c).Patch(objectID, p, mapping)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			objectID, principal, err := parsePermissionId(d.Id())
+			if err != nil {
+				return err
+			}
+			mapping, err := getResourcePermissionsFromId(objectID)
+			if err != nil {
+				return err
+			}
+			return NewPermissionsAPI(ctx, c).RemovePrincipal(objectID, principal, mapping)
+		},
+	}
+}
@@ -0,0 +1,228 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var clusterObjectPermissions = &iam.ObjectPermissions{
+	ObjectId:   "/clusters/abc",
+	ObjectType: "cluster",
+	AccessControlList: []iam.AccessControlResponse{
+		{
+			UserName: TestingUser,
+			AllPermissions: []iam.Permission{
+				{
+					PermissionLevel: "CAN_RESTART",
+				},
+			},
+		},
+		{
+			GroupName: "data-engineers",
+			AllPermissions: []iam.Permission{
+				{
+					PermissionLevel: "CAN_ATTACH_TO",
+				},
+			},
+		},
+		{
+			GroupName: "admins",
+			AllPermissions: []iam.Permission{
+				{
+					PermissionLevel: "CAN_MANAGE",
+					Inherited:       true,
+				},
+			},
+		},
+	},
+}
+
+func TestResourcePermissionCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			mwc.GetMockCurrentUserAPI().EXPECT().Me(mock.Anything).Return(&iam.User{UserName: TestingAdminUser}, nil)
+			e := mwc.GetMockPermissionsAPI().EXPECT()
+			e.Update(mock.Anything, iam.UpdateObjectPermissions{
+				RequestObjectId:   "abc",
+				RequestObjectType: "clusters",
+				AccessControlList: []iam.AccessControlRequest{
+					{
+						UserName:        TestingUser,
+						PermissionLevel: "CAN_RESTART",
+					},
+				},
+			}).Return(nil, nil)
+			e.Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "abc",
+				RequestObjectType: "clusters",
+			}).Return(clusterObjectPermissions, nil)
+		},
+		Resource: ResourcePermission(),
+		Create:   true,
+		HCL: `
+		cluster_id = "abc"
+		user_name = "ben"
+		permission_level = "CAN_RESTART"
+		`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "/clusters/abc|user_name|ben", d.Id())
+	assert.Equal(t, "cluster", d.Get("object_type"))
+	assert.Equal(t, "CAN_RESTART", d.Get("permission_level"))
+}
+
+func TestResourcePermissionRead(t *testing.T) {
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			mwc.GetMockPermissionsAPI().EXPECT().Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "abc",
+				RequestObjectType: "clusters",
+			}).Return(clusterObjectPermissions, nil)
+		},
+		Resource: ResourcePermission(),
+		Read:     true,
+		New:      true,
+		ID:       "/clusters/abc|group_name|data-engineers",
+	}.ApplyAndExpectData(t, map[string]any{
+		"cluster_id":       "abc",
+		"group_name":       "data-engineers",
+		"permission_level": "CAN_ATTACH_TO",
+		"object_type":      "cluster",
+	})
+}
+
+func TestResourcePermissionRead_InheritedOnly(t *testing.T) {
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			mwc.GetMockPermissionsAPI().EXPECT().Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "abc",
+				RequestObjectType: "clusters",
+			}).Return(clusterObjectPermissions, nil)
+		},
+		Resource: ResourcePermission(),
+		Read:     true,
+		Removed:  true,
+		ID:       "/clusters/abc|group_name|admins",
+	}.ApplyNoError(t)
+}
+
+func TestResourcePermissionRead_InvalidId(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourcePermission(),
+		Read:     true,
+		New:      true,
+		ID:       "/clusters/abc",
+	}.ExpectError(t, "ID must be in form of <object_id>|<principal_type>|<principal>: /clusters/abc")
+}
+
+func TestResourcePermissionUpdate(t *testing.T) {
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			mwc.GetMockCurrentUserAPI().EXPECT().Me(mock.Anything).Return(&iam.User{UserName: TestingAdminUser}, nil)
+			e := mwc.GetMockPermissionsAPI().EXPECT()
+			e.Update(mock.Anything, iam.UpdateObjectPermissions{
+				RequestObjectId:   "abc",
+				RequestObjectType: "clusters",
+				AccessControlList: []iam.AccessControlRequest{
+					{
+						UserName:        TestingUser,
+						PermissionLevel: "CAN_RESTART",
+					},
+				},
+			}).Return(nil, nil)
+			e.Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "abc",
+				RequestObjectType: "clusters",
+			}).Return(clusterObjectPermissions, nil)
+		},
+		Resource: ResourcePermission(),
+		Update:   true,
+		ID:       "/clusters/abc|user_name|ben",
+		InstanceState: map[string]string{
+			"cluster_id":       "abc",
+			"user_name":        "ben",
+			"permission_level": "CAN_ATTACH_TO",
+		},
+		HCL: `
+		cluster_id = "abc"
+		user_name = "ben"
+		permission_level = "CAN_RESTART"
+		`,
+	}.ApplyNoError(t)
+}
+
+func TestResourcePermissionDelete(t *testing.T) {
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			mwc.GetMockCurrentUserAPI().EXPECT().Me(mock.Anything).Return(&iam.User{UserName: TestingAdminUser}, nil)
+			e := mwc.GetMockPermissionsAPI().EXPECT()
+			e.Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "abc",
+				RequestObjectType: "clusters",
+			}).Return(clusterObjectPermissions, nil)
+			e.Set(mock.Anything, iam.SetObjectPermissions{
+				RequestObjectId:   "abc",
+				RequestObjectType: "clusters",
+				AccessControlList: []iam.AccessControlRequest{
+					{
+						GroupName:       "data-engineers",
+						PermissionLevel: "CAN_ATTACH_TO",
+					},
+					{
+						UserName:        TestingAdminUser,
+						PermissionLevel: "CAN_MANAGE",
+					},
+				},
+			}).Return(nil, nil)
+		},
+		Resource: ResourcePermission(),
+		Delete:   true,
+		ID:       "/clusters/abc|user_name|ben",
+	}.ApplyNoError(t)
+}
+
+func TestResourcePermissionDelete_AlreadyRemoved(t *testing.T) {
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			mwc.GetMockPermissionsAPI().EXPECT().Get(mock.Anything, iam.GetPermissionRequest{
+				RequestObjectId:   "abc",
+				RequestObjectType: "clusters",
+			}).Return(clusterObjectPermissions, nil)
+		},
+		Resource: ResourcePermission(),
+		Delete:   true,
+		ID:       "/clusters/abc|user_name|someone",
+	}.ApplyNoError(t)
+}
+
+func TestResourcePermissionCreate_InvalidLevel(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourcePermission(),
+		Create:   true,
+		HCL: `
+		cluster_id = "abc"
+		user_name = "ben"
+		permission_level = "CAN_USE"
+		`,
+	}.ExpectError(t, "permission_level CAN_USE is not supported with cluster_id objects; allowed levels: CAN_ATTACH_TO, CAN_MANAGE, CAN_RESTART")
+}
+
+func TestResourcePermissionCreate_AdminsGroup(t *testing.T) {
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(mwc *mocks.MockWorkspaceClient) {
+			mwc.GetMockCurrentUserAPI().EXPECT().Me(mock.Anything).Return(&iam.User{UserName: TestingAdminUser}, nil)
+		},
+		Resource: ResourcePermission(),
+		Create:   true,
+		HCL: `
+		cluster_id = "abc"
+		group_name = "admins"
+		permission_level = "CAN_MANAGE"
+		`,
+	}.ExpectError(t, "it is not possible to modify admin permissions for cluster resources")
+}
@@ -7,6 +7,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/iam"
 	"github.com/databricks/terraform-provider-databricks/common"
@@ -120,13 +121,18 @@ func (a PermissionsAPI) readRaw(objectID string, mapping resourcePermissions) (*
 	if err != nil {
 		return nil, err
 	}
+	return readObjectPermissions(a.context, w, objectID, mapping)
+}
+
+// readObjectPermissions gets the raw ACL of the object, including inherited permissions
+func readObjectPermissions(ctx context.Context, w *databricks.WorkspaceClient, objectID string, mapping resourcePermissions) (*iam.ObjectPermissions, error) {
 	idParts := strings.Split(objectID, "/")
 	id := idParts[len(idParts)-1]
 
 	// TODO: This a temporary measure to implement retry on 504 until this is
 	// supported natively in the Go SDK.
-	permissions, err := common.RetryOn504(a.context, func(ctx context.Context) (*iam.ObjectPermissions, error) {
-		return w.Permissions.Get(a.context, iam.GetPermissionRequest{
+	permissions, err := common.RetryOn504(ctx, func(ctx context.Context) (*iam.ObjectPermissions, error) {
+		return w.Permissions.Get(ctx, iam.GetPermissionRequest{
 			RequestObjectId:   id,
 			RequestObjectType: mapping.requestObjectType,
 		})
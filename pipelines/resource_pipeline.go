@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/databricks/databricks-sdk-go"
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // DefaultTimeout is the default amount of time that Terraform will wait when creating, updating and deleting pipelines.
@@ -35,9 +37,11 @@ func adjustForceSendFields(clusterList *[]pipelines.PipelineCluster) {
 	}
 }
 
-func Create(w *databricks.WorkspaceClient, ctx context.Context, d *schema.ResourceData, timeout time.Duration) error {
+func Create(w *databricks.WorkspaceClient, ctx context.Context, d *schema.ResourceData, timeout time.Duration,
+	mergeTags func(map[string]string) map[string]string) error {
 	var createPipelineRequest createPipelineRequestStruct
 	common.DataToStructPointer(d, pipelineSchema, &createPipelineRequest)
+	createPipelineRequest.Tags = mergeTags(createPipelineRequest.Tags)
 	adjustForceSendFields(&createPipelineRequest.Clusters)
 
 	createdPipeline, err := w.Pipelines.Create(ctx, createPipelineRequest.CreatePipeline)
@@ -57,6 +61,13 @@ func Create(w *databricks.WorkspaceClient, ctx context.Context, d *schema.Resour
 		return err
 	}
 	d.SetId(id)
+	// the pipeline is created successfully, so a failed update must not taint it, as its replacement
+	// would drop managed tables of the pipeline
+	if err = runOnApply(w, ctx, d, timeout); err != nil {
+		common.AddWarning(ctx, fmt.Sprintf("Update of pipeline %s has not completed", id),
+			fmt.Sprintf("The pipeline is created, but %s. The update is started again after the next change "+
+				"of the pipeline.", err))
+	}
 	return nil
 }
 
@@ -66,18 +77,34 @@ func Read(w *databricks.WorkspaceClient, ctx context.Context, id string) (*pipel
 	})
 }
 
-func Update(w *databricks.WorkspaceClient, ctx context.Context, d *schema.ResourceData, timeout time.Duration) error {
+func Update(w *databricks.WorkspaceClient, ctx context.Context, d *schema.ResourceData, timeout time.Duration,
+	mergeTags func(map[string]string) map[string]string) error {
 	var updatePipelineRequest updatePipelineRequestStruct
 	common.DataToStructPointer(d, pipelineSchema, &updatePipelineRequest)
+	updatePipelineRequest.Tags = mergeTags(updatePipelineRequest.Tags)
 	updatePipelineRequest.EditPipeline.PipelineId = d.Id()
 	adjustForceSendFields(&updatePipelineRequest.Clusters)
 	err := w.Pipelines.Update(ctx, updatePipelineRequest.EditPipeline)
 	if err != nil {
 		return err
 	}
-	return waitForState(w, ctx, d.Id(), timeout, pipelines.PipelineStateRunning)
+	err = waitForState(w, ctx, d.Id(), timeout, pipelines.PipelineStateRunning)
+	if err != nil {
+		return err
+	}
+	if updatePipelineRequest.Continuous && d.Get("control_run_state").(bool) {
+		return restartContinuousPipeline(w, ctx, d.Id(), timeout)
+	}
+	if !d.HasChangesExcept(runOnApplySkippedFields...) {
+		return nil
+	}
+	return runOnApply(w, ctx, d, timeout)
 }
 
+// runOnApplySkippedFields don't change the pipeline specification, so their changes don't start an update
+// of the pipeline with `run_on_apply`
+var runOnApplySkippedFields = []string{"run_on_apply", "control_run_state", "tags", common.TagsAllField}
+
 func Delete(w *databricks.WorkspaceClient, ctx context.Context, id string, timeout time.Duration) error {
 	err := w.Pipelines.Delete(ctx, pipelines.DeletePipelineRequest{
 		PipelineId: id,
@@ -100,7 +127,11 @@ func Delete(w *databricks.WorkspaceClient, ctx context.Context, id string, timeo
 		})
 }
 
-func waitForState(w *databricks.WorkspaceClient, ctx context.Context, id string, timeout time.Duration, desiredState pipelines.PipelineState) error {
+func waitForState(w *databricks.WorkspaceClient, ctx context.Context, id string, timeout time.Duration, desiredState pipelines.PipelineState) (err error) {
+	ctx, span := common.StartSpan(ctx, "waitForState",
+		attribute.String("databricks.pipeline_id", id),
+		attribute.String("databricks.desired_state", string(desiredState)))
+	defer func() { common.EndSpan(span, err) }()
 	return retry.RetryContext(ctx, timeout,
 		func() *retry.RetryError {
 			i, err := Read(w, ctx, id)
@@ -124,6 +155,136 @@ func waitForState(w *databricks.WorkspaceClient, ctx context.Context, id string,
 		})
 }
 
+// runOnApply starts a pipeline update if the `run_on_apply` block is configured
+// and waits for it to complete.
+func runOnApply(w *databricks.WorkspaceClient, ctx context.Context, d *schema.ResourceData, timeout time.Duration) error {
+	var p Pipeline
+	common.DataToStructPointer(d, pipelineSchema, &p)
+	if p.RunOnApply == nil {
+		return nil
+	}
+	update, err := w.Pipelines.StartUpdate(ctx, pipelines.StartUpdate{
+		PipelineId:           d.Id(),
+		FullRefresh:          p.RunOnApply.FullRefresh,
+		RefreshSelection:     p.RunOnApply.RefreshSelection,
+		FullRefreshSelection: p.RunOnApply.FullRefreshSelection,
+	})
+	if err != nil {
+		return err
+	}
+	return waitForUpdate(w, ctx, d.Id(), update.UpdateId, timeout)
+}
+
+// restartContinuousPipeline stops the active update of a continuous pipeline, so that
+// a new update picks up the changed pipeline specification.
+func restartContinuousPipeline(w *databricks.WorkspaceClient, ctx context.Context, id string, timeout time.Duration) error {
+	_, err := w.Pipelines.Stop(ctx, pipelines.StopRequest{
+		PipelineId: id,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Pipelines.WaitGetPipelineIdle(ctx, id, timeout, nil)
+	if err != nil {
+		return err
+	}
+	_, err = w.Pipelines.StartUpdate(ctx, pipelines.StartUpdate{
+		PipelineId: id,
+	})
+	// The pipeline could be restarted automatically after it was stopped, in which case
+	// a conflict is returned. It's safe to ignore, as a new update has started anyway.
+	if err != nil && !errors.Is(err, databricks.ErrResourceConflict) {
+		return err
+	}
+	return waitForState(w, ctx, id, timeout, pipelines.PipelineStateRunning)
+}
+
+func waitForUpdate(w *databricks.WorkspaceClient, ctx context.Context, id, updateId string, timeout time.Duration) (err error) {
+	ctx, span := common.StartSpan(ctx, "waitForUpdate",
+		attribute.String("databricks.pipeline_id", id),
+		attribute.String("databricks.update_id", updateId))
+	defer func() { common.EndSpan(span, err) }()
+	return retry.RetryContext(ctx, timeout,
+		func() *retry.RetryError {
+			resp, err := w.Pipelines.GetUpdate(ctx, pipelines.GetUpdateRequest{
+				PipelineId: id,
+				UpdateId:   updateId,
+			})
+			if err != nil {
+				return retry.NonRetryableError(err)
+			}
+			if resp.Update == nil {
+				return retry.NonRetryableError(fmt.Errorf("update %s of pipeline %s is not found", updateId, id))
+			}
+			switch resp.Update.State {
+			case pipelines.UpdateInfoStateCompleted:
+				return nil
+			case pipelines.UpdateInfoStateFailed, pipelines.UpdateInfoStateCanceled:
+				return retry.NonRetryableError(updateError(w, ctx, resp.Update))
+			}
+			message := fmt.Sprintf("Update %s of pipeline %s is in state %s, not yet completed",
+				updateId, id, resp.Update.State)
+			log.Printf("[DEBUG] %s", message)
+			return retry.RetryableError(errors.New(message))
+		})
+}
+
+// maxUpdateErrors is the maximum number of event log errors included into the error message.
+const maxUpdateErrors = 5
+
+// updateError builds an error for a failed or cancelled update that includes
+// errors reported in the pipeline event log.
+func updateError(w *databricks.WorkspaceClient, ctx context.Context, update *pipelines.UpdateInfo) error {
+	message := fmt.Sprintf("update %s of pipeline %s is %s", update.UpdateId,
+		update.PipelineId, strings.ToLower(update.State.String()))
+	filter := "level='ERROR'"
+	if update.CreationTime > 0 {
+		filter += fmt.Sprintf(" AND timestamp >= '%s'",
+			time.UnixMilli(update.CreationTime).UTC().Format("2006-01-02T15:04:05.000Z"))
+	}
+	events, err := w.Pipelines.ListPipelineEventsAll(ctx, pipelines.ListPipelineEventsRequest{
+		PipelineId: update.PipelineId,
+		Filter:     filter,
+	})
+	if err != nil {
+		log.Printf("[WARN] Can't get event log of pipeline %s: %v", update.PipelineId, err)
+		return errors.New(message)
+	}
+	var errs []string
+	for _, e := range events {
+		if e.Origin != nil && e.Origin.UpdateId != "" && e.Origin.UpdateId != update.UpdateId {
+			continue
+		}
+		errs = append(errs, eventMessage(e))
+		if len(errs) == maxUpdateErrors {
+			break
+		}
+	}
+	if len(errs) == 0 {
+		return errors.New(message)
+	}
+	return fmt.Errorf("%s: %s", message, strings.Join(errs, "; "))
+}
+
+func eventMessage(e pipelines.PipelineEvent) string {
+	if e.Error == nil {
+		return e.Message
+	}
+	for _, ex := range e.Error.Exceptions {
+		if ex.Message != "" && ex.Message != e.Message {
+			return fmt.Sprintf("%s (%s)", e.Message, ex.Message)
+		}
+	}
+	return e.Message
+}
+
+// RunOnApply specifies the pipeline update that is started after the pipeline is created or changed.
+type RunOnApply struct {
+	FullRefresh          bool     `json:"full_refresh,omitempty"`
+	RefreshSelection     []string `json:"refresh_selection,omitempty"`
+	FullRefreshSelection []string `json:"full_refresh_selection,omitempty"`
+}
+
 type createPipelineRequestStruct struct {
 	pipelines.CreatePipeline
 }
@@ -173,6 +334,9 @@ type Pipeline struct {
 	State                pipelines.PipelineState             `json:"state,omitempty"`
 	// Provides the URL to the pipeline in the Databricks UI.
 	URL string `json:"url,omitempty"`
+	// Terraform-only fields that control pipeline updates after apply.
+	RunOnApply      *RunOnApply `json:"run_on_apply,omitempty"`
+	ControlRunState bool        `json:"control_run_state,omitempty"`
 }
 
 func (Pipeline) Aliases() map[string]map[string]string {
@@ -256,6 +420,8 @@ func (Pipeline) CustomizeSchema(s *common.CustomizableSchema) *common.Customizab
 	s.SchemaPath("ingestion_definition", "connection_name").SetConflictsWith([]string{"ingestion_definition.0.ingestion_gateway_id"})
 	s.SchemaPath("target").SetConflictsWith([]string{"schema"})
 	s.SchemaPath("schema").SetConflictsWith([]string{"target"})
+	s.SchemaPath("run_on_apply").SetConflictsWith([]string{"control_run_state"})
+	s.SchemaPath("control_run_state").SetConflictsWith([]string{"run_on_apply"})
 
 	// MinItems fields
 	s.SchemaPath("library").SetMinItems(1)
@@ -281,12 +447,24 @@ var pipelineSchema = common.StructToSchema(Pipeline{}, nil)
 func ResourcePipeline() common.Resource {
 	return common.Resource{
 		Schema: pipelineSchema,
+		// tags of the pipeline are also added to its clusters
+		TagsPath: []string{"tags"},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			continuous := d.Get("continuous").(bool)
+			if d.Get("control_run_state").(bool) && !continuous {
+				return fmt.Errorf("`control_run_state` must be specified only with `continuous`")
+			}
+			if _, ok := d.GetOk("run_on_apply"); ok && continuous {
+				return fmt.Errorf("`run_on_apply` can't be specified with `continuous`")
+			}
+			return nil
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			w, err := c.WorkspaceClient()
 			if err != nil {
 				return err
 			}
-			return Create(w, ctx, d, d.Timeout(schema.TimeoutCreate))
+			return Create(w, ctx, d, d.Timeout(schema.TimeoutCreate), c.MergeDefaultTags)
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			w, err := c.WorkspaceClient()
@@ -321,7 +499,7 @@ func ResourcePipeline() common.Resource {
 			if err != nil {
 				return err
 			}
-			return Update(w, ctx, d, d.Timeout(schema.TimeoutUpdate))
+			return Update(w, ctx, d, d.Timeout(schema.TimeoutUpdate), c.MergeDefaultTags)
 
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -335,5 +513,6 @@ func ResourcePipeline() common.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(DefaultTimeout),
 		},
+		WithIdentity: true,
 	}
 }
@@ -806,3 +806,299 @@ func TestDefault(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "abcd", d.Id())
 }
+
+func TestResourcePipelineCreate_RunOnApply(t *testing.T) {
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(w *mocks.MockWorkspaceClient) {
+			e := w.GetMockPipelinesAPI().EXPECT()
+			e.Create(mock.Anything, mock.Anything).Return(&pipelines.CreatePipelineResponse{
+				PipelineId: "abcd",
+			}, nil)
+			e.Get(mock.Anything, pipelines.GetPipelineRequest{
+				PipelineId: "abcd",
+			}).Return(&pipelines.GetPipelineResponse{
+				PipelineId: "abcd",
+				Name:       "test-pipeline",
+				State:      pipelines.PipelineStateIdle,
+				Spec:       &basicPipelineSpec,
+			}, nil)
+			e.StartUpdate(mock.Anything, pipelines.StartUpdate{
+				PipelineId:           "abcd",
+				FullRefreshSelection: []string{"sales"},
+			}).Return(&pipelines.StartUpdateResponse{
+				UpdateId: "u1",
+			}, nil)
+			e.GetUpdate(mock.Anything, pipelines.GetUpdateRequest{
+				PipelineId: "abcd",
+				UpdateId:   "u1",
+			}).Return(&pipelines.GetUpdateResponse{
+				Update: &pipelines.UpdateInfo{
+					PipelineId: "abcd",
+					UpdateId:   "u1",
+					State:      pipelines.UpdateInfoStateRunning,
+				},
+			}, nil).Once()
+			e.GetUpdate(mock.Anything, pipelines.GetUpdateRequest{
+				PipelineId: "abcd",
+				UpdateId:   "u1",
+			}).Return(&pipelines.GetUpdateResponse{
+				Update: &pipelines.UpdateInfo{
+					PipelineId: "abcd",
+					UpdateId:   "u1",
+					State:      pipelines.UpdateInfoStateCompleted,
+				},
+			}, nil).Once()
+		},
+		Resource: ResourcePipeline(),
+		Create:   true,
+		HCL: `name = "test-pipeline"
+		library {
+			notebook {
+				path = "/Test"
+			}
+		}
+		run_on_apply {
+			full_refresh_selection = ["sales"]
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id": "abcd",
+		"run_on_apply.0.full_refresh_selection.0": "sales",
+	})
+}
+
+func TestResourcePipelineUpdate_RunOnApplyFailed(t *testing.T) {
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(w *mocks.MockWorkspaceClient) {
+			e := w.GetMockPipelinesAPI().EXPECT()
+			e.Update(mock.Anything, mock.Anything).Return(nil)
+			e.Get(mock.Anything, pipelines.GetPipelineRequest{
+				PipelineId: "abcd",
+			}).Return(&pipelines.GetPipelineResponse{
+				PipelineId: "abcd",
+				Spec:       &basicPipelineSpec,
+				State:      pipelines.PipelineStateIdle,
+			}, nil)
+			e.StartUpdate(mock.Anything, pipelines.StartUpdate{
+				PipelineId:  "abcd",
+				FullRefresh: true,
+			}).Return(&pipelines.StartUpdateResponse{
+				UpdateId: "u1",
+			}, nil)
+			e.GetUpdate(mock.Anything, pipelines.GetUpdateRequest{
+				PipelineId: "abcd",
+				UpdateId:   "u1",
+			}).Return(&pipelines.GetUpdateResponse{
+				Update: &pipelines.UpdateInfo{
+					PipelineId:   "abcd",
+					UpdateId:     "u1",
+					State:        pipelines.UpdateInfoStateFailed,
+					CreationTime: 1700000000000,
+				},
+			}, nil)
+			e.ListPipelineEventsAll(mock.Anything, pipelines.ListPipelineEventsRequest{
+				PipelineId: "abcd",
+				Filter:     "level='ERROR' AND timestamp >= '2023-11-14T22:13:20.000Z'",
+			}).Return([]pipelines.PipelineEvent{
+				{
+					Level:   pipelines.EventLevelError,
+					Message: "Update u1 has failed",
+					Origin: &pipelines.Origin{
+						UpdateId: "u1",
+					},
+					Error: &pipelines.ErrorDetail{
+						Exceptions: []pipelines.SerializedException{
+							{
+								Message: "Table or view not found: sales",
+							},
+						},
+					},
+				},
+				{
+					Level:   pipelines.EventLevelError,
+					Message: "Update u0 has failed",
+					Origin: &pipelines.Origin{
+						UpdateId: "u0",
+					},
+				},
+			}, nil)
+		},
+		Resource: ResourcePipeline(),
+		HCL: `name = "test"
+		library {
+			notebook {
+				path = "/Test"
+			}
+		}
+		run_on_apply {
+			full_refresh = true
+		}`,
+		InstanceState: map[string]string{
+			"name": "test",
+		},
+		Update: true,
+		ID:     "abcd",
+	}.ExpectError(t, "update u1 of pipeline abcd is failed: Update u1 has failed (Table or view not found: sales)")
+}
+
+func TestResourcePipelineCreate_RunOnApplyFailedDoesNotTaint(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(w *mocks.MockWorkspaceClient) {
+			e := w.GetMockPipelinesAPI().EXPECT()
+			e.Create(mock.Anything, mock.Anything).Return(&pipelines.CreatePipelineResponse{
+				PipelineId: "abcd",
+			}, nil)
+			e.Get(mock.Anything, pipelines.GetPipelineRequest{
+				PipelineId: "abcd",
+			}).Return(&pipelines.GetPipelineResponse{
+				PipelineId: "abcd",
+				Name:       "test-pipeline",
+				State:      pipelines.PipelineStateIdle,
+				Spec:       &basicPipelineSpec,
+			}, nil)
+			e.StartUpdate(mock.Anything, pipelines.StartUpdate{
+				PipelineId:  "abcd",
+				FullRefresh: true,
+			}).Return(&pipelines.StartUpdateResponse{
+				UpdateId: "u1",
+			}, nil)
+			e.GetUpdate(mock.Anything, pipelines.GetUpdateRequest{
+				PipelineId: "abcd",
+				UpdateId:   "u1",
+			}).Return(&pipelines.GetUpdateResponse{
+				Update: &pipelines.UpdateInfo{
+					PipelineId:   "abcd",
+					UpdateId:     "u1",
+					State:        pipelines.UpdateInfoStateFailed,
+					CreationTime: 1700000000000,
+				},
+			}, nil)
+			e.ListPipelineEventsAll(mock.Anything, mock.Anything).Return([]pipelines.PipelineEvent{}, nil)
+		},
+		Resource: ResourcePipeline(),
+		Create:   true,
+		HCL: `name = "test-pipeline"
+		library {
+			notebook {
+				path = "/Test"
+			}
+		}
+		run_on_apply {
+			full_refresh = true
+		}
+		`,
+	}.Apply(t)
+	// no error means that the created pipeline isn't tainted and won't be replaced on the next apply
+	assert.NoError(t, err)
+	assert.Equal(t, "abcd", d.Id())
+}
+
+func TestResourcePipelineUpdate_RunOnApplySkippedForTagsOnlyChange(t *testing.T) {
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(w *mocks.MockWorkspaceClient) {
+			e := w.GetMockPipelinesAPI().EXPECT()
+			e.Update(mock.Anything, mock.Anything).Return(nil)
+			e.Get(mock.Anything, pipelines.GetPipelineRequest{
+				PipelineId: "abcd",
+			}).Return(&pipelines.GetPipelineResponse{
+				PipelineId: "abcd",
+				Spec:       &basicPipelineSpec,
+				State:      pipelines.PipelineStateIdle,
+			}, nil)
+		},
+		Resource: ResourcePipeline(),
+		HCL: `name = "test"
+		tags = {
+			team = "sales"
+		}
+		run_on_apply {
+			full_refresh = true
+		}`,
+		InstanceState: map[string]string{
+			"name":                        "test",
+			"edition":                     "ADVANCED",
+			"channel":                     "CURRENT",
+			"run_on_apply.#":              "1",
+			"run_on_apply.0.full_refresh": "true",
+		},
+		Update: true,
+		ID:     "abcd",
+	}.ApplyNoError(t)
+}
+
+func TestResourcePipelineUpdate_ControlRunState(t *testing.T) {
+	spec := basicPipelineSpec
+	spec.Continuous = true
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(w *mocks.MockWorkspaceClient) {
+			e := w.GetMockPipelinesAPI().EXPECT()
+			e.Update(mock.Anything, mock.Anything).Return(nil)
+			e.Get(mock.Anything, pipelines.GetPipelineRequest{
+				PipelineId: "abcd",
+			}).Return(&pipelines.GetPipelineResponse{
+				PipelineId: "abcd",
+				Spec:       &spec,
+				State:      pipelines.PipelineStateRunning,
+			}, nil)
+			e.Stop(mock.Anything, pipelines.StopRequest{
+				PipelineId: "abcd",
+			}).Return(&pipelines.WaitGetPipelineIdle[struct{}]{}, nil)
+			e.WaitGetPipelineIdle(mock.Anything, "abcd", mock.Anything, mock.Anything).Return(
+				&pipelines.GetPipelineResponse{
+					PipelineId: "abcd",
+					State:      pipelines.PipelineStateIdle,
+				}, nil)
+			e.StartUpdate(mock.Anything, pipelines.StartUpdate{
+				PipelineId: "abcd",
+			}).Return(&pipelines.StartUpdateResponse{
+				UpdateId: "u1",
+			}, nil)
+		},
+		Resource: ResourcePipeline(),
+		HCL: `name = "test"
+		library {
+			notebook {
+				path = "/Test"
+			}
+		}
+		continuous = true
+		control_run_state = true`,
+		InstanceState: map[string]string{
+			"name":       "test",
+			"continuous": "true",
+		},
+		Update: true,
+		ID:     "abcd",
+	}.ApplyNoError(t)
+}
+
+func TestResourcePipeline_ControlRunStateRequiresContinuous(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourcePipeline(),
+		HCL: `name = "test"
+		library {
+			notebook {
+				path = "/Test"
+			}
+		}
+		control_run_state = true`,
+		Create: true,
+	}.ExpectError(t, "`control_run_state` must be specified only with `continuous`")
+}
+
+func TestResourcePipeline_RunOnApplyConflictsWithContinuous(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourcePipeline(),
+		HCL: `name = "test"
+		library {
+			notebook {
+				path = "/Test"
+			}
+		}
+		continuous = true
+		run_on_apply {
+			full_refresh = true
+		}`,
+		Create: true,
+	}.ExpectError(t, "`run_on_apply` can't be specified with `continuous`")
+}
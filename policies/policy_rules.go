@@ -0,0 +1,540 @@
+package policies
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// PolicyRule is a typed representation of a single cluster policy rule, that is
+// serialized into the policy definition JSON
+type PolicyRule struct {
+	Path         string   `json:"path"`
+	Type         string   `json:"type"`
+	Value        string   `json:"value,omitempty"`
+	Values       []string `json:"values,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+	MinValue     *float64 `json:"min_value,omitempty"`
+	MaxValue     *float64 `json:"max_value,omitempty"`
+	DefaultValue string   `json:"default_value,omitempty"`
+	IsOptional   bool     `json:"is_optional,omitempty"`
+	Hidden       bool     `json:"hidden,omitempty"`
+}
+
+// policyRuleSchema is written explicitly, as zero is a valid bound of the range rule and
+// bounds are pointers, that aren't supported by common.StructToSchema
+func policyRuleSchema() *schema.Schema {
+	optionalString := func() *schema.Schema {
+		return &schema.Schema{Type: schema.TypeString, Optional: true}
+	}
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"path": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(policyRuleTypes(), false),
+				},
+				"value": optionalString(),
+				"values": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"pattern": optionalString(),
+				"min_value": {
+					Type:     schema.TypeFloat,
+					Optional: true,
+				},
+				"max_value": {
+					Type:     schema.TypeFloat,
+					Optional: true,
+				},
+				"default_value": optionalString(),
+				"is_optional": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"hidden": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// policyRuleJSON is the wire format of the policy rule
+type policyRuleJSON struct {
+	Type         string   `json:"type"`
+	Value        any      `json:"value,omitempty"`
+	Values       []any    `json:"values,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+	MinValue     *float64 `json:"minValue,omitempty"`
+	MaxValue     *float64 `json:"maxValue,omitempty"`
+	DefaultValue any      `json:"defaultValue,omitempty"`
+	IsOptional   bool     `json:"isOptional,omitempty"`
+	Hidden       bool     `json:"hidden,omitempty"`
+}
+
+const (
+	policyValueString  = "string"
+	policyValueNumber  = "number"
+	policyValueBoolean = "boolean"
+	// free-form attributes, like `spark_conf.*`, accept values of any type
+	policyValueAny = "any"
+)
+
+// policyRuleArguments lists type-specific arguments of each policy rule type
+var policyRuleArguments = map[string]map[string]bool{
+	"fixed":     {"value": true, "hidden": true},
+	"forbidden": {},
+	"allowlist": {"values": true, "default_value": true, "is_optional": true},
+	"blocklist": {"values": true, "default_value": true, "is_optional": true},
+	"regex":     {"pattern": true, "default_value": true, "is_optional": true},
+	"range":     {"min_value": true, "max_value": true, "default_value": true, "is_optional": true},
+	"unlimited": {"default_value": true, "is_optional": true},
+}
+
+// policyAttributePaths lists cluster attribute paths, that could be used in policy rules,
+// including virtual attributes, with types of their values
+var policyAttributePaths = map[string]string{
+	"autoscale.max_workers":                    policyValueNumber,
+	"autoscale.min_workers":                    policyValueNumber,
+	"autotermination_minutes":                  policyValueNumber,
+	"aws_attributes.availability":              policyValueString,
+	"aws_attributes.ebs_volume_count":          policyValueNumber,
+	"aws_attributes.ebs_volume_iops":           policyValueNumber,
+	"aws_attributes.ebs_volume_size":           policyValueNumber,
+	"aws_attributes.ebs_volume_throughput":     policyValueNumber,
+	"aws_attributes.ebs_volume_type":           policyValueString,
+	"aws_attributes.first_on_demand":           policyValueNumber,
+	"aws_attributes.instance_profile_arn":      policyValueString,
+	"aws_attributes.spot_bid_price_percent":    policyValueNumber,
+	"aws_attributes.zone_id":                   policyValueString,
+	"azure_attributes.availability":            policyValueString,
+	"azure_attributes.first_on_demand":         policyValueNumber,
+	"azure_attributes.spot_bid_max_price":      policyValueNumber,
+	"cluster_log_conf.path":                    policyValueString,
+	"cluster_log_conf.region":                  policyValueString,
+	"cluster_log_conf.type":                    policyValueString,
+	"cluster_name":                             policyValueString,
+	"cluster_type":                             policyValueString,
+	"data_security_mode":                       policyValueString,
+	"dbus_per_hour":                            policyValueNumber,
+	"docker_image.basic_auth.password":         policyValueString,
+	"docker_image.basic_auth.username":         policyValueString,
+	"docker_image.url":                         policyValueString,
+	"driver_instance_pool_id":                  policyValueString,
+	"driver_node_type_id":                      policyValueString,
+	"enable_elastic_disk":                      policyValueBoolean,
+	"enable_local_disk_encryption":             policyValueBoolean,
+	"gcp_attributes.availability":              policyValueString,
+	"gcp_attributes.boot_disk_size":            policyValueNumber,
+	"gcp_attributes.first_on_demand":           policyValueNumber,
+	"gcp_attributes.google_service_account":    policyValueString,
+	"gcp_attributes.local_ssd_count":           policyValueNumber,
+	"gcp_attributes.use_preemptible_executors": policyValueBoolean,
+	"gcp_attributes.zone_id":                   policyValueString,
+	"instance_pool_id":                         policyValueString,
+	"is_single_node":                           policyValueBoolean,
+	"kind":                                     policyValueString,
+	"node_type_id":                             policyValueString,
+	"num_workers":                              policyValueNumber,
+	"runtime_engine":                           policyValueString,
+	"single_user_name":                         policyValueString,
+	"spark_version":                            policyValueString,
+	"use_ml_runtime":                           policyValueBoolean,
+	"workload_type.clients.jobs":               policyValueBoolean,
+	"workload_type.clients.notebooks":          policyValueBoolean,
+}
+
+// prefixes of attribute paths with user-defined keys or list indexes
+var policyAttributePrefixes = map[string]string{
+	"custom_tags.":     policyValueString,
+	"init_scripts.":    policyValueString,
+	"spark_conf.":      policyValueAny,
+	"spark_env_vars.":  policyValueAny,
+	"ssh_public_keys.": policyValueString,
+}
+
+func policyRuleTypes() []string {
+	types := []string{}
+	for t := range policyRuleArguments {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func policyAttributeKind(path string) (string, bool) {
+	if kind, ok := policyAttributePaths[path]; ok {
+		return kind, true
+	}
+	for prefix, kind := range policyAttributePrefixes {
+		if strings.HasPrefix(path, prefix) && len(path) > len(prefix) {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// policyValueToJSON converts the value from HCL string into JSON value according
+// to the type of the cluster attribute
+func policyValueToJSON(path, kind, value string) (any, error) {
+	switch kind {
+	case policyValueNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: value %q must be a number", path, value)
+		}
+		return n, nil
+	case policyValueBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: value %q must be a boolean", path, value)
+		}
+		return b, nil
+	}
+	return value, nil
+}
+
+// policyValueFromJSON converts the JSON value back into HCL string
+func policyValueFromJSON(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	return fmt.Sprint(v)
+}
+
+// setPolicyRuleArguments returns names of type-specific arguments, that are set in the rule
+func setPolicyRuleArguments(rule PolicyRule) (args []string) {
+	for arg, isSet := range map[string]bool{
+		"value":         rule.Value != "",
+		"values":        len(rule.Values) > 0,
+		"pattern":       rule.Pattern != "",
+		"min_value":     rule.MinValue != nil,
+		"max_value":     rule.MaxValue != nil,
+		"default_value": rule.DefaultValue != "",
+		"is_optional":   rule.IsOptional,
+		"hidden":        rule.Hidden,
+	} {
+		if isSet {
+			args = append(args, arg)
+		}
+	}
+	sort.Strings(args)
+	return
+}
+
+// validatePolicyRule checks the rule against known rule types and cluster attribute paths
+func validatePolicyRule(rule PolicyRule) (string, error) {
+	allowed, ok := policyRuleArguments[rule.Type]
+	if !ok {
+		return "", fmt.Errorf("%s: unknown rule type %q, expected one of: %s",
+			rule.Path, rule.Type, strings.Join(policyRuleTypes(), ", "))
+	}
+	kind, ok := policyAttributeKind(rule.Path)
+	if !ok {
+		return "", fmt.Errorf("%s: unknown cluster attribute path", rule.Path)
+	}
+	for _, arg := range setPolicyRuleArguments(rule) {
+		if !allowed[arg] {
+			return "", fmt.Errorf("%s: argument %s is not supported by %s rule", rule.Path, arg, rule.Type)
+		}
+	}
+	switch rule.Type {
+	case "fixed":
+		if rule.Value == "" {
+			return "", fmt.Errorf("%s: fixed rule requires value", rule.Path)
+		}
+	case "allowlist", "blocklist":
+		if len(rule.Values) == 0 {
+			return "", fmt.Errorf("%s: %s rule requires values", rule.Path, rule.Type)
+		}
+	case "regex":
+		if rule.Pattern == "" {
+			return "", fmt.Errorf("%s: regex rule requires pattern", rule.Path)
+		}
+	case "range":
+		if kind != policyValueNumber && kind != policyValueAny {
+			return "", fmt.Errorf("%s: range rule is only supported for numeric attributes", rule.Path)
+		}
+		if rule.MinValue == nil && rule.MaxValue == nil {
+			return "", fmt.Errorf("%s: range rule requires min_value or max_value", rule.Path)
+		}
+		if rule.MinValue != nil && rule.MaxValue != nil && *rule.MinValue > *rule.MaxValue {
+			return "", fmt.Errorf("%s: min_value must not be greater than max_value", rule.Path)
+		}
+	}
+	return kind, nil
+}
+
+// policyRulesToDefinition validates rules and serializes them into canonical policy definition JSON
+func policyRulesToDefinition(rules []PolicyRule) (string, error) {
+	definition := map[string]policyRuleJSON{}
+	for _, rule := range rules {
+		kind, err := validatePolicyRule(rule)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := definition[rule.Path]; ok {
+			return "", fmt.Errorf("%s: only one rule per attribute path is allowed", rule.Path)
+		}
+		r := policyRuleJSON{
+			Type:       rule.Type,
+			Pattern:    rule.Pattern,
+			IsOptional: rule.IsOptional,
+			Hidden:     rule.Hidden,
+		}
+		if rule.Value != "" {
+			if r.Value, err = policyValueToJSON(rule.Path, kind, rule.Value); err != nil {
+				return "", err
+			}
+		}
+		for _, v := range rule.Values {
+			value, err := policyValueToJSON(rule.Path, kind, v)
+			if err != nil {
+				return "", err
+			}
+			r.Values = append(r.Values, value)
+		}
+		if rule.DefaultValue != "" {
+			if r.DefaultValue, err = policyValueToJSON(rule.Path, kind, rule.DefaultValue); err != nil {
+				return "", err
+			}
+		}
+		r.MinValue = rule.MinValue
+		r.MaxValue = rule.MaxValue
+		definition[rule.Path] = r
+	}
+	// maps are marshalled with sorted keys, so the result is canonical
+	raw, err := json.Marshal(definition)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// policyDefinitionToRules parses policy definition JSON into typed rules, sorted by path
+func policyDefinitionToRules(definition string) ([]PolicyRule, error) {
+	if definition == "" {
+		return nil, nil
+	}
+	var parsed map[string]policyRuleJSON
+	err := json.Unmarshal([]byte(definition), &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse policy definition: %w", err)
+	}
+	rules := []PolicyRule{}
+	for path, r := range parsed {
+		rule := PolicyRule{
+			Path:         path,
+			Type:         r.Type,
+			Value:        policyValueFromJSON(r.Value),
+			Pattern:      r.Pattern,
+			DefaultValue: policyValueFromJSON(r.DefaultValue),
+			IsOptional:   r.IsOptional,
+			Hidden:       r.Hidden,
+			MinValue:     r.MinValue,
+			MaxValue:     r.MaxValue,
+		}
+		for _, v := range r.Values {
+			rule.Values = append(rule.Values, policyValueFromJSON(v))
+		}
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Path < rules[j].Path
+	})
+	return rules, nil
+}
+
+// suppressDiffPolicyDefinition suppresses diffs between semantically equal policy definitions
+func suppressDiffPolicyDefinition(k, old, new string, d *schema.ResourceData) bool {
+	var o, n any
+	if json.Unmarshal([]byte(old), &o) == nil && json.Unmarshal([]byte(new), &n) == nil {
+		return reflect.DeepEqual(o, n)
+	}
+	return common.SuppressDiffWhitespaceChange(k, old, new, d)
+}
+
+// isUnconfigured returns true, if the top-level attribute is missing in the known raw configuration
+func isUnconfigured(raw cty.Value, name string) bool {
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(name) {
+		return false
+	}
+	return raw.GetAttr(name).IsNull()
+}
+
+// configuredRangeBounds returns paths of rules with `min_value` and `max_value` present in the
+// configuration. Values in the set are zero when not configured, so the raw configuration is used.
+// It's null outside of plan and apply, so bounds are present there, if they aren't zero.
+func configuredRangeBounds(raw cty.Value) (minValues, maxValues map[string]bool) {
+	minValues, maxValues = map[string]bool{}, map[string]bool{}
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute("rule") {
+		return
+	}
+	rules := raw.GetAttr("rule")
+	if rules.IsNull() || !rules.IsKnown() || !rules.CanIterateElements() {
+		return
+	}
+	for it := rules.ElementIterator(); it.Next(); {
+		_, rule := it.Element()
+		if rule.IsNull() || !rule.IsKnown() {
+			continue
+		}
+		path := rule.GetAttr("path")
+		if path.IsNull() || !path.IsKnown() {
+			continue
+		}
+		minValues[path.AsString()] = !rule.GetAttr("min_value").IsNull()
+		maxValues[path.AsString()] = !rule.GetAttr("max_value").IsNull()
+	}
+	return
+}
+
+// getPolicyRules reads `rule` blocks directly from the set, as nested lists can't be
+// reliably addressed by the hash of the set element
+func getPolicyRules(d interface {
+	Get(string) any
+	GetRawConfig() cty.Value
+}) (rules []PolicyRule) {
+	set, ok := d.Get("rule").(*schema.Set)
+	if !ok {
+		return
+	}
+	minValues, maxValues := configuredRangeBounds(d.GetRawConfig())
+	for _, raw := range set.List() {
+		m := raw.(map[string]any)
+		rule := PolicyRule{}
+		rule.Path, _ = m["path"].(string)
+		rule.Type, _ = m["type"].(string)
+		rule.Value, _ = m["value"].(string)
+		rule.Pattern, _ = m["pattern"].(string)
+		rule.DefaultValue, _ = m["default_value"].(string)
+		rule.IsOptional, _ = m["is_optional"].(bool)
+		rule.Hidden, _ = m["hidden"].(bool)
+		if v, _ := m["min_value"].(float64); v != 0 || minValues[rule.Path] {
+			rule.MinValue = &v
+		}
+		if v, _ := m["max_value"].(float64); v != 0 || maxValues[rule.Path] {
+			rule.MaxValue = &v
+		}
+		values, _ := m["values"].([]any)
+		for _, v := range values {
+			value, _ := v.(string)
+			rule.Values = append(rule.Values, value)
+		}
+		rules = append(rules, rule)
+	}
+	return
+}
+
+// setDefinitionFromRules serializes `rule` blocks either into policy definition, or into
+// policy family definition overrides, if policy family is used
+func setDefinitionFromRules(d *schema.ResourceData, familyID string, definition, overrides *string) error {
+	rules := getPolicyRules(d)
+	if len(rules) == 0 {
+		return nil
+	}
+	serialized, err := policyRulesToDefinition(rules)
+	if err != nil {
+		return err
+	}
+	if familyID != "" {
+		*overrides = serialized
+	} else {
+		*definition = serialized
+	}
+	return nil
+}
+
+// policyRuleToMap converts the rule into an element of `rule` set
+func policyRuleToMap(rule PolicyRule) map[string]any {
+	values := []any{}
+	for _, v := range rule.Values {
+		values = append(values, v)
+	}
+	m := map[string]any{
+		"path":          rule.Path,
+		"type":          rule.Type,
+		"value":         rule.Value,
+		"values":        values,
+		"pattern":       rule.Pattern,
+		"min_value":     0.0,
+		"max_value":     0.0,
+		"default_value": rule.DefaultValue,
+		"is_optional":   rule.IsOptional,
+		"hidden":        rule.Hidden,
+	}
+	if rule.MinValue != nil {
+		m["min_value"] = *rule.MinValue
+	}
+	if rule.MaxValue != nil {
+		m["max_value"] = *rule.MaxValue
+	}
+	return m
+}
+
+// samePolicyRule returns true, if both rules serialize into the same definition, e.g. when
+// the server returns numeric values in another format, like `5` instead of `5.0`
+func samePolicyRule(a, b PolicyRule) bool {
+	x, err := policyRulesToDefinition([]PolicyRule{a})
+	if err != nil {
+		return false
+	}
+	y, err := policyRulesToDefinition([]PolicyRule{b})
+	if err != nil {
+		return false
+	}
+	return suppressDiffPolicyDefinition("rule", x, y, nil)
+}
+
+// readRulesFromDefinition updates `rule` blocks from the policy definition, if they are used.
+// Rules, that are semantically equal to the ones in the state, are kept as is.
+func readRulesFromDefinition(d *schema.ResourceData, familyID, definition, overrides string) error {
+	current := map[string]PolicyRule{}
+	for _, rule := range getPolicyRules(d) {
+		current[rule.Path] = rule
+	}
+	if len(current) == 0 {
+		return nil
+	}
+	if familyID != "" {
+		definition = overrides
+	}
+	rules, err := policyDefinitionToRules(definition)
+	if err != nil {
+		return err
+	}
+	set := []any{}
+	for _, rule := range rules {
+		if old, ok := current[rule.Path]; ok && samePolicyRule(old, rule) {
+			rule = old
+		}
+		set = append(set, policyRuleToMap(rule))
+	}
+	return d.Set("rule", set)
+}
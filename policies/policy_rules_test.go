@@ -0,0 +1,155 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func TestPolicyRulesToDefinition_Errors(t *testing.T) {
+	for expected, rule := range map[string]PolicyRule{
+		`spark_cnf.foo: unknown cluster attribute path`: {
+			Path: "spark_cnf.foo", Type: "fixed", Value: "bar"},
+		`spark_conf.: unknown cluster attribute path`: {
+			Path: "spark_conf.", Type: "fixed", Value: "bar"},
+		`num_workers: unknown rule type "fixd", expected one of: allowlist, blocklist, fixed, forbidden, range, regex, unlimited`: {
+			Path: "num_workers", Type: "fixd", Value: "1"},
+		`num_workers: fixed rule requires value`: {
+			Path: "num_workers", Type: "fixed"},
+		`num_workers: argument values is not supported by fixed rule`: {
+			Path: "num_workers", Type: "fixed", Value: "1", Values: []string{"1"}},
+		`node_type_id: argument hidden is not supported by allowlist rule`: {
+			Path: "node_type_id", Type: "allowlist", Values: []string{"i3.xlarge"}, Hidden: true},
+		`node_type_id: blocklist rule requires values`: {
+			Path: "node_type_id", Type: "blocklist"},
+		`cluster_name: regex rule requires pattern`: {
+			Path: "cluster_name", Type: "regex"},
+		`cluster_name: range rule is only supported for numeric attributes`: {
+			Path: "cluster_name", Type: "range", MaxValue: ptr(10)},
+		`num_workers: range rule requires min_value or max_value`: {
+			Path: "num_workers", Type: "range"},
+		`num_workers: min_value must not be greater than max_value`: {
+			Path: "num_workers", Type: "range", MinValue: ptr(10), MaxValue: ptr(1)},
+		`enable_elastic_disk: value "yes" must be a boolean`: {
+			Path: "enable_elastic_disk", Type: "fixed", Value: "yes"},
+	} {
+		_, err := policyRulesToDefinition([]PolicyRule{rule})
+		assert.EqualError(t, err, expected)
+	}
+	_, err := policyRulesToDefinition([]PolicyRule{
+		{Path: "num_workers", Type: "fixed", Value: "1"},
+		{Path: "num_workers", Type: "forbidden"},
+	})
+	assert.EqualError(t, err, "num_workers: only one rule per attribute path is allowed")
+}
+
+func TestPolicyRulesRoundTrip(t *testing.T) {
+	rules := []PolicyRule{
+		{Path: "autoscale.max_workers", Type: "range", MinValue: ptr(1), MaxValue: ptr(10), DefaultValue: "2",
+			IsOptional: true},
+		{Path: "cluster_name", Type: "regex", Pattern: "^team-.*"},
+		{Path: "init_scripts.0.volumes.destination", Type: "fixed", Value: "/Volumes/main/default/init.sh"},
+		{Path: "instance_pool_id", Type: "forbidden"},
+		{Path: "spark_conf.spark.databricks.delta.preview.enabled", Type: "fixed", Value: "true", Hidden: true},
+		{Path: "spark_version", Type: "unlimited", DefaultValue: "auto:latest-lts"},
+	}
+	definition, err := policyRulesToDefinition(rules)
+	require.NoError(t, err)
+	assert.Equal(t, `{"autoscale.max_workers":{"type":"range","minValue":1,"maxValue":10,"defaultValue":2,"isOptional":true},`+
+		`"cluster_name":{"type":"regex","pattern":"^team-.*"},`+
+		`"init_scripts.0.volumes.destination":{"type":"fixed","value":"/Volumes/main/default/init.sh"},`+
+		`"instance_pool_id":{"type":"forbidden"},`+
+		`"spark_conf.spark.databricks.delta.preview.enabled":{"type":"fixed","value":"true","hidden":true},`+
+		`"spark_version":{"type":"unlimited","defaultValue":"auto:latest-lts"}}`, definition)
+
+	parsed, err := policyDefinitionToRules(definition)
+	require.NoError(t, err)
+	assert.Equal(t, rules, parsed)
+}
+
+func TestPolicyRulesZeroRangeBounds(t *testing.T) {
+	rules := []PolicyRule{
+		{Path: "autotermination_minutes", Type: "range", MaxValue: ptr(0)},
+		{Path: "num_workers", Type: "range", MinValue: ptr(0), MaxValue: ptr(10)},
+	}
+	definition, err := policyRulesToDefinition(rules)
+	require.NoError(t, err)
+	assert.Equal(t, `{"autotermination_minutes":{"type":"range","maxValue":0},`+
+		`"num_workers":{"type":"range","minValue":0,"maxValue":10}}`, definition)
+
+	parsed, err := policyDefinitionToRules(definition)
+	require.NoError(t, err)
+	assert.Equal(t, rules, parsed)
+
+	_, err = policyRulesToDefinition([]PolicyRule{
+		{Path: "num_workers", Type: "range", MinValue: ptr(1), MaxValue: ptr(0)},
+	})
+	assert.EqualError(t, err, "num_workers: min_value must not be greater than max_value")
+}
+
+func TestConfiguredRangeBounds(t *testing.T) {
+	ruleType := cty.Object(map[string]cty.Type{
+		"path":      cty.String,
+		"min_value": cty.Number,
+		"max_value": cty.Number,
+	})
+	raw := cty.ObjectVal(map[string]cty.Value{
+		"rule": cty.SetVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"path":      cty.StringVal("num_workers"),
+				"min_value": cty.NumberIntVal(0),
+				"max_value": cty.NumberIntVal(10),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"path":      cty.StringVal("autotermination_minutes"),
+				"min_value": cty.NullVal(cty.Number),
+				"max_value": cty.NumberIntVal(0),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"path":      cty.UnknownVal(cty.String),
+				"min_value": cty.NumberIntVal(1),
+				"max_value": cty.NullVal(cty.Number),
+			}),
+		}),
+	})
+	minValues, maxValues := configuredRangeBounds(raw)
+	assert.Equal(t, map[string]bool{"num_workers": true, "autotermination_minutes": false}, minValues)
+	assert.Equal(t, map[string]bool{"num_workers": true, "autotermination_minutes": true}, maxValues)
+
+	minValues, maxValues = configuredRangeBounds(cty.NullVal(cty.Object(map[string]cty.Type{
+		"rule": cty.Set(ruleType),
+	})))
+	assert.Empty(t, minValues)
+	assert.Empty(t, maxValues)
+}
+
+func TestSuppressDiffPolicyDefinition(t *testing.T) {
+	assert.True(t, suppressDiffPolicyDefinition("definition",
+		`{"a": {"type": "fixed", "value": 1}, "b": {"type": "forbidden"}}`,
+		`{"b":{"type":"forbidden"},"a":{"type":"fixed","value":1}}`, nil))
+	assert.False(t, suppressDiffPolicyDefinition("definition",
+		`{"a": {"type": "fixed", "value": 1}}`,
+		`{"a": {"type": "fixed", "value": "1"}}`, nil))
+	assert.True(t, suppressDiffPolicyDefinition("definition", "not json ", "not json", nil))
+}
+
+func TestSamePolicyRule(t *testing.T) {
+	assert.True(t, samePolicyRule(
+		PolicyRule{Path: "num_workers", Type: "fixed", Value: "5.0"},
+		PolicyRule{Path: "num_workers", Type: "fixed", Value: "5"}))
+	assert.True(t, samePolicyRule(
+		PolicyRule{Path: "num_workers", Type: "allowlist", Values: []string{"1.0", "2"}},
+		PolicyRule{Path: "num_workers", Type: "allowlist", Values: []string{"1", "2"}}))
+	assert.False(t, samePolicyRule(
+		PolicyRule{Path: "cluster_name", Type: "fixed", Value: "5.0"},
+		PolicyRule{Path: "cluster_name", Type: "fixed", Value: "5"}))
+	assert.False(t, samePolicyRule(
+		PolicyRule{Path: "num_workers", Type: "range", MaxValue: ptr(0)},
+		PolicyRule{Path: "num_workers", Type: "range", MinValue: ptr(0)}))
+}
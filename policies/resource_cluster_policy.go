@@ -34,13 +34,18 @@ var rcpSchema = common.StructToSchema(
 		}
 		m["definition"].ConflictsWith = []string{"policy_family_definition_overrides", "policy_family_id"}
 		m["definition"].Computed = true
-		m["definition"].DiffSuppressFunc = common.SuppressDiffWhitespaceChange
+		m["definition"].DiffSuppressFunc = suppressDiffPolicyDefinition
 
 		m["policy_family_definition_overrides"].ConflictsWith = []string{"definition"}
-		m["policy_family_definition_overrides"].DiffSuppressFunc = common.SuppressDiffWhitespaceChange
+		// overrides are computed from `rule` blocks, when they are used with policy family
+		m["policy_family_definition_overrides"].Computed = true
+		m["policy_family_definition_overrides"].DiffSuppressFunc = suppressDiffPolicyDefinition
 		m["policy_family_id"].ConflictsWith = []string{"definition"}
 		m["policy_family_definition_overrides"].RequiredWith = []string{"policy_family_id"}
 
+		m["rule"] = policyRuleSchema()
+		m["rule"].ConflictsWith = []string{"definition", "policy_family_definition_overrides"}
+
 		return m
 	})
 
@@ -56,6 +61,28 @@ func ResourceClusterPolicy() common.Resource {
 				Upgrade: removeZeroMaxClustersPerUser,
 			},
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			rules := getPolicyRules(d)
+			isFamily := d.Get("policy_family_id").(string) != ""
+			if len(rules) == 0 {
+				// overrides are only computed from rules, so they are removed, if neither is configured
+				if isFamily && isUnconfigured(d.GetRawConfig(), "policy_family_definition_overrides") &&
+					d.Get("policy_family_definition_overrides").(string) != "" {
+					return d.SetNew("policy_family_definition_overrides", "")
+				}
+				return nil
+			}
+			if _, err := policyRulesToDefinition(rules); err != nil {
+				return err
+			}
+			if !d.HasChange("rule") {
+				return nil
+			}
+			if isFamily {
+				return d.SetNewComputed("policy_family_definition_overrides")
+			}
+			return d.SetNewComputed("definition")
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			w, err := c.WorkspaceClient()
 			if err != nil {
@@ -64,6 +91,10 @@ func ResourceClusterPolicy() common.Resource {
 
 			var request compute.CreatePolicy
 			common.DataToStructPointer(d, rcpSchema, &request)
+			err = setDefinitionFromRules(d, request.PolicyFamilyId, &request.Definition, &request.PolicyFamilyDefinitionOverrides)
+			if err != nil {
+				return err
+			}
 
 			var clusterPolicy *compute.CreatePolicyResponse
 			if request.PolicyFamilyId != "" {
@@ -80,6 +111,7 @@ func ResourceClusterPolicy() common.Resource {
 					var editRequest compute.EditPolicy
 					common.DataToStructPointer(d, rcpSchema, &editRequest)
 					editRequest.PolicyId = resp.PolicyId
+					editRequest.PolicyFamilyDefinitionOverrides = request.PolicyFamilyDefinitionOverrides
 					err = w.ClusterPolicies.Edit(ctx, editRequest)
 				} else {
 					clusterPolicy, err = w.ClusterPolicies.Create(ctx, request)
@@ -102,7 +134,11 @@ func ResourceClusterPolicy() common.Resource {
 			if err != nil {
 				return err
 			}
-			return common.StructToData(resp, rcpSchema, d)
+			err = common.StructToData(resp, rcpSchema, d)
+			if err != nil {
+				return err
+			}
+			return readRulesFromDefinition(d, resp.PolicyFamilyId, resp.Definition, resp.PolicyFamilyDefinitionOverrides)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			w, err := c.WorkspaceClient()
@@ -113,6 +149,10 @@ func ResourceClusterPolicy() common.Resource {
 			var request compute.EditPolicy
 			common.DataToStructPointer(d, rcpSchema, &request)
 			request.PolicyId = d.Id()
+			err = setDefinitionFromRules(d, request.PolicyFamilyId, &request.Definition, &request.PolicyFamilyDefinitionOverrides)
+			if err != nil {
+				return err
+			}
 			if request.PolicyFamilyId != "" {
 				request.Definition = ""
 			}
@@ -8,7 +8,10 @@ import (
 	"github.com/databricks/databricks-sdk-go/service/compute"
 
 	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceClusterPolicyRead(t *testing.T) {
@@ -542,3 +545,215 @@ func TestResourceClusterPolicyDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestResourceClusterPolicyCreateWithRules(t *testing.T) {
+	definition := `{"autotermination_minutes":{"type":"range","maxValue":120,"defaultValue":60},` +
+		`"custom_tags.team":{"type":"fixed","value":"data"},` +
+		`"enable_elastic_disk":{"type":"fixed","value":true,"hidden":true},` +
+		`"node_type_id":{"type":"allowlist","values":["i3.xlarge","i3.2xlarge"]}}`
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/policies/clusters/create",
+				ExpectedRequest: compute.CreatePolicy{
+					Name:       "Dummy",
+					Definition: definition,
+				},
+				Response: compute.CreatePolicyResponse{
+					PolicyId: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: compute.Policy{
+					PolicyId: "abc",
+					Name:     "Dummy",
+					// server may return definition with different formatting
+					Definition: `{"node_type_id": {"type": "allowlist", "values": ["i3.xlarge", "i3.2xlarge"]},
+						"enable_elastic_disk": {"type": "fixed", "value": true, "hidden": true},
+						"custom_tags.team": {"type": "fixed", "value": "data"},
+						"autotermination_minutes": {"type": "range", "maxValue": 120, "defaultValue": 60}}`,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		rule {
+			path = "custom_tags.team"
+			type = "fixed"
+			value = "data"
+		}
+		rule {
+			path = "enable_elastic_disk"
+			type = "fixed"
+			value = "true"
+			hidden = true
+		}
+		rule {
+			path = "node_type_id"
+			type = "allowlist"
+			values = ["i3.xlarge", "i3.2xlarge"]
+		}
+		rule {
+			path = "autotermination_minutes"
+			type = "range"
+			max_value = 120
+			default_value = "60"
+		}`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 4, d.Get("rule.#"))
+}
+
+func TestResourceClusterPolicyCreateWithRules_PolicyFamily(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policy-families?",
+				Response: compute.ListPolicyFamiliesResponse{},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/policies/clusters/create",
+				ExpectedRequest: compute.CreatePolicy{
+					Name:                            "Dummy",
+					PolicyFamilyId:                  "personal-vm",
+					PolicyFamilyDefinitionOverrides: `{"spark_conf.foo":{"type":"fixed","value":"bar"}}`,
+				},
+				Response: compute.CreatePolicyResponse{
+					PolicyId: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: compute.Policy{
+					PolicyId:                        "abc",
+					Name:                            "Dummy",
+					PolicyFamilyId:                  "personal-vm",
+					Definition:                      `{"spark_conf.baz": {"type": "fixed", "value": "bar"}}`,
+					PolicyFamilyDefinitionOverrides: `{"spark_conf.foo":{"type":"fixed","value":"bar"}}`,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		policy_family_id = "personal-vm"
+		rule {
+			path = "spark_conf.foo"
+			type = "fixed"
+			value = "bar"
+		}`,
+		Create: true,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":     "abc",
+		"rule.#": 1,
+	})
+}
+
+func TestResourceClusterPolicyCreateWithRules_Invalid(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		rule {
+			path = "num_workers"
+			type = "fixed"
+			value = "many"
+		}`,
+		Create: true,
+	}.ExpectError(t, `num_workers: value "many" must be a number`)
+}
+
+func TestResourceClusterPolicyCreateWithRules_UnknownType(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		rule {
+			path = "num_workers"
+			type = "fixd"
+			value = "1"
+		}`,
+		Create: true,
+	}.ExpectError(t, "invalid config supplied. [rule] expected rule.0.type to be one of "+
+		"[allowlist blocklist fixed forbidden range regex unlimited], got fixd")
+}
+
+func TestResourceClusterPolicyCreateWithRules_NoDiffAfterCreate(t *testing.T) {
+	hcl := `
+	name = "Dummy"
+	policy_family_id = "personal-vm"
+	rule {
+		path = "num_workers"
+		type = "fixed"
+		value = "5.0"
+	}
+	rule {
+		path = "autotermination_minutes"
+		type = "range"
+		min_value = 10
+		max_value = 60.0
+	}`
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policy-families?",
+				Response: compute.ListPolicyFamiliesResponse{},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/policies/clusters/create",
+				ExpectedRequest: compute.CreatePolicy{
+					Name:           "Dummy",
+					PolicyFamilyId: "personal-vm",
+					PolicyFamilyDefinitionOverrides: `{"autotermination_minutes":{"type":"range","minValue":10,"maxValue":60},` +
+						`"num_workers":{"type":"fixed","value":5}}`,
+				},
+				Response: compute.CreatePolicyResponse{
+					PolicyId: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: compute.Policy{
+					PolicyId:       "abc",
+					Name:           "Dummy",
+					PolicyFamilyId: "personal-vm",
+					Definition:     `{"spark_conf.baz": {"type": "fixed", "value": "bar"}}`,
+					PolicyFamilyDefinitionOverrides: `{"num_workers": {"type": "fixed", "value": 5},
+						"autotermination_minutes": {"type": "range", "minValue": 10, "maxValue": 60}}`,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		HCL:      hcl,
+		Create:   true,
+	}.Apply(t)
+	require.NoError(t, err)
+	values := map[string]any{}
+	for _, rule := range d.Get("rule").(*schema.Set).List() {
+		values[rule.(map[string]any)["path"].(string)] = rule.(map[string]any)["value"]
+	}
+	// value is kept as configured, as it's equal to the one returned by the server
+	assert.Equal(t, map[string]any{"num_workers": "5.0", "autotermination_minutes": ""}, values)
+
+	// the next plan has no changes
+	qa.ResourceFixture{
+		Resource:      ResourceClusterPolicy(),
+		HCL:           hcl,
+		InstanceState: d.State().Attributes,
+		ExpectedDiff:  map[string]*terraform.ResourceAttrDiff{},
+		Read:          true,
+		ID:            "abc",
+	}.ApplyNoError(t)
+}
@@ -249,10 +249,12 @@ func ResourceInstancePool() common.Resource {
 		return s
 	})
 	return common.Resource{
-		Schema: s,
+		Schema:   s,
+		TagsPath: []string{"custom_tags"},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var ip InstancePool
 			common.DataToStructPointer(d, s, &ip)
+			ip.CustomTags = c.MergeDefaultTags(ip.CustomTags)
 			instancePoolInfo, err := NewInstancePoolsAPI(ctx, c).Create(ip)
 			if err != nil {
 				return err
@@ -270,6 +272,7 @@ func ResourceInstancePool() common.Resource {
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var ip InstancePool
 			common.DataToStructPointer(d, s, &ip)
+			ip.CustomTags = c.MergeDefaultTags(ip.CustomTags)
 			ip.InstancePoolID = d.Id()
 			return NewInstancePoolsAPI(ctx, c).Update(ip)
 		},
@@ -54,6 +54,65 @@ func TestResourceInstancePoolCreate(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceInstancePoolCreate_DefaultTags(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/create",
+				ExpectedRequest: InstancePool{
+					InstancePoolName:                   "Shared Pool",
+					MaxCapacity:                        1000,
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					CustomTags: map[string]string{
+						"cost_center": "engineering",
+						"team":        "data",
+					},
+				},
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					MaxCapacity:                        1000,
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					CustomTags: map[string]string{
+						"cost_center": "engineering",
+						"team":        "data",
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		DefaultTags: map[string]string{
+			"cost_center": "engineering",
+			"team":        "platform",
+		},
+		HCL: `
+		idle_instance_autotermination_minutes = 15
+		instance_pool_name = "Shared Pool"
+		max_capacity = 1000
+		node_type_id = "i3.xlarge"
+		custom_tags = {
+			team = "data"
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"team": "data"}, d.Get("custom_tags"))
+	assert.Equal(t, map[string]any{"cost_center": "engineering", "team": "data"}, d.Get("tags_all"))
+}
+
 func TestResourceInstancePoolCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -122,6 +122,8 @@ type ResourceFixture struct {
 	Gcp         bool
 	AccountID   string
 	Token       string
+	// DefaultTags are `default_tags` of the provider
+	DefaultTags map[string]string
 	// new resource
 	New bool
 }
@@ -281,6 +283,7 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 	if f.AccountID != "" {
 		config.AccountID = f.AccountID
 	}
+	client.SetDefaultTags(f.DefaultTags)
 	f.setDatabricksEnvironmentForTest(client, server.URL)
 	if len(f.HCL) > 0 {
 		var out any
@@ -304,7 +307,7 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 				strings.ReplaceAll(diagsToString(diags), "\"", ""))
 		}
 	}
-	schemaMap := schema.InternalMap(f.Resource.Schema)
+	schemaMap := schema.InternalMap(resource.Schema)
 	is := &terraform.InstanceState{
 		Attributes: f.InstanceState,
 	}
@@ -341,7 +344,7 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 	if execute != nil {
 		// this is a bit strange, but we'll fix it later
 		diags := execute(ctx, resourceData, client)
-		if diags != nil {
+		if diags.HasError() {
 			return resourceData, errors.New(diagsToString(diags))
 		}
 	}
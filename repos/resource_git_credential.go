@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -19,17 +20,36 @@ func isOnlyOneGitCredentialForProviderError(err error) bool {
 		(strings.Contains(errStr, "Only one Git credential is supported ") && strings.Contains(errStr, " at this time"))
 }
 
+// personalAccessTokenEnvVars are used for the personal access token, if neither it, nor its write-only variant
+// is configured.
+var personalAccessTokenEnvVars = []string{
+	"GITHUB_TOKEN",               // https://registry.terraform.io/providers/integrations/github/latest/docs
+	"GITLAB_TOKEN",               // https://registry.terraform.io/providers/gitlabhq/gitlab/latest/docs
+	"AZDO_PERSONAL_ACCESS_TOKEN", // https://registry.terraform.io/providers/microsoft/azuredevops/latest/docs
+}
+
+// getPersonalAccessToken returns the personal access token from the configuration, or from the environment.
+// The environment isn't the default of the attribute, as it would conflict with the write-only variant,
+// and would be stored in the state.
+func getPersonalAccessToken(d *schema.ResourceData) string {
+	if token := common.GetWriteOnlyStringOrValue(d, "personal_access_token"); token != "" {
+		return token
+	}
+	for _, name := range personalAccessTokenEnvVars {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
 func ResourceGitCredential() common.Resource {
 	s := common.StructToSchema(workspace.CreateCredentialsRequest{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		s["force"] = &schema.Schema{
 			Type:     schema.TypeBool,
 			Optional: true,
 		}
-		s["personal_access_token"].DefaultFunc = schema.MultiEnvDefaultFunc([]string{
-			"GITHUB_TOKEN",               // https://registry.terraform.io/providers/integrations/github/latest/docs
-			"GITLAB_TOKEN",               // https://registry.terraform.io/providers/gitlabhq/gitlab/latest/docs
-			"AZDO_PERSONAL_ACCESS_TOKEN", // https://registry.terraform.io/providers/microsoft/azuredevops/latest/docs
-		}, nil)
+		common.AddWriteOnlyVariant(s, "personal_access_token")
 		return s
 	})
 
@@ -44,6 +64,7 @@ func ResourceGitCredential() common.Resource {
 
 			var req workspace.CreateCredentialsRequest
 			common.DataToStructPointer(d, s, &req)
+			req.PersonalAccessToken = getPersonalAccessToken(d)
 			resp, err := w.GitCredentials.Create(ctx, req)
 
 			if err != nil {
@@ -59,6 +80,7 @@ func ResourceGitCredential() common.Resource {
 				}
 				var req workspace.UpdateCredentialsRequest
 				common.DataToStructPointer(d, s, &req)
+				req.PersonalAccessToken = getPersonalAccessToken(d)
 				req.CredentialId = creds[0].CredentialId
 
 				err = w.GitCredentials.Update(ctx, req)
@@ -91,6 +113,7 @@ func ResourceGitCredential() common.Resource {
 			var req workspace.UpdateCredentialsRequest
 
 			common.DataToStructPointer(d, s, &req)
+			req.PersonalAccessToken = getPersonalAccessToken(d)
 			cred_id, err := strconv.ParseInt(d.Id(), 10, 64)
 			if err != nil {
 				return err
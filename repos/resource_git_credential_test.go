@@ -9,7 +9,9 @@ import (
 	"github.com/databricks/databricks-sdk-go/experimental/mocks"
 	"github.com/databricks/databricks-sdk-go/service/workspace"
 	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceGitCredentialRead(t *testing.T) {
@@ -186,6 +188,42 @@ func TestResourceGitCredentialCreate(t *testing.T) {
 	})
 }
 
+func TestResourceGitCredentialCreate_WriteOnly(t *testing.T) {
+	resp := workspace.CreateCredentialsResponse{
+		CredentialId: 121232342,
+		GitProvider:  "gitHub",
+		GitUsername:  "test",
+	}
+	qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(w *mocks.MockWorkspaceClient) {
+			gmock := w.GetMockGitCredentialsAPI().EXPECT()
+			gmock.Create(mock.Anything, workspace.CreateCredentialsRequest{
+				GitProvider:         "gitHub",
+				GitUsername:         "test",
+				PersonalAccessToken: "12345",
+			}).
+				Return(&resp, nil)
+			gmock.Get(mock.Anything, workspace.GetCredentialsRequest{CredentialId: resp.CredentialId}).
+				Return(&workspace.GetCredentialsResponse{
+					CredentialId: resp.CredentialId,
+					GitProvider:  "gitHub",
+					GitUsername:  "test",
+				}, nil)
+		},
+		Resource: ResourceGitCredential(),
+		HCL: `
+		git_provider = "gitHub"
+		git_username = "test"
+		personal_access_token_wo = "12345"
+		personal_access_token_wo_version = 1
+		`,
+		Create: true,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                               "121232342",
+		"personal_access_token_wo_version": 1,
+	})
+}
+
 func TestResourceGitCredentialCreate_Error_OnlyOneGitCredential(t *testing.T) {
 	provider := "gitHub"
 	user := "test"
@@ -424,3 +462,75 @@ func TestGitCredentialCornerCases(t *testing.T) {
 		qa.CornerCaseSkipCRUD("create"),
 		qa.CornerCaseExpectError(`strconv.ParseInt: parsing "x": invalid syntax`))
 }
+
+func TestResourceGitCredentialCreate_WriteOnlyWithEnvironment(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "from-environment")
+	resp := workspace.CreateCredentialsResponse{
+		CredentialId: 121232342,
+		GitProvider:  "gitHub",
+		GitUsername:  "test",
+	}
+	d, err := qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(w *mocks.MockWorkspaceClient) {
+			gmock := w.GetMockGitCredentialsAPI().EXPECT()
+			gmock.Create(mock.Anything, workspace.CreateCredentialsRequest{
+				GitProvider:         "gitHub",
+				GitUsername:         "test",
+				PersonalAccessToken: "12345",
+			}).
+				Return(&resp, nil)
+			gmock.Get(mock.Anything, workspace.GetCredentialsRequest{CredentialId: resp.CredentialId}).
+				Return(&workspace.GetCredentialsResponse{
+					CredentialId: resp.CredentialId,
+					GitProvider:  "gitHub",
+					GitUsername:  "test",
+				}, nil)
+		},
+		Resource: ResourceGitCredential(),
+		HCL: `
+		git_provider = "gitHub"
+		git_username = "test"
+		personal_access_token_wo = "12345"
+		personal_access_token_wo_version = 1
+		`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "", d.Get("personal_access_token"))
+}
+
+func TestResourceGitCredentialCreate_Environment(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITLAB_TOKEN", "from-environment")
+	resp := workspace.CreateCredentialsResponse{
+		CredentialId: 121232342,
+		GitProvider:  "gitLab",
+		GitUsername:  "test",
+	}
+	d, err := qa.ResourceFixture{
+		MockWorkspaceClientFunc: func(w *mocks.MockWorkspaceClient) {
+			gmock := w.GetMockGitCredentialsAPI().EXPECT()
+			gmock.Create(mock.Anything, workspace.CreateCredentialsRequest{
+				GitProvider:         "gitLab",
+				GitUsername:         "test",
+				PersonalAccessToken: "from-environment",
+			}).
+				Return(&resp, nil)
+			gmock.Get(mock.Anything, workspace.GetCredentialsRequest{CredentialId: resp.CredentialId}).
+				Return(&workspace.GetCredentialsResponse{
+					CredentialId: resp.CredentialId,
+					GitProvider:  "gitLab",
+					GitUsername:  "test",
+				}, nil)
+		},
+		Resource: ResourceGitCredential(),
+		HCL: `
+		git_provider = "gitLab"
+		git_username = "test"
+		`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err)
+	// the token from the environment isn't stored in the state
+	assert.Equal(t, "", d.Get("personal_access_token"))
+}
@@ -0,0 +1,168 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+const (
+	// maximum number of OR clauses in a single SCIM filter
+	principalsFilterBatchSize = 50
+	// number of entities requested per page of SCIM filter results
+	principalsFilterPageSize = 100
+)
+
+type principalsData struct {
+	UserNames         []string          `json:"user_names,omitempty" tf:"slice_set"`
+	GroupDisplayNames []string          `json:"group_display_names,omitempty" tf:"slice_set"`
+	ApplicationIDs    []string          `json:"application_ids,omitempty" tf:"slice_set"`
+	Strict            bool              `json:"strict,omitempty"`
+	Users             map[string]string `json:"users,omitempty" tf:"computed"`
+	Groups            map[string]string `json:"groups,omitempty" tf:"computed"`
+	ServicePrincipals map[string]string `json:"service_principals,omitempty" tf:"computed"`
+	UnresolvedNames   []string          `json:"unresolved_names,omitempty" tf:"computed"`
+}
+
+// principalKind describes how principals of a single type are found with SCIM filters
+type principalKind struct {
+	// attribute, that is matched against the given names
+	attribute string
+	// name of principals in messages
+	name string
+	// returns IDs of principals matching the filter by the lowercase value of the matched attribute
+	find func(ctx context.Context, c *common.DatabricksClient, filter string) (map[string]string, error)
+}
+
+var (
+	userPrincipals = principalKind{
+		attribute: "userName",
+		name:      "users",
+		find: func(ctx context.Context, c *common.DatabricksClient, filter string) (map[string]string, error) {
+			users, err := NewUsersAPI(ctx, c).FilterPaged(filter, "id,userName", principalsFilterPageSize)
+			if err != nil {
+				return nil, err
+			}
+			found := map[string]string{}
+			for _, u := range users {
+				found[strings.ToLower(u.UserName)] = u.ID
+			}
+			return found, nil
+		},
+	}
+	groupPrincipals = principalKind{
+		attribute: "displayName",
+		name:      "groups",
+		find: func(ctx context.Context, c *common.DatabricksClient, filter string) (map[string]string, error) {
+			groups, err := NewGroupsAPI(ctx, c).FilterPaged(filter, "id,displayName", principalsFilterPageSize)
+			if err != nil {
+				return nil, err
+			}
+			found := map[string]string{}
+			for _, g := range groups {
+				found[strings.ToLower(g.DisplayName)] = g.ID
+			}
+			return found, nil
+		},
+	}
+	servicePrincipalPrincipals = principalKind{
+		attribute: "applicationId",
+		name:      "service principals",
+		find: func(ctx context.Context, c *common.DatabricksClient, filter string) (map[string]string, error) {
+			sps, err := NewServicePrincipalsAPI(ctx, c).FilterPaged(filter, "id,applicationId", principalsFilterPageSize)
+			if err != nil {
+				return nil, err
+			}
+			found := map[string]string{}
+			for _, sp := range sps {
+				found[strings.ToLower(sp.ApplicationID)] = sp.ID
+			}
+			return found, nil
+		},
+	}
+)
+
+// orFilter returns SCIM filter, that matches any of the given values of the attribute
+func orFilter(attribute string, values []string) string {
+	clauses := make([]string, 0, len(values))
+	for _, v := range values {
+		escaped := strings.ReplaceAll(strings.ReplaceAll(v, `\`, `\\`), `"`, `\"`)
+		clauses = append(clauses, fmt.Sprintf(`%s eq "%s"`, attribute, escaped))
+	}
+	return strings.Join(clauses, " or ")
+}
+
+// resolve returns IDs of the given names and the names, that couldn't be resolved. Names are matched
+// case-insensitively, like SCIM filters do.
+func (k principalKind) resolve(ctx context.Context, c *common.DatabricksClient,
+	names []string) (ids map[string]string, unresolved []string, err error) {
+	ids = map[string]string{}
+	found := map[string]string{}
+	for _, batch := range chunks(names, principalsFilterBatchSize) {
+		batchFound, err := k.find(ctx, c, orFilter(k.attribute, batch))
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot resolve %s: %w", k.name, err)
+		}
+		for value, id := range batchFound {
+			found[value] = id
+		}
+	}
+	for _, name := range names {
+		id, ok := found[strings.ToLower(name)]
+		if !ok {
+			unresolved = append(unresolved, name)
+			continue
+		}
+		ids[name] = id
+	}
+	return ids, unresolved, nil
+}
+
+// DataSourcePrincipals resolves many users, groups and service principals to their IDs with
+// a few batched SCIM requests
+func DataSourcePrincipals() common.Resource {
+	return common.DataResource(principalsData{}, func(ctx context.Context, e any, c *common.DatabricksClient) error {
+		data := e.(*principalsData)
+		data.Users = map[string]string{}
+		data.Groups = map[string]string{}
+		data.ServicePrincipals = map[string]string{}
+		data.UnresolvedNames = []string{}
+		for _, v := range []struct {
+			kind  principalKind
+			names []string
+			ids   map[string]string
+		}{
+			{userPrincipals, data.UserNames, data.Users},
+			{groupPrincipals, data.GroupDisplayNames, data.Groups},
+			{servicePrincipalPrincipals, data.ApplicationIDs, data.ServicePrincipals},
+		} {
+			if len(v.names) == 0 {
+				continue
+			}
+			names := append([]string{}, v.names...)
+			sort.Strings(names)
+			ids, unresolved, err := v.kind.resolve(ctx, c, names)
+			if err != nil {
+				return err
+			}
+			for name, id := range ids {
+				v.ids[name] = id
+			}
+			data.UnresolvedNames = append(data.UnresolvedNames, unresolved...)
+		}
+		if len(data.UnresolvedNames) == 0 {
+			return nil
+		}
+		message := fmt.Sprintf("cannot resolve %d principals: %s",
+			len(data.UnresolvedNames), strings.Join(data.UnresolvedNames, ", "))
+		if data.Strict {
+			return errors.New(message)
+		}
+		common.AddWarning(ctx, "Unresolved principals", message)
+		return nil
+	})
+}
@@ -0,0 +1,126 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrFilter(t *testing.T) {
+	assert.Equal(t, `userName eq "a@example.com" or userName eq "b\"c\\d"`,
+		orFilter("userName", []string{"a@example.com", `b"c\d`}))
+}
+
+func TestDataSourcePrincipals(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?attributes=id%2CuserName&count=100&filter=userName%20eq%20%22a%40example.com%22%20or%20userName%20eq%20%22b%40example.com%22%20or%20userName%20eq%20%22c%40example.com%22&startIndex=1",
+				Response: UserList{
+					TotalResults: 2,
+					Resources: []User{
+						{ID: "1", UserName: "A@example.com"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?attributes=id%2CuserName&count=100&filter=userName%20eq%20%22a%40example.com%22%20or%20userName%20eq%20%22b%40example.com%22%20or%20userName%20eq%20%22c%40example.com%22&startIndex=2",
+				Response: UserList{
+					TotalResults: 2,
+					Resources: []User{
+						{ID: "2", UserName: "b@example.com"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?attributes=id%2CdisplayName&count=100&filter=displayName%20eq%20%22admins%22&startIndex=1",
+				Response: GroupList{
+					TotalResults: 1,
+					Resources: []Group{
+						{ID: "10", DisplayName: "admins"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals?attributes=id%2CapplicationId&count=100&filter=applicationId%20eq%20%2200000000-0000-0000-0000-000000000001%22&startIndex=1",
+				Response: UserList{
+					TotalResults: 1,
+					Resources: []User{
+						{ID: "20", ApplicationID: "00000000-0000-0000-0000-000000000001"},
+					},
+				},
+			},
+		},
+		Resource: DataSourcePrincipals(),
+		HCL: `
+		user_names = ["b@example.com", "a@example.com", "c@example.com"]
+		group_display_names = ["admins"]
+		application_ids = ["00000000-0000-0000-0000-000000000001"]
+		`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"users": map[string]any{
+			"a@example.com": "1",
+			"b@example.com": "2",
+		},
+		"groups": map[string]any{
+			"admins": "10",
+		},
+		"service_principals": map[string]any{
+			"00000000-0000-0000-0000-000000000001": "20",
+		},
+		"unresolved_names": []any{"c@example.com"},
+	})
+}
+
+func TestDataSourcePrincipals_Strict(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?attributes=id%2CdisplayName&count=100&filter=displayName%20eq%20%22admins%22%20or%20displayName%20eq%20%22ghosts%22&startIndex=1",
+				Response: GroupList{
+					TotalResults: 1,
+					Resources: []Group{
+						{ID: "10", DisplayName: "admins"},
+					},
+				},
+			},
+		},
+		Resource: DataSourcePrincipals(),
+		HCL: `
+		group_display_names = ["admins", "ghosts"]
+		strict = true
+		`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "cannot resolve 1 principals: ghosts")
+}
+
+func TestDataSourcePrincipals_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?attributes=id%2CdisplayName&count=100&filter=displayName%20eq%20%22admins%22&startIndex=1",
+				Status:   500,
+				Response: map[string]string{
+					"detail": "internal error",
+				},
+			},
+		},
+		Resource:    DataSourcePrincipals(),
+		HCL:         `group_display_names = ["admins"]`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "cannot resolve groups: internal error")
+}
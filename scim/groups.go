@@ -51,6 +51,15 @@ func (a GroupsAPI) Filter(filter string, attributes string) (GroupList, error) {
 	return groups, err
 }
 
+// FilterPaged returns all groups matching the filter, requesting them by pages of the given size
+func (a GroupsAPI) FilterPaged(filter, attributes string, pageSize int) ([]Group, error) {
+	return filterPages(filter, attributes, pageSize, func(req map[string]string) ([]Group, int32, error) {
+		var groups GroupList
+		err := a.client.Scim(a.context, http.MethodGet, "/preview/scim/v2/Groups", req, &groups)
+		return groups.Resources, groups.TotalResults, err
+	})
+}
+
 func (a GroupsAPI) ReadByDisplayName(displayName, attributes string) (group Group, err error) {
 	groupList, err := a.Filter(fmt.Sprintf(`displayName eq "%s"`, displayName), attributes)
 	if err != nil {
@@ -0,0 +1,428 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/databricks-sdk-go/service/pipelines"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ownershipTransfer reassigns workspace objects owned by a user or service principal to another
+// principal, so that the former can be deleted or deactivated without leaving orphaned objects.
+type ownershipTransfer struct {
+	c *common.DatabricksClient
+	w *databricks.WorkspaceClient
+	// user name or application ID of the principal to be deleted
+	from string
+	// user name or application ID of the new owner
+	to string
+}
+
+// isAccountLevel returns true for providers configured with the account-level API
+func isAccountLevel(c *common.DatabricksClient) bool {
+	return c != nil && c.DatabricksClient != nil && c.Config.IsAccountClient() && c.Config.AccountID != ""
+}
+
+// transferOwnershipCustomizeDiff rejects `transfer_ownership_to` during plan, as account-level
+// providers can't reach workspace objects of the principal.
+func transferOwnershipCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, c *common.DatabricksClient) error {
+	if d.Get("transfer_ownership_to").(string) != "" && isAccountLevel(c) {
+		return fmt.Errorf("transfer_ownership_to: only supported with workspace-level provider")
+	}
+	return nil
+}
+
+func newOwnershipTransfer(c *common.DatabricksClient, from, to string) (ownershipTransfer, error) {
+	w, err := c.WorkspaceClient()
+	if err != nil {
+		return ownershipTransfer{}, err
+	}
+	return ownershipTransfer{c: c, w: w, from: from, to: to}, nil
+}
+
+func (t ownershipTransfer) newOwner(level iam.PermissionLevel) iam.AccessControlRequest {
+	if common.StringIsUUID(t.to) {
+		return iam.AccessControlRequest{ServicePrincipalName: t.to, PermissionLevel: level}
+	}
+	return iam.AccessControlRequest{UserName: t.to, PermissionLevel: level}
+}
+
+func (t ownershipTransfer) newRunAs() *jobs.JobRunAs {
+	if common.StringIsUUID(t.to) {
+		return &jobs.JobRunAs{ServicePrincipalName: t.to}
+	}
+	return &jobs.JobRunAs{UserName: t.to}
+}
+
+func (t ownershipTransfer) isFrom(userName, servicePrincipalName string) bool {
+	return userName == t.from || servicePrincipalName == t.from
+}
+
+// transferIsOwner changes IS_OWNER permission of the object, if it's owned by the principal
+func (t ownershipTransfer) transferIsOwner(ctx context.Context, requestObjectType, id string) error {
+	acl, err := t.w.Permissions.Get(ctx, iam.GetPermissionRequest{
+		RequestObjectId:   id,
+		RequestObjectType: requestObjectType,
+	})
+	if apierr.IsMissing(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, ac := range acl.AccessControlList {
+		if !t.isFrom(ac.UserName, ac.ServicePrincipalName) {
+			continue
+		}
+		for _, permission := range ac.AllPermissions {
+			if permission.PermissionLevel != iam.PermissionLevelIsOwner {
+				continue
+			}
+			tflog.Info(ctx, fmt.Sprintf("Transferring ownership of /%s/%s from %s to %s", requestObjectType, id, t.from, t.to))
+			_, err = t.w.Permissions.Update(ctx, iam.UpdateObjectPermissions{
+				RequestObjectId:   id,
+				RequestObjectType: requestObjectType,
+				AccessControlList: []iam.AccessControlRequest{t.newOwner(iam.PermissionLevelIsOwner)},
+			})
+			return err
+		}
+	}
+	return nil
+}
+
+// jobRunsAsFrom returns true, if the job runs as the principal. The list of jobs includes run_as only
+// for some jobs, so the job is read for the effective run_as_user_name otherwise.
+func (t ownershipTransfer) jobRunsAsFrom(ctx context.Context, job jobs.BaseJob) (bool, error) {
+	if job.Settings != nil && job.Settings.RunAs != nil {
+		return t.isFrom(job.Settings.RunAs.UserName, job.Settings.RunAs.ServicePrincipalName), nil
+	}
+	details, err := t.w.Jobs.GetByJobId(ctx, job.JobId)
+	if apierr.IsMissing(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if details.Settings != nil && details.Settings.RunAs != nil {
+		return t.isFrom(details.Settings.RunAs.UserName, details.Settings.RunAs.ServicePrincipalName), nil
+	}
+	return details.RunAsUserName == t.from, nil
+}
+
+func (t ownershipTransfer) jobs(ctx context.Context) error {
+	all, err := t.w.Jobs.ListAll(ctx, jobs.ListJobsRequest{})
+	if err != nil {
+		return err
+	}
+	for _, job := range all {
+		jobID := strconv.FormatInt(job.JobId, 10)
+		runAsFrom, err := t.jobRunsAsFrom(ctx, job)
+		if err != nil {
+			return fmt.Errorf("job %s: %w", jobID, err)
+		}
+		if job.CreatorUserName != t.from && !runAsFrom {
+			continue
+		}
+		err = t.transferIsOwner(ctx, "jobs", jobID)
+		if err != nil {
+			return fmt.Errorf("job %s: %w", jobID, err)
+		}
+		if runAsFrom {
+			tflog.Info(ctx, fmt.Sprintf("Changing run_as of job %s from %s to %s", jobID, t.from, t.to))
+			err = t.w.Jobs.Update(ctx, jobs.UpdateJob{
+				JobId: job.JobId,
+				NewSettings: &jobs.JobSettings{
+					RunAs: t.newRunAs(),
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("job %s: %w", jobID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (t ownershipTransfer) pipelines(ctx context.Context) error {
+	all, err := t.w.Pipelines.ListPipelinesAll(ctx, pipelines.ListPipelinesRequest{})
+	if err != nil {
+		return err
+	}
+	for _, pipeline := range all {
+		if pipeline.CreatorUserName != t.from && pipeline.RunAsUserName != t.from {
+			continue
+		}
+		err = t.transferIsOwner(ctx, "pipelines", pipeline.PipelineId)
+		if err != nil {
+			return fmt.Errorf("pipeline %s: %w", pipeline.PipelineId, err)
+		}
+	}
+	return nil
+}
+
+// clusters changes the owner of UI and API clusters. The owner can only be changed for terminated clusters,
+// so other clusters are left as they are and reported, instead of terminating them during the transfer.
+func (t ownershipTransfer) clusters(ctx context.Context) error {
+	all, err := t.w.Clusters.ListAll(ctx, compute.ListClustersRequest{})
+	if err != nil {
+		return err
+	}
+	var skipped []string
+	for _, cluster := range all {
+		if cluster.CreatorUserName != t.from || cluster.ClusterSource == compute.ClusterSourceJob {
+			continue
+		}
+		if cluster.State != compute.StateTerminated {
+			skipped = append(skipped, fmt.Sprintf("%s (%s)", cluster.ClusterId, cluster.State))
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("Changing owner of cluster %s from %s to %s", cluster.ClusterId, t.from, t.to))
+		err = t.w.Clusters.ChangeOwner(ctx, compute.ChangeClusterOwner{
+			ClusterId:     cluster.ClusterId,
+			OwnerUsername: t.to,
+		})
+		if err != nil {
+			return fmt.Errorf("cluster %s: %w", cluster.ClusterId, err)
+		}
+	}
+	if len(skipped) > 0 {
+		common.AddWarning(ctx, fmt.Sprintf("Owner of clusters created by %s is not changed", t.from),
+			fmt.Sprintf("The owner can only be changed for terminated clusters. Terminate these clusters "+
+				"and change their owner to %s: %s", t.to, strings.Join(skipped, ", ")))
+	}
+	return nil
+}
+
+func (t ownershipTransfer) warehouses(ctx context.Context) error {
+	all, err := t.w.Warehouses.ListAll(ctx, sql.ListWarehousesRequest{})
+	if err != nil {
+		return err
+	}
+	for _, warehouse := range all {
+		if warehouse.CreatorName != t.from {
+			continue
+		}
+		err = t.transferIsOwner(ctx, "sql/warehouses", warehouse.Id)
+		if err != nil {
+			return fmt.Errorf("warehouse %s: %w", warehouse.Id, err)
+		}
+	}
+	return nil
+}
+
+func (t ownershipTransfer) queries(ctx context.Context) error {
+	all, err := t.w.Queries.ListAll(ctx, sql.ListQueriesRequest{})
+	if err != nil {
+		return err
+	}
+	for _, query := range all {
+		if query.OwnerUserName != t.from {
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("Changing owner of query %s from %s to %s", query.Id, t.from, t.to))
+		_, err = t.w.Queries.Update(ctx, sql.UpdateQueryRequest{
+			Id:         query.Id,
+			UpdateMask: "owner_user_name",
+			Query: &sql.UpdateQueryRequestQuery{
+				OwnerUserName: t.to,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("query %s: %w", query.Id, err)
+		}
+	}
+	return nil
+}
+
+func (t ownershipTransfer) alerts(ctx context.Context) error {
+	all, err := t.w.Alerts.ListAll(ctx, sql.ListAlertsRequest{})
+	if err != nil {
+		return err
+	}
+	for _, alert := range all {
+		if alert.OwnerUserName != t.from {
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("Changing owner of alert %s from %s to %s", alert.Id, t.from, t.to))
+		_, err = t.w.Alerts.Update(ctx, sql.UpdateAlertRequest{
+			Id:         alert.Id,
+			UpdateMask: "owner_user_name",
+			Alert: &sql.UpdateAlertRequestAlert{
+				OwnerUserName: t.to,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("alert %s: %w", alert.Id, err)
+		}
+	}
+	return nil
+}
+
+// dashboards transfers legacy SQL dashboards. AI/BI dashboards are stored in the workspace
+// and are moved together with the home directory.
+func (t ownershipTransfer) dashboards(ctx context.Context) error {
+	all, err := t.w.Dashboards.ListAll(ctx, sql.ListDashboardsRequest{})
+	if err != nil {
+		return err
+	}
+	for _, dashboard := range all {
+		if dashboard.User == nil || dashboard.User.Email != t.from {
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("Changing owner of dashboard %s from %s to %s", dashboard.Id, t.from, t.to))
+		// DbsqlPermissions.TransferOwnership formats its struct-typed ObjectId into the URL,
+		// so the request is sent to the same endpoint with the ID of the dashboard instead.
+		err = t.c.Post(ctx, fmt.Sprintf("/preview/sql/permissions/%s/%s/transfer",
+			sql.OwnableObjectTypeDashboard, dashboard.Id), sql.TransferOwnershipRequest{NewOwner: t.to}, nil)
+		if err != nil {
+			return fmt.Errorf("dashboard %s: %w", dashboard.Id, err)
+		}
+	}
+	return nil
+}
+
+// homeDirectory copies the home directory of the principal object by object into the home directory of the
+// new owner, where it's archived. The original home directory is never removed by the transfer, so that objects
+// created after the copy aren't lost. kept is true, if any of the objects can't be copied, so that the original
+// home directory must not be removed by force_delete_home_dir either.
+func (t ownershipTransfer) homeDirectory(ctx context.Context) (kept bool, err error) {
+	home := path.Join("/Users", t.from)
+	_, err = t.w.Workspace.GetStatusByPath(ctx, home)
+	if apierr.IsMissing(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	target := path.Join("/Users", t.to, t.from)
+	tflog.Info(ctx, fmt.Sprintf("Copying home directory %s to %s", home, target))
+	err = t.w.Workspace.MkdirsByPath(ctx, target)
+	if err != nil {
+		return false, fmt.Errorf("cannot create %s: %w", target, err)
+	}
+	var copied, skipped []string
+	err = t.copyDirectory(ctx, home, target, &copied, &skipped)
+	if err != nil {
+		return false, err
+	}
+	if len(skipped) > 0 {
+		common.AddWarning(ctx, fmt.Sprintf("%s is not fully copied", home),
+			fmt.Sprintf("Git folders and libraries can't be copied to %s, so they are kept in %s: %s",
+				target, home, strings.Join(skipped, ", ")))
+		return true, nil
+	}
+	return false, nil
+}
+
+// copyDirectory copies the content of the source directory into the target directory. Paths of copied
+// objects are appended to copied, with directories before their content, and paths of objects, that
+// can't be exported, i.e. Git folders, are appended to skipped.
+func (t ownershipTransfer) copyDirectory(ctx context.Context, source, target string, copied, skipped *[]string) error {
+	objects, err := t.w.Workspace.ListAll(ctx, workspace.ListWorkspaceRequest{Path: source})
+	if err != nil {
+		return fmt.Errorf("cannot list %s: %w", source, err)
+	}
+	for _, object := range objects {
+		destination := path.Join(target, path.Base(object.Path))
+		switch object.ObjectType {
+		case workspace.ObjectTypeDirectory:
+			err = t.w.Workspace.MkdirsByPath(ctx, destination)
+			if err != nil {
+				return fmt.Errorf("cannot create %s: %w", destination, err)
+			}
+			*copied = append(*copied, object.Path)
+			err = t.copyDirectory(ctx, object.Path, destination, copied, skipped)
+			if err != nil {
+				return err
+			}
+		case workspace.ObjectTypeNotebook, workspace.ObjectTypeFile, workspace.ObjectTypeDashboard:
+			err = t.copyObject(ctx, object, destination)
+			if err != nil {
+				return err
+			}
+			*copied = append(*copied, object.Path)
+		default:
+			*skipped = append(*skipped, object.Path)
+		}
+	}
+	return nil
+}
+
+// copyObject exports a notebook, a file or an AI/BI dashboard and imports it with the same type,
+// then checks that the copy exists with the same type and size
+func (t ownershipTransfer) copyObject(ctx context.Context, object workspace.ObjectInfo, destination string) error {
+	exportFormat, importFormat := workspace.ExportFormatAuto, workspace.ImportFormatAuto
+	switch object.ObjectType {
+	case workspace.ObjectTypeNotebook:
+		exportFormat, importFormat = workspace.ExportFormatSource, workspace.ImportFormatSource
+	case workspace.ObjectTypeFile:
+		exportFormat, importFormat = workspace.ExportFormatRaw, workspace.ImportFormatRaw
+	}
+	exported, err := t.w.Workspace.Export(ctx, workspace.ExportRequest{
+		Path:   object.Path,
+		Format: exportFormat,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot export %s: %w", object.Path, err)
+	}
+	err = t.w.Workspace.Import(ctx, workspace.Import{
+		Path:      destination,
+		Format:    importFormat,
+		Language:  object.Language,
+		Content:   exported.Content,
+		Overwrite: true,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot import %s: %w", destination, err)
+	}
+	imported, err := t.w.Workspace.GetStatusByPath(ctx, destination)
+	if err != nil {
+		return fmt.Errorf("cannot verify %s: %w", destination, err)
+	}
+	if imported.ObjectType != object.ObjectType ||
+		(object.ObjectType == workspace.ObjectTypeFile && imported.Size != object.Size) {
+		return fmt.Errorf("copy of %s to %s doesn't match: %s of %d bytes instead of %s of %d bytes",
+			object.Path, destination, imported.ObjectType, imported.Size, object.ObjectType, object.Size)
+	}
+	return nil
+}
+
+// run reassigns all owned objects and archives the home directory. homeKept is true, if the home directory
+// isn't fully copied and must be kept.
+func (t ownershipTransfer) run(ctx context.Context) (homeKept bool, err error) {
+	for _, step := range []struct {
+		name string
+		fn   func(context.Context) error
+	}{
+		{"jobs", t.jobs},
+		{"pipelines", t.pipelines},
+		{"clusters", t.clusters},
+		{"warehouses", t.warehouses},
+		{"queries", t.queries},
+		{"alerts", t.alerts},
+		{"dashboards", t.dashboards},
+		{"home directory", func(ctx context.Context) (err error) {
+			homeKept, err = t.homeDirectory(ctx)
+			return err
+		}},
+	} {
+		err = step.fn(ctx)
+		if err != nil {
+			return false, fmt.Errorf("transfer_ownership_to: %s: %w", step.name, err)
+		}
+	}
+	return homeKept, nil
+}
@@ -0,0 +1,702 @@
+package scim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/databricks-sdk-go/service/pipelines"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceUserDelete_TransferOwnership(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.2/jobs/list?",
+				Response: jobs.ListJobsResponse{
+					Jobs: []jobs.BaseJob{
+						{
+							JobId:           1,
+							CreatorUserName: "leaver@example.com",
+							Settings: &jobs.JobSettings{
+								RunAs: &jobs.JobRunAs{UserName: "leaver@example.com"},
+							},
+						},
+						{
+							JobId:           2,
+							CreatorUserName: "someone@example.com",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.2/jobs/get?job_id=2",
+				Response: jobs.Job{
+					JobId:           2,
+					CreatorUserName: "someone@example.com",
+					RunAsUserName:   "someone@example.com",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/jobs/1?",
+				Response: iam.ObjectPermissions{
+					ObjectId:   "/jobs/1",
+					ObjectType: "job",
+					AccessControlList: []iam.AccessControlResponse{
+						{
+							UserName: "leaver@example.com",
+							AllPermissions: []iam.Permission{
+								{PermissionLevel: iam.PermissionLevelIsOwner},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/permissions/jobs/1",
+				ExpectedRequest: iam.UpdateObjectPermissions{
+					AccessControlList: []iam.AccessControlRequest{
+						{
+							UserName:        "manager@example.com",
+							PermissionLevel: iam.PermissionLevelIsOwner,
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.2/jobs/update",
+				ExpectedRequest: jobs.UpdateJob{
+					JobId: 1,
+					NewSettings: &jobs.JobSettings{
+						RunAs: &jobs.JobRunAs{UserName: "manager@example.com"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/pipelines?",
+				Response: pipelines.ListPipelinesResponse{
+					Statuses: []pipelines.PipelineStateInfo{
+						{
+							PipelineId:      "p1",
+							CreatorUserName: "someone@example.com",
+							RunAsUserName:   "someone@example.com",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/list?",
+				Response: compute.ListClustersResponse{
+					Clusters: []compute.ClusterDetails{
+						{
+							ClusterId:       "c1",
+							CreatorUserName: "leaver@example.com",
+							ClusterSource:   compute.ClusterSourceUi,
+							State:           compute.StateTerminated,
+						},
+						{
+							ClusterId:       "c2",
+							CreatorUserName: "leaver@example.com",
+							ClusterSource:   compute.ClusterSourceJob,
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/change-owner",
+				ExpectedRequest: compute.ChangeClusterOwner{
+					ClusterId:     "c1",
+					OwnerUsername: "manager@example.com",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/warehouses?",
+				Response: sql.ListWarehousesResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/queries?",
+				Response: sql.ListQueryObjectsResponse{
+					Results: []sql.ListQueryObjectsResponseQuery{
+						{
+							Id:            "q1",
+							OwnerUserName: "leaver@example.com",
+						},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/sql/queries/q1",
+				ExpectedRequest: sql.UpdateQueryRequest{
+					UpdateMask: "owner_user_name",
+					Query: &sql.UpdateQueryRequestQuery{
+						OwnerUserName: "manager@example.com",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/alerts?",
+				Response: sql.ListAlertsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/sql/dashboards?page=1",
+				Response: sql.ListResponse{
+					Page: 1,
+					Results: []sql.Dashboard{
+						{
+							Id:   "d1",
+							User: &sql.User{Email: "leaver@example.com"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/sql/dashboards?page=2",
+				Response: sql.ListResponse{
+					Page: 2,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/sql/permissions/dashboard/d1/transfer",
+				ExpectedRequest: sql.TransferOwnershipRequest{
+					NewOwner: "manager@example.com",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/get-status?path=%2FUsers%2Fleaver%40example.com",
+				Response: workspace.ObjectInfo{
+					ObjectType: workspace.ObjectTypeDirectory,
+					Path:       "/Users/leaver@example.com",
+				},
+			},
+			{
+				Method:          "POST",
+				Resource:        "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: workspace.Mkdirs{Path: "/Users/manager@example.com/leaver@example.com"},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fleaver%40example.com",
+				Response: workspace.ListResponse{
+					Objects: []workspace.ObjectInfo{
+						{
+							ObjectType: workspace.ObjectTypeNotebook,
+							Path:       "/Users/leaver@example.com/nb",
+							Language:   workspace.LanguagePython,
+						},
+						{
+							ObjectType: workspace.ObjectTypeDirectory,
+							Path:       "/Users/leaver@example.com/data",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2FUsers%2Fleaver%40example.com%2Fnb",
+				Response: workspace.ExportResponse{
+					Content: "YWJj",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: workspace.Import{
+					Path:      "/Users/manager@example.com/leaver@example.com/nb",
+					Format:    workspace.ImportFormatSource,
+					Language:  workspace.LanguagePython,
+					Content:   "YWJj",
+					Overwrite: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/get-status?path=%2FUsers%2Fmanager%40example.com%2Fleaver%40example.com%2Fnb",
+				Response: workspace.ObjectInfo{
+					ObjectType: workspace.ObjectTypeNotebook,
+					Path:       "/Users/manager@example.com/leaver@example.com/nb",
+				},
+			},
+			{
+				Method:          "POST",
+				Resource:        "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: workspace.Mkdirs{Path: "/Users/manager@example.com/leaver@example.com/data"},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fleaver%40example.com%2Fdata",
+				Response: workspace.ListResponse{
+					Objects: []workspace.ObjectInfo{
+						{
+							ObjectType: workspace.ObjectTypeFile,
+							Path:       "/Users/leaver@example.com/data/a.csv",
+							Size:       3,
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/export?format=RAW&path=%2FUsers%2Fleaver%40example.com%2Fdata%2Fa.csv",
+				Response: workspace.ExportResponse{
+					Content: "YWJj",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: workspace.Import{
+					Path:      "/Users/manager@example.com/leaver@example.com/data/a.csv",
+					Format:    workspace.ImportFormatRaw,
+					Content:   "YWJj",
+					Overwrite: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/get-status?path=%2FUsers%2Fmanager%40example.com%2Fleaver%40example.com%2Fdata%2Fa.csv",
+				Response: workspace.ObjectInfo{
+					ObjectType: workspace.ObjectTypeFile,
+					Path:       "/Users/manager@example.com/leaver@example.com/data/a.csv",
+					Size:       3,
+				},
+			},
+			// the original home directory is archived, not deleted
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+			},
+		},
+		Resource: ResourceUser(),
+		Delete:   true,
+		ID:       "abc",
+		HCL: `
+		user_name = "leaver@example.com"
+		transfer_ownership_to = "manager@example.com"
+		`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceUserDelete_TransferOwnershipKeepsHomeDirectory(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.2/jobs/list?",
+				Response: jobs.ListJobsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/pipelines?",
+				Response: pipelines.ListPipelinesResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/list?",
+				Response: compute.ListClustersResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/warehouses?",
+				Response: sql.ListWarehousesResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/queries?",
+				Response: sql.ListQueryObjectsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/alerts?",
+				Response: sql.ListAlertsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/sql/dashboards?page=1",
+				Response: sql.ListResponse{
+					Page: 1,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/get-status?path=%2FUsers%2Fleaver%40example.com",
+				Response: workspace.ObjectInfo{
+					ObjectType: workspace.ObjectTypeDirectory,
+					Path:       "/Users/leaver@example.com",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/mkdirs",
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fleaver%40example.com",
+				Response: workspace.ListResponse{
+					Objects: []workspace.ObjectInfo{
+						{
+							ObjectType: workspace.ObjectTypeRepo,
+							Path:       "/Users/leaver@example.com/project",
+						},
+					},
+				},
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+			},
+			// no recursive delete of the home directory, as it keeps the Git folder
+		},
+		Resource: ResourceUser(),
+		Delete:   true,
+		ID:       "abc",
+		HCL: `
+		user_name = "leaver@example.com"
+		transfer_ownership_to = "manager@example.com"
+		force_delete_home_dir = true
+		`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceServicePrincipalCreate_TransferOwnershipInAccount(t *testing.T) {
+	qa.ResourceFixture{
+		AccountID: "00000000-0000-0000-0000-000000000001",
+		Resource:  ResourceServicePrincipal(),
+		Create:    true,
+		HCL: `
+		application_id = "00000000-0000-0000-0000-000000000002"
+		display_name = "leaver"
+		transfer_ownership_to = "manager@example.com"
+		`,
+	}.ExpectError(t, "transfer_ownership_to: only supported with workspace-level provider")
+}
+
+func TestResourceServicePrincipalDelete_TransferOwnershipInAccount(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:          "PATCH",
+			Resource:        "/api/2.0/accounts/00000000-0000-0000-0000-000000000001/scim/v2/ServicePrincipals/abc",
+			ExpectedRequest: expectedServicePrincipalDisablePatchRequest,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.Config.WithTesting().AccountID = "00000000-0000-0000-0000-000000000001"
+		// existing state is deleted without the transfer, as the plan can't reject it anymore
+		r := ResourceServicePrincipal().ToResource()
+		d := r.TestResourceData()
+		d.SetId("abc")
+		require.NoError(t, d.Set("application_id", "00000000-0000-0000-0000-000000000002"))
+		require.NoError(t, d.Set("transfer_ownership_to", "manager@example.com"))
+		diags := r.DeleteContext(ctx, d, client)
+		assert.False(t, diags.HasError(), "%v", diags)
+	})
+}
+
+func TestOwnershipTransferSkipsRunningClusters(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/list?",
+			Response: compute.ListClustersResponse{
+				Clusters: []compute.ClusterDetails{
+					{
+						ClusterId:       "running",
+						CreatorUserName: "leaver@example.com",
+						ClusterSource:   compute.ClusterSourceUi,
+						State:           compute.StateRunning,
+					},
+					{
+						ClusterId:       "terminated",
+						CreatorUserName: "leaver@example.com",
+						ClusterSource:   compute.ClusterSourceApi,
+						State:           compute.StateTerminated,
+					},
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/clusters/change-owner",
+			ExpectedRequest: compute.ChangeClusterOwner{
+				ClusterId:     "terminated",
+				OwnerUsername: "manager@example.com",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		transfer, err := newOwnershipTransfer(client, "leaver@example.com", "manager@example.com")
+		require.NoError(t, err)
+		// the running cluster is reported, but doesn't fail the transfer
+		assert.NoError(t, transfer.clusters(ctx))
+	})
+}
+
+func TestOwnershipTransferJobsRunAs(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.2/jobs/list?",
+			Response: jobs.ListJobsResponse{
+				Jobs: []jobs.BaseJob{
+					{
+						JobId:           1,
+						CreatorUserName: "someone@example.com",
+					},
+					{
+						JobId:           2,
+						CreatorUserName: "someone@example.com",
+					},
+					{
+						JobId:           3,
+						CreatorUserName: "someone@example.com",
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.2/jobs/get?job_id=1",
+			Response: jobs.Job{
+				JobId:           1,
+				CreatorUserName: "someone@example.com",
+				RunAsUserName:   "leaver@example.com",
+				Settings:        &jobs.JobSettings{},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.2/jobs/get?job_id=2",
+			Response: jobs.Job{
+				JobId:           2,
+				CreatorUserName: "someone@example.com",
+				RunAsUserName:   "someone@example.com",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.2/jobs/get?job_id=3",
+			Status:   404,
+			Response: apierr.APIError{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Job 3 does not exist.",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/permissions/jobs/1?",
+			Response: iam.ObjectPermissions{
+				ObjectId:   "/jobs/1",
+				ObjectType: "job",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.2/jobs/update",
+			ExpectedRequest: jobs.UpdateJob{
+				JobId: 1,
+				NewSettings: &jobs.JobSettings{
+					RunAs: &jobs.JobRunAs{UserName: "manager@example.com"},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		transfer, err := newOwnershipTransfer(client, "leaver@example.com", "manager@example.com")
+		require.NoError(t, err)
+		// job 1 runs as the principal, though the list doesn't include its run_as, and job 3 was removed
+		assert.NoError(t, transfer.jobs(ctx))
+	})
+}
+
+func TestResourceUserDelete_TransferOwnershipForceDeleteHomeDirectory(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.2/jobs/list?",
+				Response: jobs.ListJobsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/pipelines?",
+				Response: pipelines.ListPipelinesResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/list?",
+				Response: compute.ListClustersResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/warehouses?",
+				Response: sql.ListWarehousesResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/queries?",
+				Response: sql.ListQueryObjectsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/alerts?",
+				Response: sql.ListAlertsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/sql/dashboards?page=1",
+				Response: sql.ListResponse{
+					Page: 1,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/get-status?path=%2FUsers%2Fleaver%40example.com",
+				Response: workspace.ObjectInfo{
+					ObjectType: workspace.ObjectTypeDirectory,
+					Path:       "/Users/leaver@example.com",
+				},
+			},
+			{
+				Method:          "POST",
+				Resource:        "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: workspace.Mkdirs{Path: "/Users/manager@example.com/leaver@example.com"},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fleaver%40example.com",
+				Response: workspace.ListResponse{},
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+			},
+			// the original home directory is deleted only on request, after it's archived
+			{
+				Method:          "POST",
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: workspace.Delete{Path: "/Users/leaver@example.com", Recursive: true},
+			},
+		},
+		Resource: ResourceUser(),
+		Delete:   true,
+		ID:       "abc",
+		HCL: `
+		user_name = "leaver@example.com"
+		transfer_ownership_to = "manager@example.com"
+		force_delete_home_dir = true
+		`,
+	}.ApplyNoError(t)
+}
+
+func TestOwnershipTransferHomeDirectoryKeepsGitFolders(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/get-status?path=%2FUsers%2Fleaver%40example.com",
+			Response: workspace.ObjectInfo{
+				ObjectType: workspace.ObjectTypeDirectory,
+				Path:       "/Users/leaver@example.com",
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/workspace/mkdirs",
+			ExpectedRequest: workspace.Mkdirs{Path: "/Users/manager@example.com/leaver@example.com"},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fleaver%40example.com",
+			Response: workspace.ListResponse{
+				Objects: []workspace.ObjectInfo{
+					{
+						ObjectType: workspace.ObjectTypeRepo,
+						Path:       "/Users/leaver@example.com/project",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		transfer, err := newOwnershipTransfer(client, "leaver@example.com", "manager@example.com")
+		require.NoError(t, err)
+		// the Git folder isn't copied, so the home directory must be kept
+		kept, err := transfer.homeDirectory(ctx)
+		assert.NoError(t, err)
+		assert.True(t, kept)
+	})
+}
+
+func TestOwnershipTransferHomeDirectoryVerifiesCopy(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/get-status?path=%2FUsers%2Fleaver%40example.com",
+			Response: workspace.ObjectInfo{
+				ObjectType: workspace.ObjectTypeDirectory,
+				Path:       "/Users/leaver@example.com",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/workspace/mkdirs",
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fleaver%40example.com",
+			Response: workspace.ListResponse{
+				Objects: []workspace.ObjectInfo{
+					{
+						ObjectType: workspace.ObjectTypeFile,
+						Path:       "/Users/leaver@example.com/a.csv",
+						Size:       3,
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/export?format=RAW&path=%2FUsers%2Fleaver%40example.com%2Fa.csv",
+			Response: workspace.ExportResponse{
+				Content: "YWJj",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/workspace/import",
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/get-status?path=%2FUsers%2Fmanager%40example.com%2Fleaver%40example.com%2Fa.csv",
+			Response: workspace.ObjectInfo{
+				ObjectType: workspace.ObjectTypeFile,
+				Size:       1,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		transfer, err := newOwnershipTransfer(client, "leaver@example.com", "manager@example.com")
+		require.NoError(t, err)
+		_, err = transfer.homeDirectory(ctx)
+		assert.EqualError(t, err, "copy of /Users/leaver@example.com/a.csv to "+
+			"/Users/manager@example.com/leaver@example.com/a.csv doesn't match: FILE of 1 bytes instead of FILE of 3 bytes")
+	})
+}
@@ -73,7 +73,8 @@ func ResourceGroup() common.Resource {
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewGroupsAPI(ctx, c).Delete(d.Id())
 		},
-		Schema: groupSchema,
+		Schema:       groupSchema,
+		WithIdentity: true,
 	}
 }
 
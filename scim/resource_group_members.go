@@ -0,0 +1,164 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maximum number of members added or removed within a single SCIM PATCH request
+const groupMembersPatchChunkSize = 100
+
+type groupMembers struct {
+	GroupID   string   `json:"group_id" tf:"force_new"`
+	MemberIDs []string `json:"member_ids" tf:"slice_set"`
+}
+
+// chunks splits the list of IDs into chunks of at most size elements
+func chunks(ids []string, size int) (out [][]string) {
+	for len(ids) > size {
+		out = append(out, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		out = append(out, ids)
+	}
+	return
+}
+
+// diffMembers returns sorted lists of members to add and to remove to get from current to desired members
+func diffMembers(current map[string]struct{}, desired []string) (add []string, remove []string) {
+	desiredSet := map[string]struct{}{}
+	for _, id := range desired {
+		desiredSet[id] = struct{}{}
+		if !hasMember(current, id) {
+			add = append(add, id)
+		}
+	}
+	for id := range current {
+		if !hasMember(desiredSet, id) {
+			remove = append(remove, id)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return
+}
+
+// patchMembers adds and removes group members with batched PATCH requests, keeping the cache of
+// databricks_group_member resources consistent
+func (gc *groupCache) patchMembers(api GroupsAPI, groupID string, add []string, remove []string) error {
+	groupInfo := gc.getOrCreateGroupInfo(groupID)
+	groupInfo.lock.Lock()
+	defer groupInfo.lock.Unlock()
+
+	for _, chunk := range chunks(remove, groupMembersPatchChunkSize) {
+		tflog.Debug(api.context, fmt.Sprintf("Removing %d members from group %s", len(chunk), groupID))
+		operations := make([]patchOperation, 0, len(chunk))
+		for _, memberID := range chunk {
+			operations = append(operations, patchOperation{
+				Op:   "remove",
+				Path: fmt.Sprintf(`members[value eq "%s"]`, memberID),
+			})
+		}
+		err := api.Patch(groupID, PatchRequestComplexValue(operations))
+		if err != nil {
+			return err
+		}
+		if groupInfo.initialized {
+			for _, memberID := range chunk {
+				delete(groupInfo.members, memberID)
+			}
+		}
+	}
+	for _, chunk := range chunks(add, groupMembersPatchChunkSize) {
+		tflog.Debug(api.context, fmt.Sprintf("Adding %d members to group %s", len(chunk), groupID))
+		values := make([]ComplexValue, 0, len(chunk))
+		for _, memberID := range chunk {
+			values = append(values, ComplexValue{Value: memberID})
+		}
+		err := api.Patch(groupID, PatchRequestComplexValue([]patchOperation{
+			{
+				Op:    "add",
+				Path:  "members",
+				Value: values,
+			},
+		}))
+		if err != nil {
+			return err
+		}
+		if groupInfo.initialized {
+			for _, memberID := range chunk {
+				groupInfo.members[memberID] = struct{}{}
+			}
+		}
+	}
+	return nil
+}
+
+// readMembers returns the current members of the group
+func readMembers(api GroupsAPI, groupID string) (map[string]struct{}, error) {
+	group, err := api.Read(groupID, "members")
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string]struct{}, len(group.Members))
+	for _, member := range group.Members {
+		members[member.Value] = struct{}{}
+	}
+	return members, nil
+}
+
+// ResourceGroupMembers manages the complete set of members of a group
+func ResourceGroupMembers() common.Resource {
+	s := common.StructToSchema(groupMembers{}, common.NoCustomize)
+	update := func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+		var gm groupMembers
+		common.DataToStructPointer(d, s, &gm)
+		api := NewGroupsAPI(ctx, c)
+		current, err := readMembers(api, gm.GroupID)
+		if err != nil {
+			return err
+		}
+		add, remove := diffMembers(current, gm.MemberIDs)
+		return globalGroupsCache.patchMembers(api, gm.GroupID, add, remove)
+	}
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			err := update(ctx, d, c)
+			if err != nil {
+				return err
+			}
+			d.SetId(d.Get("group_id").(string))
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			current, err := readMembers(NewGroupsAPI(ctx, c), d.Id())
+			if err != nil {
+				return err
+			}
+			gm := groupMembers{
+				GroupID:   d.Id(),
+				MemberIDs: make([]string, 0, len(current)),
+			}
+			for id := range current {
+				gm.MemberIDs = append(gm.MemberIDs, id)
+			}
+			sort.Strings(gm.MemberIDs)
+			return common.StructToData(gm, s, d)
+		},
+		Update: update,
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var gm groupMembers
+			common.DataToStructPointer(d, s, &gm)
+			remove := append([]string{}, gm.MemberIDs...)
+			sort.Strings(remove)
+			return globalGroupsCache.patchMembers(NewGroupsAPI(ctx, c), d.Id(), nil, remove)
+		},
+	}
+}
@@ -0,0 +1,202 @@
+package scim
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunks(t *testing.T) {
+	assert.Nil(t, chunks(nil, 2))
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, chunks([]string{"a", "b", "c"}, 2))
+	assert.Equal(t, [][]string{{"a", "b"}}, chunks([]string{"a", "b"}, 2))
+}
+
+func TestDiffMembers(t *testing.T) {
+	add, remove := diffMembers(map[string]struct{}{
+		"a": {},
+		"b": {},
+	}, []string{"c", "b"})
+	assert.Equal(t, []string{"c"}, add)
+	assert.Equal(t, []string{"a"}, remove)
+}
+
+func TestResourceGroupMembersCreate(t *testing.T) {
+	globalGroupsCache = newGroupCache()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: Group{
+					ID: "abc",
+					Members: []ComplexValue{
+						{Value: "keep"},
+						{Value: "stale"},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: PatchRequestComplexValue([]patchOperation{
+					{
+						Op:   "remove",
+						Path: `members[value eq "stale"]`,
+					},
+				}),
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: PatchRequestComplexValue([]patchOperation{
+					{
+						Op:   "add",
+						Path: "members",
+						Value: []ComplexValue{
+							{Value: "new1"},
+							{Value: "new2"},
+						},
+					},
+				}),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: Group{
+					ID: "abc",
+					Members: []ComplexValue{
+						{Value: "keep"},
+						{Value: "new1"},
+						{Value: "new2"},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		Create:   true,
+		HCL: `
+		group_id = "abc"
+		member_ids = ["keep", "new2", "new1"]
+		`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 3, d.Get("member_ids.#"))
+}
+
+func TestResourceGroupMembersUpdate_ChunksInAccount(t *testing.T) {
+	globalGroupsCache = newGroupCache()
+	desired := []any{}
+	firstChunk := []ComplexValue{}
+	for i := 0; i < groupMembersPatchChunkSize+1; i++ {
+		id := fmt.Sprintf("m%03d", i)
+		desired = append(desired, id)
+		if i < groupMembersPatchChunkSize {
+			firstChunk = append(firstChunk, ComplexValue{Value: id})
+		}
+	}
+	qa.ResourceFixture{
+		AccountID: "00000000-0000-0000-0000-000000000001",
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/accounts/00000000-0000-0000-0000-000000000001/scim/v2/Groups/abc?attributes=members",
+				ReuseRequest: true,
+				Response: Group{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/00000000-0000-0000-0000-000000000001/scim/v2/Groups/abc",
+				ExpectedRequest: PatchRequestComplexValue([]patchOperation{
+					{
+						Op:    "add",
+						Path:  "members",
+						Value: firstChunk,
+					},
+				}),
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/00000000-0000-0000-0000-000000000001/scim/v2/Groups/abc",
+				ExpectedRequest: PatchRequestComplexValue([]patchOperation{
+					{
+						Op:   "add",
+						Path: "members",
+						Value: []ComplexValue{
+							{Value: fmt.Sprintf("m%03d", groupMembersPatchChunkSize)},
+						},
+					},
+				}),
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		Update:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"group_id": "abc",
+		},
+		State: map[string]any{
+			"group_id":   "abc",
+			"member_ids": desired,
+		},
+	}.ApplyNoError(t)
+}
+
+func TestResourceGroupMembersRead(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: Group{
+					ID: "abc",
+					Members: []ComplexValue{
+						{Value: "b"},
+						{Value: "a"},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+	}.ApplyAndExpectData(t, map[string]any{
+		"group_id":   "abc",
+		"member_ids": []string{"a", "b"},
+	})
+}
+
+func TestResourceGroupMembersDelete(t *testing.T) {
+	globalGroupsCache = newGroupCache()
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: PatchRequestComplexValue([]patchOperation{
+					{
+						Op:   "remove",
+						Path: `members[value eq "a"]`,
+					},
+					{
+						Op:   "remove",
+						Path: `members[value eq "b"]`,
+					},
+				}),
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		Delete:   true,
+		ID:       "abc",
+		HCL: `
+		group_id = "abc"
+		member_ids = ["b", "a"]
+		`,
+	}.ApplyNoError(t)
+}
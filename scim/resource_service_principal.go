@@ -62,6 +62,15 @@ func (a ServicePrincipalsAPI) Filter(filter string, excludeRoles bool) (u []User
 	return
 }
 
+// FilterPaged retrieves all service principals matching the filter, requesting them by pages of the given size
+func (a ServicePrincipalsAPI) FilterPaged(filter, attributes string, pageSize int) ([]User, error) {
+	return filterPages(filter, attributes, pageSize, func(req map[string]string) ([]User, int32, error) {
+		var sps UserList
+		err := a.client.Scim(a.context, http.MethodGet, "/preview/scim/v2/ServicePrincipals", req, &sps)
+		return sps.Resources, sps.TotalResults, err
+	})
+}
+
 // Patch updates resource-friendly entity
 func (a ServicePrincipalsAPI) Patch(servicePrincipalID string, r patchRequest) error {
 	return a.client.Scim(a.context, http.MethodPatch, fmt.Sprintf("/preview/scim/v2/ServicePrincipals/%v", servicePrincipalID), r, nil)
@@ -128,6 +137,10 @@ func ResourceServicePrincipal() common.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			}
+			m["transfer_ownership_to"] = &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			}
 			m["disable_as_user_deletion"] = &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -209,6 +222,24 @@ func ResourceServicePrincipal() common.Resource {
 			if !isAccount && isDisable && isForceDeleteHomeDir {
 				return fmt.Errorf("force_delete_home_dir: cannot force delete if disable_as_user_deletion is set")
 			}
+			// Reassign owned objects before the principal is gone
+			homeKept := false
+			if transferTo := d.Get("transfer_ownership_to").(string); transferTo != "" {
+				// existing state must stay deletable, even though the plan rejects it for account-level providers
+				if isAccount {
+					common.AddWarning(ctx, "transfer_ownership_to is ignored",
+						"ownership transfer is only supported with workspace-level provider")
+				} else {
+					transfer, err := newOwnershipTransfer(c, appId, transferTo)
+					if err != nil {
+						return err
+					}
+					homeKept, err = transfer.run(ctx)
+					if err != nil {
+						return err
+					}
+				}
+			}
 			// Disable or delete
 			if isDisable {
 				r := PatchRequestWithValue("replace", "active", "false")
@@ -227,7 +258,11 @@ func ResourceServicePrincipal() common.Resource {
 						return fmt.Errorf("force_delete_repos: %s", err.Error())
 					}
 				}
-				if isForceDeleteHomeDir {
+				// the ownership transfer keeps the home directory, if some objects can't be copied
+				if isForceDeleteHomeDir && homeKept {
+					common.AddWarning(ctx, "force_delete_home_dir is ignored",
+						"home directory is kept by transfer_ownership_to")
+				} else if isForceDeleteHomeDir {
 					err = workspace.NewNotebooksAPI(ctx, c).Delete(fmt.Sprintf("/Users/%v", appId), true)
 					if err != nil && !apierr.IsMissing(err) {
 						return fmt.Errorf("force_delete_home_dir: %s", err.Error())
@@ -236,6 +271,8 @@ func ResourceServicePrincipal() common.Resource {
 			}
 			return nil
 		},
+		CustomizeDiffWithClient: transferOwnershipCustomizeDiff,
+		WithIdentity:            true,
 	}
 }
 
@@ -66,6 +66,10 @@ func ResourceUser() common.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			}
+			m["transfer_ownership_to"] = &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			}
 			m["disable_as_user_deletion"] = &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -143,6 +147,24 @@ func ResourceUser() common.Resource {
 			if !isAccount && isDisable && isForceDeleteHomeDir {
 				return fmt.Errorf("force_delete_home_dir: cannot force delete if disable_as_user_deletion is set")
 			}
+			// Reassign owned objects before the principal is gone
+			homeKept := false
+			if transferTo := d.Get("transfer_ownership_to").(string); transferTo != "" {
+				// existing state must stay deletable, even though the plan rejects it for account-level providers
+				if isAccount {
+					common.AddWarning(ctx, "transfer_ownership_to is ignored",
+						"ownership transfer is only supported with workspace-level provider")
+				} else {
+					transfer, err := newOwnershipTransfer(c, userName, transferTo)
+					if err != nil {
+						return err
+					}
+					homeKept, err = transfer.run(ctx)
+					if err != nil {
+						return err
+					}
+				}
+			}
 			// Disable or delete
 			if isDisable {
 				r := PatchRequestWithValue("replace", "active", "false")
@@ -161,7 +183,11 @@ func ResourceUser() common.Resource {
 						return fmt.Errorf("force_delete_repos: %s", err.Error())
 					}
 				}
-				if isForceDeleteHomeDir {
+				// the ownership transfer keeps the home directory, if some objects can't be copied
+				if isForceDeleteHomeDir && homeKept {
+					common.AddWarning(ctx, "force_delete_home_dir is ignored",
+						"home directory is kept by transfer_ownership_to")
+				} else if isForceDeleteHomeDir {
 					err = workspace.NewNotebooksAPI(ctx, c).Delete(fmt.Sprintf("/Users/%v", userName), true)
 					if err != nil && !apierr.IsMissing(err) {
 						return fmt.Errorf("force_delete_home_dir: %s", err.Error())
@@ -170,6 +196,8 @@ func ResourceUser() common.Resource {
 			}
 			return nil
 		},
+		CustomizeDiffWithClient: transferOwnershipCustomizeDiff,
+		WithIdentity:            true,
 	}
 }
 
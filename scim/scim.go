@@ -1,6 +1,8 @@
 package scim
 
 import (
+	"strconv"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -124,6 +126,34 @@ type Group struct {
 	Meta         *ResourceMeta  `json:"meta,omitempty" tf:"computed"`
 }
 
+// filterPages requests entities matching the filter page by page, until all of them are fetched. The page function
+// returns the entities of a single page and the total number of matching entities.
+func filterPages[T any](filter, attributes string, pageSize int,
+	page func(req map[string]string) ([]T, int32, error)) ([]T, error) {
+	all := []T{}
+	for startIndex := 1; ; {
+		req := map[string]string{
+			"startIndex": strconv.Itoa(startIndex),
+			"count":      strconv.Itoa(pageSize),
+		}
+		if filter != "" {
+			req["filter"] = filter
+		}
+		if attributes != "" {
+			req["attributes"] = attributes
+		}
+		resources, totalResults, err := page(req)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resources...)
+		startIndex += len(resources)
+		if len(resources) == 0 || len(all) >= int(totalResults) {
+			return all, nil
+		}
+	}
+}
+
 // GroupList contains a list of groups fetched from a list api call from SCIM api
 type GroupList struct {
 	TotalResults int32   `json:"totalResults,omitempty"`
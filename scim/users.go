@@ -50,6 +50,15 @@ func (a UsersAPI) Filter(filter string, excludeRoles bool) (u []User, err error)
 	return
 }
 
+// FilterPaged retrieves all users matching the filter, requesting them by pages of the given size
+func (a UsersAPI) FilterPaged(filter, attributes string, pageSize int) ([]User, error) {
+	return filterPages(filter, attributes, pageSize, func(req map[string]string) ([]User, int32, error) {
+		var users UserList
+		err := a.client.Scim(a.context, http.MethodGet, "/preview/scim/v2/Users", req, &users)
+		return users.Resources, users.TotalResults, err
+	})
+}
+
 func (a UsersAPI) Read(userID, attributes string) (User, error) {
 	userPath := fmt.Sprintf("/preview/scim/v2/Users/%v?attributes=%s", userID, attributes)
 	return a.readByPath(userPath)
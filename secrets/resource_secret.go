@@ -67,16 +67,21 @@ func ResourceSecret() common.Resource {
 			Computed: true,
 		},
 	}
+	common.AddWriteOnlyVariant(s, "string_value")
+	putSecret := func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+		w, err := c.WorkspaceClient()
+		if err != nil {
+			return err
+		}
+		var putSecretReq workspace.PutSecret
+		common.DataToStructPointer(d, s, &putSecretReq)
+		putSecretReq.StringValue = common.GetWriteOnlyStringOrValue(d, "string_value")
+		return w.Secrets.PutSecret(ctx, putSecretReq)
+	}
 	return common.Resource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			w, err := c.WorkspaceClient()
-			if err != nil {
-				return err
-			}
-			var putSecretReq workspace.PutSecret
-			common.DataToStructPointer(d, s, &putSecretReq)
-			err = w.Secrets.PutSecret(ctx, putSecretReq)
+			err := putSecret(ctx, d, c)
 			if err != nil {
 				return err
 			}
@@ -99,6 +104,8 @@ func ResourceSecret() common.Resource {
 			d.Set("config_reference", fmt.Sprintf("{{secrets/%s/%s}}", scope, key))
 			return d.Set("last_updated_timestamp", m.LastUpdatedTimestamp)
 		},
+		// only `string_value_wo_version` can be updated, all other attributes force a new secret
+		Update: putSecret,
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			scope, key, err := p.Unpack(d)
 			if err != nil {
@@ -2,12 +2,12 @@ package secrets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/databricks/databricks-sdk-go/service/workspace"
 	"github.com/databricks/terraform-provider-databricks/common"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -100,15 +100,21 @@ type secretsClient interface {
 //
 // The function is retried until the ACL is applied with the right
 // permission. This is necessary to workaround current limitations due to
-// an internal caching mechanism.
+// an internal caching mechanism. Retries follow the `not_found` policy of
+// the `retry` provider block.
 //
 // See [issue-4195] for reference.
 //
 // [issue-4195]: https://github.com/databricks/terraform-provider-databricks/issues/4195
 func robustPutACL(sc secretsClient, ctx context.Context, req workspace.PutAcl, timeout time.Duration) error {
-	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	_, err := common.RetryWithPolicy(ctx, common.RetryNotFound, func(err error) bool {
+		var unverified *unverifiedACLError
+		return errors.As(err, &unverified)
+	}, func(ctx context.Context) (*struct{}, error) {
 		if err := sc.PutAcl(ctx, req); err != nil {
-			return retry.NonRetryableError(fmt.Errorf("failed to create Secret ACL: %w", err))
+			return nil, fmt.Errorf("failed to create Secret ACL: %w", err)
 		}
 
 		// Verify that the ACL was properly applied with the right permissions.
@@ -118,12 +124,26 @@ func robustPutACL(sc secretsClient, ctx context.Context, req workspace.PutAcl, t
 			Principal: req.Principal,
 		})
 		if err != nil {
-			return retry.RetryableError(fmt.Errorf("secret ACL creation could not be verified: %w", err))
+			return nil, &unverifiedACLError{fmt.Errorf("secret ACL creation could not be verified: %w", err)}
 		}
 		if secretACL.Permission.String() != req.Permission.String() {
-			return retry.RetryableError(fmt.Errorf("secret ACL permission mismatch: expected %s, got %s", req.Permission.String(), secretACL.Permission.String()))
+			return nil, &unverifiedACLError{fmt.Errorf("secret ACL permission mismatch: expected %s, got %s", req.Permission.String(), secretACL.Permission.String())}
 		}
 
-		return nil
+		return nil, nil
 	})
+	return err
+}
+
+// unverifiedACLError is returned, when the ACL isn't applied yet, so that robustPutACL retries it
+type unverifiedACLError struct {
+	err error
+}
+
+func (e *unverifiedACLError) Error() string {
+	return e.err.Error()
+}
+
+func (e *unverifiedACLError) Unwrap() error {
+	return e.err
 }
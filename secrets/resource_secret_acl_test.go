@@ -9,6 +9,7 @@ import (
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
 )
@@ -203,6 +204,7 @@ func TestRobustPutACL(t *testing.T) {
 		name          string
 		timeout       time.Duration
 		sc            secretsClient
+		maxAttempts   int
 		req           workspace.PutAcl
 		wantErrPrefix string
 	}{
@@ -252,6 +254,20 @@ func TestRobustPutACL(t *testing.T) {
 			},
 			req: workspace.PutAcl{Permission: "MANAGE"},
 		},
+		{
+			name:        "max attempts",
+			timeout:     defaultTimeout,
+			maxAttempts: 2,
+			sc: &mockSecretsClient{
+				putError: []error{nil, nil},
+				getResp: []getACLResponse{
+					{resp: &workspace.AclItem{Permission: "OTHER"}},
+					{resp: &workspace.AclItem{Permission: "READ"}},
+				},
+			},
+			req:           workspace.PutAcl{Permission: "MANAGE"},
+			wantErrPrefix: "secret ACL permission mismatch: expected MANAGE, got READ",
+		},
 		{
 			name:    "retry on permission mismatch",
 			timeout: defaultTimeout,
@@ -273,7 +289,10 @@ func TestRobustPutACL(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := robustPutACL(tc.sc, context.Background(), tc.req, tc.timeout)
+			ctx := common.WithRetryPolicies(context.Background(), common.RetryPolicies{
+				common.RetryNotFound: {MaxAttempts: tc.maxAttempts, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+			})
+			err := robustPutACL(tc.sc, ctx, tc.req, tc.timeout)
 
 			if err == nil && tc.wantErrPrefix != "" {
 				t.Errorf("expected error, got nil")
@@ -0,0 +1,170 @@
+package serving
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/databricks/databricks-sdk-go/service/serving"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	externalModelApiKeyField         = "external_model_api_key"
+	externalModelApiKeysVersionField = "external_model_api_keys_wo_version"
+)
+
+// externalModelApiKeySetters set plaintext API keys of external models. They return false, if the corresponding
+// provider configuration isn't present.
+var externalModelApiKeySetters = map[string]func(m *serving.ExternalModel, v string) bool{
+	"ai21labs_config.ai21labs_api_key_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.Ai21labsConfig == nil {
+			return false
+		}
+		m.Ai21labsConfig.Ai21labsApiKeyPlaintext = v
+		return true
+	},
+	"amazon_bedrock_config.aws_access_key_id_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.AmazonBedrockConfig == nil {
+			return false
+		}
+		m.AmazonBedrockConfig.AwsAccessKeyIdPlaintext = v
+		return true
+	},
+	"amazon_bedrock_config.aws_secret_access_key_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.AmazonBedrockConfig == nil {
+			return false
+		}
+		m.AmazonBedrockConfig.AwsSecretAccessKeyPlaintext = v
+		return true
+	},
+	"anthropic_config.anthropic_api_key_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.AnthropicConfig == nil {
+			return false
+		}
+		m.AnthropicConfig.AnthropicApiKeyPlaintext = v
+		return true
+	},
+	"cohere_config.cohere_api_key_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.CohereConfig == nil {
+			return false
+		}
+		m.CohereConfig.CohereApiKeyPlaintext = v
+		return true
+	},
+	"custom_provider_config.api_key_auth.value_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.CustomProviderConfig == nil || m.CustomProviderConfig.ApiKeyAuth == nil {
+			return false
+		}
+		m.CustomProviderConfig.ApiKeyAuth.ValuePlaintext = v
+		return true
+	},
+	"custom_provider_config.bearer_token_auth.token_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.CustomProviderConfig == nil || m.CustomProviderConfig.BearerTokenAuth == nil {
+			return false
+		}
+		m.CustomProviderConfig.BearerTokenAuth.TokenPlaintext = v
+		return true
+	},
+	"databricks_model_serving_config.databricks_api_token_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.DatabricksModelServingConfig == nil {
+			return false
+		}
+		m.DatabricksModelServingConfig.DatabricksApiTokenPlaintext = v
+		return true
+	},
+	"google_cloud_vertex_ai_config.private_key_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.GoogleCloudVertexAiConfig == nil {
+			return false
+		}
+		m.GoogleCloudVertexAiConfig.PrivateKeyPlaintext = v
+		return true
+	},
+	"openai_config.microsoft_entra_client_secret_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.OpenaiConfig == nil {
+			return false
+		}
+		m.OpenaiConfig.MicrosoftEntraClientSecretPlaintext = v
+		return true
+	},
+	"openai_config.openai_api_key_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.OpenaiConfig == nil {
+			return false
+		}
+		m.OpenaiConfig.OpenaiApiKeyPlaintext = v
+		return true
+	},
+	"palm_config.palm_api_key_plaintext": func(m *serving.ExternalModel, v string) bool {
+		if m.PalmConfig == nil {
+			return false
+		}
+		m.PalmConfig.PalmApiKeyPlaintext = v
+		return true
+	},
+}
+
+// addExternalModelApiKeysSchema adds the `external_model_api_key` blocks with write-only API keys of external models.
+// They can't be nested into `config`, because it's computed and computed blocks can't have write-only attributes.
+func addExternalModelApiKeysSchema(m map[string]*schema.Schema) {
+	fields := make([]string, 0, len(externalModelApiKeySetters))
+	for field := range externalModelApiKeySetters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	m[externalModelApiKeyField] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"served_entity_name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"field": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(fields, false),
+				},
+				"value_wo": {
+					Type:      schema.TypeString,
+					Required:  true,
+					WriteOnly: true,
+					Sensitive: true,
+				},
+			},
+		},
+	}
+	m[externalModelApiKeysVersionField] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+	}
+}
+
+// setExternalModelApiKeys copies write-only API keys from `external_model_api_key` blocks into the served entities
+func setExternalModelApiKeys(d *schema.ResourceData, config *serving.EndpointCoreConfigInput) error {
+	apiKeys := d.Get(externalModelApiKeyField).([]any)
+	for i, raw := range apiKeys {
+		apiKey := raw.(map[string]any)
+		name := apiKey["served_entity_name"].(string)
+		field := apiKey["field"].(string)
+		var entity *serving.ServedEntityInput
+		if config != nil {
+			idx := slices.IndexFunc(config.ServedEntities, func(e serving.ServedEntityInput) bool {
+				return e.ExternalModel != nil && (e.Name == name || (e.Name == "" && e.ExternalModel.Name == name))
+			})
+			if idx >= 0 {
+				entity = &config.ServedEntities[idx]
+			}
+		}
+		if entity == nil {
+			return fmt.Errorf("served entity %s with an external model isn't found", name)
+		}
+		value := common.GetWriteOnlyString(d, fmt.Sprintf("%s.%d.value_wo", externalModelApiKeyField, i))
+		if !externalModelApiKeySetters[field](entity.ExternalModel, value) {
+			return fmt.Errorf("served entity %s doesn't have a configuration block for %s", name, field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package serving
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/serving"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetExternalModelApiKeys(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceModelServing().Schema, map[string]any{
+		"name": "test-endpoint",
+		"external_model_api_key": []any{
+			map[string]any{
+				"served_entity_name": "gpt",
+				"field":              "openai_config.openai_api_key_plaintext",
+				"value_wo":           "sk-123",
+			},
+		},
+	})
+	config := &serving.EndpointCoreConfigInput{
+		ServedEntities: []serving.ServedEntityInput{
+			{
+				Name: "gpt",
+				ExternalModel: &serving.ExternalModel{
+					Name:         "gpt-4",
+					Provider:     "openai",
+					OpenaiConfig: &serving.OpenAiConfig{},
+				},
+			},
+		},
+	}
+	require.NoError(t, setExternalModelApiKeys(d, config))
+	assert.Equal(t, "sk-123", config.ServedEntities[0].ExternalModel.OpenaiConfig.OpenaiApiKeyPlaintext)
+
+	config.ServedEntities[0].ExternalModel.OpenaiConfig = nil
+	assert.EqualError(t, setExternalModelApiKeys(d, config),
+		"served entity gpt doesn't have a configuration block for openai_config.openai_api_key_plaintext")
+
+	assert.EqualError(t, setExternalModelApiKeys(d, nil),
+		"served entity gpt with an external model isn't found")
+}
@@ -3,6 +3,8 @@ package serving
 import (
 	"context"
 	"log"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -131,6 +133,21 @@ func updateAiGateway(ctx context.Context, w *databricks.WorkspaceClient, name st
 	return err
 }
 
+// mergeDefaultTags adds `default_tags` of the provider to tags of the endpoint, that aren't set explicitly
+func mergeDefaultTags(c *common.DatabricksClient, tags []serving.EndpointTag) []serving.EndpointTag {
+	configured := map[string]string{}
+	for _, tag := range tags {
+		configured[tag.Key] = tag.Value
+	}
+	merged := c.MergeDefaultTags(configured)
+	for _, k := range slices.Sorted(maps.Keys(merged)) {
+		if _, ok := configured[k]; !ok {
+			tags = append(tags, serving.EndpointTag{Key: k, Value: merged[k]})
+		}
+	}
+	return tags
+}
+
 func ResourceModelServing() common.Resource {
 	s := common.StructToSchema(
 		serving.CreateServingEndpoint{},
@@ -182,6 +199,7 @@ func ResourceModelServing() common.Resource {
 				Computed: true,
 				Type:     schema.TypeString,
 			}
+			addExternalModelApiKeysSchema(m)
 			return m
 		})
 
@@ -193,6 +211,10 @@ func ResourceModelServing() common.Resource {
 			}
 			var e serving.CreateServingEndpoint
 			common.DataToStructPointer(d, s, &e)
+			e.Tags = mergeDefaultTags(c, e.Tags)
+			if err := setExternalModelApiKeys(d, e.Config); err != nil {
+				return err
+			}
 			wait, err := w.ServingEndpoints.Create(ctx, e)
 			if err != nil {
 				return err
@@ -248,13 +270,18 @@ func ResourceModelServing() common.Resource {
 			}
 			var e serving.CreateServingEndpoint
 			common.DataToStructPointer(d, s, &e)
-			if d.HasChange("config") {
+			if d.HasChanges("config", externalModelApiKeysVersionField) {
+				// API keys have to be sent with every update of the configuration
+				if err := setExternalModelApiKeys(d, e.Config); err != nil {
+					return err
+				}
 				if err := updateConfig(ctx, w, e.Name, e.Config, d); err != nil {
 					return err
 				}
 			}
-			if d.HasChange("tags") {
-				if err := updateTags(ctx, w, e.Name, e.Tags, d); err != nil {
+			// `tags_all` is changed, when `default_tags` of the provider are changed
+			if d.HasChanges("tags", common.TagsAllField) {
+				if err := updateTags(ctx, w, e.Name, mergeDefaultTags(c, e.Tags), d); err != nil {
 					return err
 				}
 			}
@@ -274,6 +301,7 @@ func ResourceModelServing() common.Resource {
 		},
 		StateUpgraders: []schema.StateUpgrader{},
 		Schema:         s,
+		TagsPath:       []string{"tags"},
 		SchemaVersion:  0,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(DefaultProvisionTimeout),
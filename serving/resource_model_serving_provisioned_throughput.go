@@ -45,6 +45,7 @@ func ResourceModelServingProvisionedThroughput() common.Resource {
 			}
 			var e serving.CreatePtEndpointRequest
 			common.DataToStructPointer(d, s, &e)
+			e.Tags = mergeDefaultTags(c, e.Tags)
 			wait, err := w.ServingEndpoints.CreateProvisionedThroughputEndpoint(ctx, e)
 			if err != nil {
 				return err
@@ -100,8 +101,9 @@ func ResourceModelServingProvisionedThroughput() common.Resource {
 					return err
 				}
 			}
-			if d.HasChange("tags") {
-				if err := updateTags(ctx, w, e.Name, e.Tags, d); err != nil {
+			// `tags_all` is changed, when `default_tags` of the provider are changed
+			if d.HasChanges("tags", common.TagsAllField) {
+				if err := updateTags(ctx, w, e.Name, mergeDefaultTags(c, e.Tags), d); err != nil {
 					return err
 				}
 			}
@@ -119,7 +121,8 @@ func ResourceModelServingProvisionedThroughput() common.Resource {
 			}
 			return w.ServingEndpoints.DeleteByName(ctx, d.Id())
 		},
-		Schema: s,
+		Schema:   s,
+		TagsPath: []string{"tags"},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(defaultPtProvisionTimeout),
 			Update: schema.DefaultTimeout(defaultPtProvisionTimeout),
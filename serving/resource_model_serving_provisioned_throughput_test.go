@@ -10,6 +10,7 @@ import (
 
 	"github.com/databricks/terraform-provider-databricks/qa"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestModelServingProvisionedThroughputCornerCases(t *testing.T) {
@@ -137,6 +138,86 @@ func TestModelServingProvisionedThroughputCreate(t *testing.T) {
 	}.ApplyNoError(t)
 }
 
+func TestModelServingProvisionedThroughputCreate_DefaultTags(t *testing.T) {
+	tags := []serving.EndpointTag{{Key: "team", Value: "data"}, {Key: "cost_center", Value: "engineering"}}
+	endpoint := serving.ServingEndpointDetailed{
+		Id:   "test-endpoint",
+		Name: "test-endpoint",
+		Tags: tags,
+		State: &serving.EndpointState{
+			ConfigUpdate: serving.EndpointStateConfigUpdateNotUpdating,
+		},
+		Config: &serving.EndpointCoreConfigOutput{
+			ServedEntities: []serving.ServedEntityOutput{
+				{
+					Name:          "prod_model",
+					EntityName:    "ads1",
+					EntityVersion: "2",
+				},
+			},
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/serving-endpoints/pt",
+				ExpectedRequest: serving.CreatePtEndpointRequest{
+					Name: "test-endpoint",
+					Config: serving.PtEndpointCoreConfig{
+						ServedEntities: []serving.PtServedModel{
+							{
+								Name:                  "prod_model",
+								EntityName:            "ads1",
+								EntityVersion:         "2",
+								ProvisionedModelUnits: 50,
+							},
+						},
+					},
+					Tags: tags,
+				},
+				Response: serving.ServingEndpointDetailed{
+					Name: "test-endpoint",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/serving-endpoints/test-endpoint?",
+				Response: endpoint,
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/serving-endpoints/test-endpoint?",
+				Response: endpoint,
+			},
+		},
+		Resource: ResourceModelServingProvisionedThroughput(),
+		DefaultTags: map[string]string{
+			"cost_center": "engineering",
+			"team":        "platform",
+		},
+		HCL: `
+			name = "test-endpoint"
+			config {
+				served_entities {
+					name = "prod_model"
+					entity_name = "ads1"
+					entity_version = "2"
+					provisioned_model_units = 50
+				}
+			}
+			tags {
+				key = "team"
+				value = "data"
+			}
+			`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"key": "team", "value": "data"}}, d.Get("tags"))
+	assert.Equal(t, map[string]any{"cost_center": "engineering", "team": "data"}, d.Get("tags_all"))
+}
+
 func TestModelServingProvisionedThroughputCreate_Error(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -450,6 +531,7 @@ func TestModelServingProvisionedThroughputUpdate_RemoveConfigIsNoOp(t *testing.T
 			"config.0.served_entities.0.entity_version":          "2",
 			"config.0.served_entities.0.provisioned_model_units": "50",
 			"serving_endpoint_id":                                "id",
+			"tags_all.%":                                         "0",
 		},
 		HCL: `
 			name = "test-endpoint"
@@ -560,6 +642,8 @@ func TestModelServingProvisionedThroughputUpdate_Tags(t *testing.T) {
 			"tags.#":                                 "1",
 			"tags.0.key":                             "env",
 			"tags.0.value":                           "prod",
+			"tags_all.%":                             "1",
+			"tags_all.env":                           "prod",
 			"config.#":                               "1",
 			"config.0.served_entities.#":             "1",
 			"config.0.served_entities.0.name":        "prod_model",
@@ -621,6 +621,7 @@ func TestModelServingUpdate_RemoveConfigIsNoOp(t *testing.T) {
 			"config.0.served_models.0.name": "prod_model",
 			"serving_endpoint_id":           "id",
 			"endpoint_url":                  "https://example.com/endpoint",
+			"tags_all.%":                    "0",
 		},
 		HCL: `
 			name = "test-endpoint"
@@ -18,22 +18,27 @@ var (
 	etagAttrName     = "etag"
 )
 
-func retryOnEtagError[Req, Resp any](f func(req Req) (Resp, error), firstReq Req, updateReq func(req *Req, newEtag string), retriableErrors []error) (Resp, error) {
+// retryOnEtagError retries the request with the etag from the error, when the setting was changed concurrently.
+// Retries follow the `conflict` policy of the `retry` provider block.
+func retryOnEtagError[Req, Resp any](ctx context.Context, f func(req Req) (Resp, error), firstReq Req, updateReq func(req *Req, newEtag string), retriableErrors []error) (Resp, error) {
 	req := firstReq
-	// Retry once on etag error.
-	res, err := f(req)
-	if err == nil {
-		return res, nil
-	}
-	if !isRetriableError(err, retriableErrors) {
-		return res, err
-	}
-	etag, err := getEtagFromError(err)
-	if err != nil {
-		return res, err
-	}
-	updateReq(&req, etag)
-	return f(req)
+	etagMissing := false
+	res, err := common.RetryWithPolicy(ctx, common.RetryConflict, func(err error) bool {
+		return !etagMissing && isRetriableError(err, retriableErrors)
+	}, func(ctx context.Context) (*Resp, error) {
+		res, err := f(req)
+		if err == nil || !isRetriableError(err, retriableErrors) {
+			return &res, err
+		}
+		etag, etagErr := getEtagFromError(err)
+		if etagErr != nil {
+			etagMissing = true
+			return &res, etagErr
+		}
+		updateReq(&req, etag)
+		return &res, err
+	})
+	return *res, err
 }
 
 func isRetriableError(err error, retriableErrors []error) bool {
@@ -370,7 +375,7 @@ func makeSettingResource[T, U any](defn genericSettingDefinition[T, U]) common.R
 			if err != nil {
 				return err
 			}
-			res, err = retryOnEtagError[T, string](
+			res, err = retryOnEtagError[T, string](ctx,
 				func(setting T) (string, error) {
 					return defn.Update(ctx, w, setting)
 				},
@@ -385,7 +390,7 @@ func makeSettingResource[T, U any](defn genericSettingDefinition[T, U]) common.R
 			if err != nil {
 				return err
 			}
-			res, err = retryOnEtagError(
+			res, err = retryOnEtagError(ctx,
 				func(setting T) (string, error) {
 					return defn.Update(ctx, a, setting)
 				},
@@ -397,7 +402,7 @@ func makeSettingResource[T, U any](defn genericSettingDefinition[T, U]) common.R
 			}
 		case accountWorkspaceSettingDefinition[T]:
 			var err error
-			res, err = retryOnEtagError(
+			res, err = retryOnEtagError(ctx,
 				func(setting T) (string, error) {
 					return defn.Update(ctx, c, setting)
 				},
@@ -476,7 +481,7 @@ func makeSettingResource[T, U any](defn genericSettingDefinition[T, U]) common.R
 				if err != nil {
 					return err
 				}
-				etag, err = retryOnEtagError(
+				etag, err = retryOnEtagError(ctx,
 					func(etag string) (string, error) {
 						return defn.Delete(ctx, w, etag)
 					},
@@ -491,7 +496,7 @@ func makeSettingResource[T, U any](defn genericSettingDefinition[T, U]) common.R
 				if err != nil {
 					return err
 				}
-				etag, err = retryOnEtagError(
+				etag, err = retryOnEtagError(ctx,
 					func(etag string) (string, error) {
 						return defn.Delete(ctx, a, etag)
 					},
@@ -503,7 +508,7 @@ func makeSettingResource[T, U any](defn genericSettingDefinition[T, U]) common.R
 				}
 			case accountWorkspaceSettingDefinition[T]:
 				var err error
-				etag, err = retryOnEtagError(
+				etag, err = retryOnEtagError(ctx,
 					func(etag string) (string, error) {
 						return defn.Delete(ctx, c, etag)
 					},
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/service/sharing"
 	"github.com/databricks/terraform-provider-databricks/common"
@@ -21,6 +22,24 @@ func recepientPropertiesSuppressDiff(k, old, new string, d *schema.ResourceData)
 	return false
 }
 
+// currentTokenExpirationTime returns expiration time of the most recent token of the recipient
+func currentTokenExpirationTime(tokens any) int64 {
+	var createdAt, expirationTime int64
+	list, _ := tokens.([]any)
+	for _, v := range list {
+		token, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		tokenCreatedAt := int64(token["created_at"].(int))
+		if tokenCreatedAt >= createdAt {
+			createdAt = tokenCreatedAt
+			expirationTime = int64(token["expiration_time"].(int))
+		}
+	}
+	return expirationTime
+}
+
 func ResourceRecipient() common.Resource {
 	recipientSchema := common.StructToSchema(sharing.RecipientInfo{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		common.CustomizeSchemaPath(s, "authentication_type").SetForceNew().SetRequired().SetValidateFunc(
@@ -42,11 +61,22 @@ func ResourceRecipient() common.Resource {
 		for _, path := range []string{"id", "created_at", "created_by", "activation_url", "expiration_time", "updated_at", "updated_by"} {
 			common.CustomizeSchemaPath(s, "tokens", path).SetReadOnly()
 		}
+		s["rotation"] = common.RotationSchema()
 
 		return s
 	})
 	return common.Resource{
 		Schema: recipientSchema,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			rotation := common.GetRotation(d)
+			if d.Id() == "" || rotation == nil {
+				return nil
+			}
+			if rotation.IsDue(currentTokenExpirationTime(d.Get("tokens")), time.Now()) {
+				return d.SetNewComputed("tokens")
+			}
+			return nil
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			w, err := c.WorkspaceClient()
 			if err != nil {
@@ -89,6 +119,19 @@ func ResourceRecipient() common.Resource {
 			common.DataToStructPointer(d, recipientSchema, &updateRecipientRequest)
 			updateRecipientRequest.Name = d.Id()
 
+			// tokens are unknown in the plan, when the rotation is due
+			tokens, _ := d.GetChange("tokens")
+			if rotation := common.GetRotation(d); rotation != nil && rotation.IsDue(currentTokenExpirationTime(tokens), time.Now()) {
+				log.Printf("[INFO] Rotating token of recipient %s", d.Id())
+				_, err = w.Recipients.RotateToken(ctx, sharing.RotateRecipientToken{
+					Name:                         d.Id(),
+					ExistingTokenExpireInSeconds: int64(rotation.Overlap.Seconds()),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
 			if d.HasChange("owner") {
 				_, err = w.Recipients.Update(ctx, sharing.UpdateRecipient{
 					Name:  updateRecipientRequest.Name,
@@ -2,7 +2,9 @@ package sharing
 
 import (
 	"net/http"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 
@@ -421,3 +423,76 @@ func TestRecipientPropsSuppressDiff(t *testing.T) {
 	assert.True(t, recepientPropertiesSuppressDiff("properties_kvpairs.0.properties.databricks.name", "test", "", nil))
 	assert.False(t, recepientPropertiesSuppressDiff("test", "test", "", nil))
 }
+
+func TestUpdateRecipientRotatesToken(t *testing.T) {
+	now := time.Now()
+	newToken := sharing.RecipientTokenInfo{
+		Id:             "new",
+		CreatedAt:      now.UnixMilli(),
+		ExpirationTime: now.Add(90 * 24 * time.Hour).UnixMilli(),
+	}
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.1/unity-catalog/recipients/a/rotate-token",
+				ExpectedRequest: sharing.RotateRecipientToken{
+					ExistingTokenExpireInSeconds: 86400,
+				},
+				Response: sharing.RecipientInfo{
+					Name: "a",
+				},
+			},
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.1/unity-catalog/recipients/a",
+				ExpectedRequest: sharing.UpdateRecipient{
+					Comment: "b",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.1/unity-catalog/recipients/a?",
+				Response: sharing.RecipientInfo{
+					Name:               "a",
+					Comment:            "b",
+					AuthenticationType: "TOKEN",
+					Owner:              "administrators",
+					Tokens: []sharing.RecipientTokenInfo{
+						{
+							Id:             "old",
+							CreatedAt:      now.Add(-85 * 24 * time.Hour).UnixMilli(),
+							ExpirationTime: now.Add(24 * time.Hour).UnixMilli(),
+						},
+						newToken,
+					},
+				},
+			},
+		},
+		Resource: ResourceRecipient(),
+		Update:   true,
+		ID:       "a",
+		InstanceState: map[string]string{
+			"name":                     "a",
+			"comment":                  "b",
+			"authentication_type":      "TOKEN",
+			"owner":                    "administrators",
+			"tokens.#":                 "1",
+			"tokens.0.id":              "old",
+			"tokens.0.created_at":      strconv.FormatInt(now.Add(-85*24*time.Hour).UnixMilli(), 10),
+			"tokens.0.expiration_time": strconv.FormatInt(now.Add(5*24*time.Hour).UnixMilli(), 10),
+		},
+		HCL: `
+		name = "a"
+		comment = "b"
+		authentication_type = "TOKEN"
+		rotation {
+			rotate_before_expiry = "168h"
+			overlap = "24h"
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"tokens.#":    2,
+		"tokens.1.id": "new",
+	})
+}
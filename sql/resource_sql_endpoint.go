@@ -3,6 +3,8 @@ package sql
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/databricks/databricks-sdk-go"
@@ -52,6 +54,29 @@ func resolveDataSourceID(ctx context.Context, w *databricks.WorkspaceClient, war
 	return "", fmt.Errorf("no data source found for endpoint %s", warehouseId)
 }
 
+// mergeDefaultTags adds `default_tags` of the provider to custom tags of the warehouse, that aren't set explicitly
+func mergeDefaultTags(c *common.DatabricksClient, tags *sql.EndpointTags) *sql.EndpointTags {
+	configured := map[string]string{}
+	if tags != nil {
+		for _, tag := range tags.CustomTags {
+			configured[tag.Key] = tag.Value
+		}
+	}
+	merged := c.MergeDefaultTags(configured)
+	if len(merged) == len(configured) {
+		return tags
+	}
+	if tags == nil {
+		tags = &sql.EndpointTags{}
+	}
+	for _, k := range slices.Sorted(maps.Keys(merged)) {
+		if _, ok := configured[k]; !ok {
+			tags.CustomTags = append(tags.CustomTags, sql.EndpointTagPair{Key: k, Value: merged[k]})
+		}
+	}
+	return tags
+}
+
 func ResourceSqlEndpoint() common.Resource {
 	s := common.StructToSchema(SqlWarehouse{}, func(
 		m map[string]*schema.Schema) map[string]*schema.Schema {
@@ -96,6 +121,7 @@ func ResourceSqlEndpoint() common.Resource {
 			}
 			var se sql.CreateWarehouseRequest
 			common.DataToStructPointer(d, s, &se)
+			se.Tags = mergeDefaultTags(c, se.Tags)
 			common.SetForceSendFields(&se, d, ForceSendFields)
 			wait, err := w.Warehouses.Create(ctx, se)
 			if err != nil {
@@ -135,6 +161,7 @@ func ResourceSqlEndpoint() common.Resource {
 			}
 			var se sql.EditWarehouseRequest
 			common.DataToStructPointer(d, s, &se)
+			se.Tags = mergeDefaultTags(c, se.Tags)
 			common.SetForceSendFields(&se, d, ForceSendFields)
 			se.Id = d.Id()
 			_, err = w.Warehouses.Edit(ctx, se)
@@ -154,5 +181,7 @@ func ResourceSqlEndpoint() common.Resource {
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
 			return d.Clear("health")
 		},
+		WithIdentity: true,
+		TagsPath:     []string{"tags", "custom_tags"},
 	}
 }
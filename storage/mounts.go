@@ -268,6 +268,8 @@ func readMountSource(ctx context.Context, mp MountPoint, d *schema.ResourceData)
 // return resource reader function
 func mountRead(tpl any, r common.Resource) func(context.Context, *schema.ResourceData, *common.DatabricksClient) error {
 	return func(ctx context.Context, d *schema.ResourceData, m *common.DatabricksClient) error {
+		// reading the source of the mount executes a read-only command on the cluster
+		ctx = common.WithReadOnlyCommands(ctx)
 		_, mp, err := mountCluster(ctx, tpl, d, m, r)
 		if err != nil {
 			return err
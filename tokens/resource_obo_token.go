@@ -11,9 +11,9 @@ import (
 )
 
 type OboToken struct {
-	ApplicationID   string `json:"application_id"`
-	LifetimeSeconds int32  `json:"lifetime_seconds,omitempty"`
-	Comment         string `json:"comment,omitempty"`
+	ApplicationID   string `json:"application_id" tf:"force_new"`
+	LifetimeSeconds int32  `json:"lifetime_seconds,omitempty" tf:"force_new"`
+	Comment         string `json:"comment,omitempty" tf:"force_new"`
 }
 
 func NewTokenManagementAPI(ctx context.Context, m any) TokenManagementAPI {
@@ -48,10 +48,19 @@ func ResourceOboToken() common.Resource {
 				Computed:  true,
 				Sensitive: true,
 			}
-			return m
+			m["creation_time"] = &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			}
+			m["expiry_time"] = &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			}
+			return addTokenRotationSchema(m)
 		})
 	return common.Resource{
-		Schema: oboTokenSchema,
+		Schema:        oboTokenSchema,
+		CustomizeDiff: customizeTokenRotationDiff(),
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var request OboToken
 			common.DataToStructPointer(d, oboTokenSchema, &request)
@@ -80,11 +89,26 @@ func ResourceOboToken() common.Resource {
 			if d.Id() != "" {
 				// set comment only if token exists
 				d.Set("comment", ot.TokenInfo.Comment)
+				d.Set("creation_time", ot.TokenInfo.CreationTime)
+				d.Set("expiry_time", ot.TokenInfo.ExpiryTime)
 			}
 			return nil
 		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var request OboToken
+			common.DataToStructPointer(d, oboTokenSchema, &request)
+			api := NewTokenManagementAPI(ctx, c)
+			return rotateToken(ctx, d, func() (TokenResponse, error) {
+				return api.CreateTokenOnBehalfOfServicePrincipal(request)
+			}, api.Delete)
+		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			return NewTokenManagementAPI(ctx, c).Delete(d.Id())
+			api := NewTokenManagementAPI(ctx, c)
+			err := revokePreviousToken(d, api.Delete)
+			if err != nil {
+				return err
+			}
+			return api.Delete(d.Id())
 		},
 	}
 }
@@ -1,6 +1,7 @@
 package tokens
 
 import (
+	"strconv"
 	"testing"
 	"time"
 
@@ -238,3 +239,67 @@ func TestResourceOboTokenCreateNoLifetimeOrComment(t *testing.T) {
 		"id": "bcd",
 	})
 }
+
+func TestResourceOboTokenUpdate_RotationWithoutOverlap(t *testing.T) {
+	now := time.Now()
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token-management/on-behalf-of/tokens",
+				ExpectedRequest: OboToken{
+					ApplicationID:   "abc",
+					LifetimeSeconds: 3600,
+					Comment:         "e",
+				},
+				Response: TokenResponse{
+					TokenValue: "dapi-new",
+					TokenInfo: &TokenInfo{
+						TokenID:      "new",
+						CreationTime: now.UnixMilli(),
+						ExpiryTime:   now.Add(time.Hour).UnixMilli(),
+					},
+				},
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/token-management/tokens/old?",
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token-management/tokens/new",
+				Response: TokenResponse{
+					TokenInfo: &TokenInfo{
+						TokenID:      "new",
+						Comment:      "e",
+						CreationTime: now.UnixMilli(),
+						ExpiryTime:   now.Add(time.Hour).UnixMilli(),
+					},
+				},
+			},
+		},
+		Resource: ResourceOboToken(),
+		Update:   true,
+		ID:       "old",
+		InstanceState: map[string]string{
+			"application_id":   "abc",
+			"comment":          "e",
+			"lifetime_seconds": "3600",
+			"token_value":      "dapi-old",
+			"creation_time":    strconv.FormatInt(now.Add(-50*time.Minute).UnixMilli(), 10),
+			"expiry_time":      strconv.FormatInt(now.Add(10*time.Minute).UnixMilli(), 10),
+		},
+		HCL: `
+		application_id = "abc"
+		comment = "e"
+		lifetime_seconds = 3600
+		rotation {
+			rotate_before_expiry = "15m"
+		}`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                   "new",
+		"token_value":          "dapi-new",
+		"previous_token_id":    "",
+		"previous_token_value": "",
+	})
+}
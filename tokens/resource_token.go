@@ -97,7 +97,7 @@ func (a TokensAPI) Delete(tokenID string) error {
 	return common.IgnoreNotFoundError(err) // ignore not found error on delete, as it is idempotent
 }
 
-// ResourceToken refreshes token in case it's expired
+// ResourceToken refreshes token in case it's expired or rotates it before it expires
 func ResourceToken() common.Resource {
 	s := map[string]*schema.Schema{
 		"lifetime_seconds": {
@@ -131,8 +131,10 @@ func ResourceToken() common.Resource {
 			Computed: true,
 		},
 	}
+	s = addTokenRotationSchema(s)
 	return common.Resource{
-		Schema: s,
+		Schema:        s,
+		CustomizeDiff: customizeTokenRotationDiff("token_id"),
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			comment := d.Get("comment").(string)
 			lifeTimeSeconds := d.Get("lifetime_seconds").(int)
@@ -165,8 +167,20 @@ func ResourceToken() common.Resource {
 			}
 			return nil
 		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			api := NewTokensAPI(ctx, c)
+			return rotateToken(ctx, d, func() (TokenResponse, error) {
+				lifeTimeSeconds := d.Get("lifetime_seconds").(int)
+				return api.Create(time.Duration(lifeTimeSeconds)*time.Second, d.Get("comment").(string))
+			}, api.Delete)
+		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			return NewTokensAPI(ctx, c).Delete(d.Id())
+			api := NewTokensAPI(ctx, c)
+			err := revokePreviousToken(d, api.Delete)
+			if err != nil {
+				return err
+			}
+			return api.Delete(d.Id())
 		},
 	}
 }
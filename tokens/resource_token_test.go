@@ -1,13 +1,18 @@
 package tokens
 
 import (
+	"context"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 
+	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceTokenRead(t *testing.T) {
@@ -322,3 +327,219 @@ func TestResourceTokenDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestResourceTokenUpdate_Rotation(t *testing.T) {
+	now := time.Now()
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/create",
+				ExpectedRequest: TokenRequest{
+					LifetimeSeconds: 2592000,
+					Comment:         "Rotating",
+				},
+				Response: TokenResponse{
+					TokenValue: "dapi-new",
+					TokenInfo: &TokenInfo{
+						TokenID:      "new",
+						CreationTime: now.UnixMilli(),
+						ExpiryTime:   now.Add(30 * 24 * time.Hour).UnixMilli(),
+						Comment:      "Rotating",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token/list",
+				Response: TokenList{
+					TokenInfos: []TokenInfo{
+						{
+							TokenID:      "old",
+							CreationTime: now.Add(-30 * 24 * time.Hour).UnixMilli(),
+							ExpiryTime:   now.Add(time.Hour).UnixMilli(),
+							Comment:      "Rotating",
+						},
+						{
+							TokenID:      "new",
+							CreationTime: now.UnixMilli(),
+							ExpiryTime:   now.Add(30 * 24 * time.Hour).UnixMilli(),
+							Comment:      "Rotating",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceToken(),
+		Update:   true,
+		ID:       "old",
+		InstanceState: map[string]string{
+			"comment":          "Rotating",
+			"lifetime_seconds": "2592000",
+			"token_id":         "old",
+			"token_value":      "dapi-old",
+			"creation_time":    strconv.FormatInt(now.Add(-30*24*time.Hour).UnixMilli(), 10),
+			"expiry_time":      strconv.FormatInt(now.Add(time.Hour).UnixMilli(), 10),
+		},
+		HCL: `
+		comment = "Rotating"
+		lifetime_seconds = 2592000
+		rotation {
+			rotate_before_expiry = "168h"
+			overlap = "24h"
+		}`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                   "new",
+		"token_id":             "new",
+		"token_value":          "dapi-new",
+		"previous_token_id":    "old",
+		"previous_token_value": "dapi-old",
+	})
+}
+
+func TestResourceTokenUpdate_RevokePreviousAfterOverlap(t *testing.T) {
+	now := time.Now()
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/delete",
+				ExpectedRequest: map[string]string{
+					"token_id": "old",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token/list",
+				Response: TokenList{
+					TokenInfos: []TokenInfo{
+						{
+							TokenID:      "new",
+							CreationTime: now.Add(-48 * time.Hour).UnixMilli(),
+							ExpiryTime:   now.Add(28 * 24 * time.Hour).UnixMilli(),
+							Comment:      "Rotating",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceToken(),
+		Update:   true,
+		ID:       "new",
+		InstanceState: map[string]string{
+			"comment":              "Rotating",
+			"lifetime_seconds":     "2592000",
+			"token_id":             "new",
+			"token_value":          "dapi-new",
+			"creation_time":        strconv.FormatInt(now.Add(-48*time.Hour).UnixMilli(), 10),
+			"expiry_time":          strconv.FormatInt(now.Add(28*24*time.Hour).UnixMilli(), 10),
+			"previous_token_id":    "old",
+			"previous_token_value": "dapi-old",
+		},
+		HCL: `
+		comment = "Rotating"
+		lifetime_seconds = 2592000
+		rotation {
+			rotate_before_expiry = "168h"
+			overlap = "24h"
+		}`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                   "new",
+		"token_value":          "dapi-new",
+		"previous_token_id":    "",
+		"previous_token_value": "",
+	})
+}
+
+func TestResourceTokenRotation_NotDue(t *testing.T) {
+	now := time.Now()
+	qa.ResourceFixture{
+		Resource: ResourceToken(),
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"comment":                         "Rotating",
+			"lifetime_seconds":                "2592000",
+			"token_id":                        "abc",
+			"token_value":                     "dapi-abc",
+			"creation_time":                   strconv.FormatInt(now.UnixMilli(), 10),
+			"expiry_time":                     strconv.FormatInt(now.Add(30*24*time.Hour).UnixMilli(), 10),
+			"previous_token_id":               "",
+			"previous_token_value":            "",
+			"rotation.#":                      "1",
+			"rotation.0.rotate_before_expiry": "168h",
+			"rotation.0.overlap":              "24h",
+		},
+		HCL: `
+		comment = "Rotating"
+		lifetime_seconds = 2592000
+		rotation {
+			rotate_before_expiry = "168h"
+			overlap = "24h"
+		}`,
+		ExpectedDiff: map[string]*terraform.ResourceAttrDiff{},
+	}.ApplyNoError(t)
+}
+
+func TestResourceTokenRotation_Due(t *testing.T) {
+	now := time.Now()
+	// the ID isn't set in the instance state of qa.ResourceFixture, so the diff is planned directly
+	state := &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"id":                              "abc",
+			"comment":                         "Rotating",
+			"lifetime_seconds":                "2592000",
+			"token_id":                        "abc",
+			"token_value":                     "dapi-abc",
+			"creation_time":                   strconv.FormatInt(now.Add(-30*24*time.Hour).UnixMilli(), 10),
+			"expiry_time":                     strconv.FormatInt(now.Add(time.Hour).UnixMilli(), 10),
+			"rotation.#":                      "1",
+			"rotation.0.rotate_before_expiry": "168h",
+			"rotation.0.overlap":              "24h",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"comment":          "Rotating",
+		"lifetime_seconds": 2592000,
+		"rotation": []any{map[string]any{
+			"rotate_before_expiry": "168h",
+			"overlap":              "24h",
+		}},
+	})
+	diff, err := ResourceToken().ToResource().Diff(context.Background(), state, config, &common.DatabricksClient{})
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	for _, k := range []string{"token_id", "token_value", "creation_time", "expiry_time",
+		"previous_token_id", "previous_token_value"} {
+		require.Contains(t, diff.Attributes, k)
+		assert.True(t, diff.Attributes[k].NewComputed, k)
+	}
+	assert.Equal(t, "abc", diff.Attributes["token_id"].Old)
+}
+
+func TestResourceTokenDelete_WithPreviousToken(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/delete",
+				ExpectedRequest: map[string]string{
+					"token_id": "old",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/delete",
+				ExpectedRequest: map[string]string{
+					"token_id": "new",
+				},
+			},
+		},
+		Resource: ResourceToken(),
+		Delete:   true,
+		ID:       "new",
+		InstanceState: map[string]string{
+			"previous_token_id": "old",
+		},
+	}.ApplyNoError(t)
+}
@@ -0,0 +1,129 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// attributes, that change when the token is rotated
+var rotatedTokenAttributes = []string{"token_value", "creation_time", "expiry_time",
+	"previous_token_id", "previous_token_value"}
+
+// addTokenRotationSchema adds `rotation` block and attributes with the previous token to the schema
+func addTokenRotationSchema(m map[string]*schema.Schema) map[string]*schema.Schema {
+	m["rotation"] = common.RotationSchema()
+	m["previous_token_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	m["previous_token_value"] = &schema.Schema{
+		Type:      schema.TypeString,
+		Computed:  true,
+		Sensitive: true,
+	}
+	return m
+}
+
+// customizeTokenRotationDiff plans a rotation, when the token is about to expire, and removal of
+// the previous token, when the overlap period has elapsed. Resource-specific attributes, that change
+// with the rotation, like the ID of the token, are passed as arguments.
+func customizeTokenRotationDiff(rotated ...string) func(ctx context.Context, d *schema.ResourceDiff) error {
+	rotated = append(rotated, rotatedTokenAttributes...)
+	return func(ctx context.Context, d *schema.ResourceDiff) error {
+		rotation := common.GetRotation(d)
+		if d.Id() == "" {
+			return nil
+		}
+		if rotation != nil && rotation.IsDue(int64(d.Get("expiry_time").(int)), time.Now()) {
+			for _, k := range rotated {
+				if err := d.SetNewComputed(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if d.Get("previous_token_id").(string) == "" {
+			return nil
+		}
+		if rotation == nil || rotation.OverlapElapsed(int64(d.Get("creation_time").(int)), time.Now()) {
+			for _, k := range []string{"previous_token_id", "previous_token_value"} {
+				if err := d.SetNew(k, ""); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// rotateToken creates a new token, when the current one is about to expire, and keeps the current token
+// as the previous one until the overlap period elapses. Old values are used, because rotated attributes
+// are unknown in the plan.
+func rotateToken(ctx context.Context, d *schema.ResourceData,
+	create func() (TokenResponse, error), revoke func(tokenID string) error) error {
+	rotation := common.GetRotation(d)
+	now := time.Now()
+	expiryTime, _ := d.GetChange("expiry_time")
+	creationTime, _ := d.GetChange("creation_time")
+	currentValue, _ := d.GetChange("token_value")
+	previousID, _ := d.GetChange("previous_token_id")
+	if rotation != nil && rotation.IsDue(int64(expiryTime.(int)), now) {
+		token, err := create()
+		if err != nil {
+			return err
+		}
+		tflog.Info(ctx, fmt.Sprintf("Rotated token %s with %s", d.Id(), token.TokenInfo.TokenID))
+		if previousID.(string) != "" {
+			// token from the rotation before the last one
+			if err = revoke(previousID.(string)); err != nil {
+				return err
+			}
+		}
+		previous := []string{d.Id(), currentValue.(string)}
+		if rotation.Overlap == 0 {
+			if err = revoke(d.Id()); err != nil {
+				return err
+			}
+			previous = []string{"", ""}
+		}
+		d.SetId(token.TokenInfo.TokenID)
+		for k, v := range map[string]any{
+			"token_value":          token.TokenValue,
+			"creation_time":        int(token.TokenInfo.CreationTime),
+			"expiry_time":          int(token.TokenInfo.ExpiryTime),
+			"previous_token_id":    previous[0],
+			"previous_token_value": previous[1],
+		} {
+			if err = d.Set(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if previousID.(string) == "" {
+		return nil
+	}
+	if rotation == nil || rotation.OverlapElapsed(int64(creationTime.(int)), now) {
+		tflog.Info(ctx, fmt.Sprintf("Revoking previous token %s", previousID))
+		if err := revoke(previousID.(string)); err != nil {
+			return err
+		}
+		d.Set("previous_token_id", "")
+		d.Set("previous_token_value", "")
+	}
+	return nil
+}
+
+// revokePreviousToken removes the previous token, if it's still kept after the rotation
+func revokePreviousToken(d *schema.ResourceData, revoke func(tokenID string) error) error {
+	previousID := d.Get("previous_token_id").(string)
+	if previousID == "" {
+		return nil
+	}
+	return revoke(previousID)
+}